@@ -9,6 +9,7 @@ import (
 	"github.com/lgbarn/pdf-cli/internal/cleanup"
 	"github.com/lgbarn/pdf-cli/internal/cli"
 	_ "github.com/lgbarn/pdf-cli/internal/commands" // Register all commands
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
 )
 
 // Version information set by build flags
@@ -28,8 +29,5 @@ func run() int {
 	defer func() { _ = cleanup.Run() }()
 
 	cli.SetVersion(version, commit, date)
-	if err := cli.ExecuteContext(ctx); err != nil {
-		return 1
-	}
-	return 0
+	return pdferrors.ExitCode(cli.ExecuteContext(ctx))
 }
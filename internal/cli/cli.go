@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/lgbarn/pdf-cli/internal/config"
+	"github.com/lgbarn/pdf-cli/internal/logging"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +25,14 @@ func SetVersion(v, c, d string) {
 	buildDate = d
 }
 
+// jsonOutput, outputDir and invertPages are set before a command runs, see
+// JSONOutput, OutputDir and InvertPages
+var (
+	jsonOutput  bool
+	outputDir   string
+	invertPages bool
+)
+
 var rootCmd = &cobra.Command{
 	Use:   "pdf",
 	Short: "A powerful CLI tool for PDF manipulation",
@@ -45,6 +56,8 @@ Examples:
   pdf meta document.pdf
   pdf watermark input.pdf -t "CONFIDENTIAL" -o marked.pdf`,
 	Version: version,
+	// Errors are printed by ExecuteContext once they are classified
+	SilenceErrors: true,
 }
 
 func init() {
@@ -56,24 +69,59 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("force", "f", false, "Overwrite existing files without prompting")
 	rootCmd.PersistentFlags().Bool("progress", cfg.Defaults.ShowProgress, "Show progress bar for long operations")
 	rootCmd.PersistentFlags().Bool("dry-run", false, "Show what would be done without executing")
+	rootCmd.PersistentFlags().Bool("fail-fast", cfg.Defaults.FailFast, "Stop a batch at the first file that fails")
+	rootCmd.PersistentFlags().Bool("continue-on-error", false, "Process all files of a batch even if some fail (overrides defaults.fail_fast)")
+	rootCmd.MarkFlagsMutuallyExclusive("fail-fast", "continue-on-error")
+	rootCmd.PersistentFlags().IntP("jobs", "j", cfg.Performance.MaxWorkers, "Number of files of a batch processed in parallel")
 
 	// Add logging flags
 	AddLoggingFlags(rootCmd)
 
 	// Initialize logging before any command runs
-	rootCmd.PersistentPreRun = func(_ *cobra.Command, _ []string) {
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, _ []string) {
 		InitLogging()
+		jsonOutput = jsonErrors(cmd)
+		outputDir, _ = cmd.Flags().GetString("output-dir")
+		invertPages, _ = cmd.Flags().GetBool("invert")
+		// Keep stderr parseable: the error envelope replaces the usage text
+		if jsonOutput {
+			cmd.SilenceUsage = true
+		}
 	}
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		if jsonErrors(cmd) {
+			cmd.SilenceUsage = true
+		}
+		return err
+	})
 }
 
 // Execute runs the root command
 func Execute() error {
-	return rootCmd.Execute()
+	return ExecuteContext(context.Background())
 }
 
 // ExecuteContext runs the root command with context
 func ExecuteContext(ctx context.Context) error {
-	return rootCmd.ExecuteContext(ctx)
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+	if err == nil {
+		return nil
+	}
+	if cmd != nil && !passwordSupplied(cmd) {
+		err = pdferrors.WithoutPassword(err)
+	}
+	if cmd != nil && jsonErrors(cmd) {
+		fmt.Fprintln(rootCmd.ErrOrStderr(), string(pdferrors.EnvelopeJSON(err)))
+	} else {
+		rootCmd.PrintErrln(rootCmd.ErrPrefix(), err.Error())
+	}
+	return err
+}
+
+// jsonErrors reports whether errors of cmd are printed as JSON, which is
+// the case with --format json or --log-format json.
+func jsonErrors(cmd *cobra.Command) bool {
+	return strings.EqualFold(GetFormat(cmd), "json") || logging.ParseFormat(GetLogFormat()) == logging.FormatJSON
 }
 
 // GetRootCmd returns the root command for testing
@@ -110,6 +158,54 @@ func IsDryRun() bool {
 	return d
 }
 
+// FailFast returns whether batches stop at the first failed file
+func FailFast() bool {
+	if ContinueOnError() {
+		return false
+	}
+	f, _ := rootCmd.PersistentFlags().GetBool("fail-fast")
+	return f
+}
+
+// ContinueOnError returns whether --continue-on-error was given
+func ContinueOnError() bool {
+	c, _ := rootCmd.PersistentFlags().GetBool("continue-on-error")
+	return c
+}
+
+// Jobs returns the number of files of a batch processed in parallel
+func Jobs() int {
+	j, _ := rootCmd.PersistentFlags().GetInt("jobs")
+	return max(1, j)
+}
+
+// Context returns the context of the running command, which is canceled on
+// interrupt
+func Context() context.Context {
+	if ctx := rootCmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
+// JSONOutput returns whether the running command reports errors and batch
+// results as JSON on stderr
+func JSONOutput() bool {
+	return jsonOutput
+}
+
+// OutputDir returns the --output-dir of the running command, or "" if its
+// outputs are written next to the inputs
+func OutputDir() string {
+	return outputDir
+}
+
+// InvertPages returns whether the running command selects the pages that
+// --pages leaves out
+func InvertPages() bool {
+	return invertPages
+}
+
 // DryRunPrint prints a dry-run message to stderr
 func DryRunPrint(format string, args ...interface{}) {
 	fmt.Fprintf(os.Stderr, "[dry-run] "+format+"\n", args...)
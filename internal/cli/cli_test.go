@@ -2,9 +2,14 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/spf13/cobra"
 )
 
@@ -267,6 +272,128 @@ func TestExecuteWithInvalidCommand(t *testing.T) {
 	}
 }
 
+func TestExecuteWrongPasswordWithoutPassword(t *testing.T) {
+	cmd := GetRootCmd()
+	AddCommand(&cobra.Command{
+		Use: "lockedcmd",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return pdferrors.WrapError("reading", "secure.pdf", pdfcpu.ErrWrongPassword)
+		},
+	})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	t.Setenv("CI", "true")
+
+	tests := []struct {
+		name     string
+		password string
+		want     error
+	}{
+		{"no password", "", pdferrors.ErrPasswordRequired},
+		{"password given", "secret", pdferrors.ErrWrongPassword},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PDF_CLI_PASSWORD", tt.password)
+			buf.Reset()
+			cmd.SetArgs([]string{"lockedcmd"})
+			err := Execute()
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Execute() = %v, want %v", err, tt.want)
+			}
+			if !strings.Contains(buf.String(), "Error: reading 'secure.pdf': "+tt.want.Error()) {
+				t.Errorf("Execute() printed %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestExecuteWrongPasswordBatchWithoutPassword(t *testing.T) {
+	cmd := GetRootCmd()
+	AddCommand(&cobra.Command{
+		Use: "lockedbatch",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return pdferrors.NewBatchError([]pdferrors.FileResult{
+				{Input: "ea.pdf", Err: pdferrors.WrapError("compressing", "ea.pdf", pdfcpu.ErrWrongPassword)},
+				{Input: "eb.pdf", Err: pdferrors.WrapError("compressing", "eb.pdf", pdfcpu.ErrWrongPassword)},
+			}, time.Second)
+		},
+	})
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	t.Setenv("CI", "true")
+	t.Setenv("PDF_CLI_PASSWORD", "")
+	defer func() { logFormat = "text" }()
+
+	cmd.SetArgs([]string{"lockedbatch", "--log-format", "json"})
+	if err := Execute(); pdferrors.ExitCode(err) != pdferrors.ExitPasswordRequired {
+		t.Errorf("Execute() = %v, want password required", err)
+	}
+	var env pdferrors.Envelope
+	if err := json.Unmarshal(stderr.Bytes(), &env); err != nil {
+		t.Fatalf("stderr is not a JSON envelope: %v\n%s", err, stderr.String())
+	}
+	if len(env.Results) != 2 {
+		t.Fatalf("envelope = %+v, want two results", env)
+	}
+	for _, r := range env.Results {
+		if r.Error == nil || r.Error.Code != pdferrors.ExitPasswordRequired {
+			t.Errorf("result %s = %+v, want code %d", r.Input, r.Error, pdferrors.ExitPasswordRequired)
+		}
+	}
+}
+
+func TestPasswordSuppliedCertificatePassword(t *testing.T) {
+	t.Setenv("CI", "true")
+	t.Setenv("PDF_CLI_PASSWORD", "")
+	cmd := &cobra.Command{Use: "signcmd"}
+	AddPasswordFileFlag(cmd, "")
+	if err := cmd.Flags().Set("password-file", "pw.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if !passwordSupplied(cmd) {
+		t.Error("passwordSupplied() = false with --password-file")
+	}
+	MarkCertificatePassword(cmd)
+	if passwordSupplied(cmd) {
+		t.Error("passwordSupplied() = true for a certificate password")
+	}
+}
+
+func TestExecuteJSONError(t *testing.T) {
+	cmd := GetRootCmd()
+	AddCommand(&cobra.Command{
+		Use: "failcmd",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return pdferrors.NewBatchError([]pdferrors.FileResult{
+				{Input: "a.pdf"},
+				{Input: "b.pdf", Err: pdferrors.NewPDFError("reading", "b.pdf", pdferrors.ErrCorruptPDF)},
+			}, time.Second)
+		},
+	})
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	defer func() { logFormat = "text" }()
+
+	cmd.SetArgs([]string{"failcmd", "--log-format", "json"})
+	if err := Execute(); pdferrors.ExitCode(err) != pdferrors.ExitPartialFailure {
+		t.Errorf("Execute() = %v, want a partial failure", err)
+	}
+	var env pdferrors.Envelope
+	if err := json.Unmarshal(stderr.Bytes(), &env); err != nil {
+		t.Fatalf("stderr is not a JSON envelope: %v\n%s", err, stderr.String())
+	}
+	if env.Error.Code != pdferrors.ExitPartialFailure || len(env.Results) != 2 || env.Results[1].Status != pdferrors.StatusFailed {
+		t.Errorf("envelope = %+v", env)
+	}
+	if strings.Contains(stderr.String(), "Usage:") {
+		t.Error("usage should not be printed in JSON mode")
+	}
+}
+
 func TestRootCommandDescription(t *testing.T) {
 	cmd := GetRootCmd()
 
@@ -372,3 +499,32 @@ func TestDryRunPrint(t *testing.T) {
 		t.Errorf("DryRunPrint() should end with newline, got %q", output)
 	}
 }
+
+func TestJobs(t *testing.T) {
+	flags := GetRootCmd().PersistentFlags()
+	def := flags.Lookup("jobs").DefValue
+	defer func() { _ = flags.Set("jobs", def) }()
+
+	for _, tt := range []struct {
+		value string
+		want  int
+	}{
+		{"4", 4},
+		{"1", 1},
+		{"0", 1},
+		{"-2", 1},
+	} {
+		if err := flags.Set("jobs", tt.value); err != nil {
+			t.Fatalf("Set(jobs, %s) error = %v", tt.value, err)
+		}
+		if got := Jobs(); got != tt.want {
+			t.Errorf("Jobs() with --jobs %s = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestContextDefaultsToBackground(t *testing.T) {
+	if Context() == nil {
+		t.Fatal("Context() returned nil")
+	}
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"github.com/lgbarn/pdf-cli/internal/fileio"
 	"github.com/lgbarn/pdf-cli/internal/logging"
 	"github.com/spf13/cobra"
 )
@@ -19,12 +20,13 @@ func AddOutputFlag(cmd *cobra.Command, usage string) {
 	cmd.Flags().StringP("output", "o", "", usage)
 }
 
-// AddPagesFlag adds the -p/--pages flag to a command
+// AddPagesFlag adds the -p/--pages and --invert flags to a command
 func AddPagesFlag(cmd *cobra.Command, usage string) {
 	if usage == "" {
 		usage = "Page range (e.g., 1-5,7,10-12)"
 	}
 	cmd.Flags().StringP("pages", "p", "", usage)
+	cmd.Flags().Bool("invert", false, "Select every page except those given with --pages")
 }
 
 // AddPasswordFlag adds the --password flag to a command
@@ -43,6 +45,21 @@ func AddPasswordFileFlag(cmd *cobra.Command, usage string) {
 	cmd.Flags().String("password-file", "", usage)
 }
 
+// Command annotation recording what the password sources of a command open
+const (
+	passwordAnnotation  = "password"
+	certificatePassword = "certificate"
+)
+
+// MarkCertificatePassword records that the password flags, PDF_CLI_PASSWORD
+// and the prompt of cmd open a certificate, not the input document.
+func MarkCertificatePassword(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[passwordAnnotation] = certificatePassword
+}
+
 // AddAllowInsecurePasswordFlag adds the --allow-insecure-password flag to a command.
 func AddAllowInsecurePasswordFlag(cmd *cobra.Command) {
 	cmd.Flags().Bool("allow-insecure-password", false, "Allow use of insecure --password flag")
@@ -54,16 +71,56 @@ func GetAllowInsecurePassword(cmd *cobra.Command) bool {
 	return allow
 }
 
+// AddOutputDirFlag adds the --output-dir flag to a command with the
+// -o/--output flag; the two cannot be combined
+func AddOutputDirFlag(cmd *cobra.Command) {
+	cmd.Flags().String("output-dir", "", "Write outputs below this directory, mirroring the input paths")
+	cmd.MarkFlagsMutuallyExclusive("output", "output-dir")
+}
+
+// AddBatchInputFlags adds the -r/--recursive, --include, --exclude and
+// --files-from flags to a command that takes many input files
+func AddBatchInputFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("recursive", "r", false, "Process the PDF files below directory arguments")
+	cmd.Flags().StringArray("include", nil, "Only process found files matching the glob pattern (repeatable, default *.pdf)")
+	cmd.Flags().StringArray("exclude", nil, "Skip found files and directories matching the glob pattern (repeatable)")
+	cmd.Flags().String("files-from", "", "Read input files from a list, one per line (- for stdin)")
+}
+
+// GetInputOptions gets the recursive, include and exclude flag values.
+func GetInputOptions(cmd *cobra.Command) fileio.InputOptions {
+	var opts fileio.InputOptions
+	opts.Recursive, _ = cmd.Flags().GetBool("recursive")
+	opts.Include, _ = cmd.Flags().GetStringArray("include")
+	opts.Exclude, _ = cmd.Flags().GetStringArray("exclude")
+	return opts
+}
+
+// GetFilesFrom gets the files-from flag value.
+func GetFilesFrom(cmd *cobra.Command) string {
+	filesFrom, _ := cmd.Flags().GetString("files-from")
+	return filesFrom
+}
+
+// BatchArgs requires at least one input argument unless the inputs are
+// read with --files-from.
+func BatchArgs(cmd *cobra.Command, args []string) error {
+	if GetFilesFrom(cmd) != "" {
+		return nil
+	}
+	return cobra.MinimumNArgs(1)(cmd, args)
+}
+
 // GetOutput gets the output flag value
 func GetOutput(cmd *cobra.Command) string {
 	output, _ := cmd.Flags().GetString("output")
 	return output
 }
 
-// GetPages gets the pages flag value
+// GetPages gets the pages flag value; see InvertPages for --invert.
 func GetPages(cmd *cobra.Command) string {
-	pages, _ := cmd.Flags().GetString("pages")
-	return pages
+	p, _ := cmd.Flags().GetString("pages")
+	return p
 }
 
 // GetPassword gets the password flag value
@@ -79,7 +136,7 @@ func GetPasswordSecure(cmd *cobra.Command, promptMsg string) (string, error) {
 
 // AddFormatFlag adds the --format flag to a command for structured output.
 func AddFormatFlag(cmd *cobra.Command) {
-	cmd.Flags().String("format", "", "Output format: json, csv, tsv (default: human-readable)")
+	cmd.Flags().String("format", "", "Output format: json, csv, tsv, yaml (default: human-readable)")
 }
 
 // GetFormat gets the format flag value.
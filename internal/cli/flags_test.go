@@ -107,7 +107,7 @@ func TestAddFormatFlag(t *testing.T) {
 	if flag.DefValue != "" {
 		t.Errorf("default = %q, want empty", flag.DefValue)
 	}
-	if flag.Usage != "Output format: json, csv, tsv (default: human-readable)" {
+	if flag.Usage != "Output format: json, csv, tsv, yaml (default: human-readable)" {
 		t.Errorf("usage = %q", flag.Usage)
 	}
 }
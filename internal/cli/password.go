@@ -98,6 +98,21 @@ To use --password anyway (not recommended), add --allow-insecure-password`)
 	return "", nil
 }
 
+// passwordSupplied reports whether cmd got a document password from one of
+// the sources of ReadPassword. Commands marked with
+// MarkCertificatePassword never get one.
+func passwordSupplied(cmd *cobra.Command) bool {
+	if cmd.Annotations[passwordAnnotation] == certificatePassword {
+		return false
+	}
+	for _, name := range []string{"password-file", "password"} {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Value.String() != "" {
+			return true
+		}
+	}
+	return os.Getenv("PDF_CLI_PASSWORD") != "" || isInteractiveTerminal()
+}
+
 // isInteractiveTerminal returns true if stdin is an interactive terminal and not in CI/batch mode.
 func isInteractiveTerminal() bool {
 	return term.IsTerminal(int(os.Stdin.Fd())) && os.Getenv("CI") == "" && os.Getenv("PDF_CLI_BATCH") == ""
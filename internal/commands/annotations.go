@@ -0,0 +1,316 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lgbarn/pdf-cli/internal/cli"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/output"
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cli.AddCommand(annotationsCmd)
+	annotationsCmd.AddCommand(annotationsListCmd)
+	annotationsCmd.AddCommand(annotationsRemoveCmd)
+	annotationsCmd.AddCommand(annotationsFlattenCmd)
+
+	cli.AddPagesFlag(annotationsListCmd, "Pages to list annotations from (default: all)")
+	cli.AddPasswordFlag(annotationsListCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(annotationsListCmd, "")
+	cli.AddAllowInsecurePasswordFlag(annotationsListCmd)
+	cli.AddFormatFlag(annotationsListCmd)
+	annotationsListCmd.Flags().StringSlice("type", nil, "Annotation types to list, e.g. Text,Highlight (default: all)")
+
+	for _, cmd := range []*cobra.Command{annotationsRemoveCmd, annotationsFlattenCmd} {
+		cli.AddOutputFlag(cmd, "Output file path (only with single file)")
+		cli.AddOutputDirFlag(cmd)
+		cli.AddBatchInputFlags(cmd)
+		cli.AddPagesFlag(cmd, "Pages to process (default: all)")
+		cli.AddPasswordFlag(cmd, "Password for encrypted PDFs")
+		cli.AddPasswordFileFlag(cmd, "")
+		cli.AddAllowInsecurePasswordFlag(cmd)
+	}
+	annotationsRemoveCmd.Flags().StringSlice("type", nil, "Annotation types to remove, e.g. Link,Highlight (default: all)")
+	annotationsFlattenCmd.Flags().StringSlice("type", nil, "Annotation types to flatten, e.g. Text,Highlight (default: all)")
+}
+
+var annotationsCmd = &cobra.Command{
+	Use:   "annotations",
+	Short: "List, remove and flatten annotations",
+	Long: `Work with the annotations of a PDF: comments, highlights, stamps,
+links and other markup.
+
+Available subcommands:
+  list    - List annotations with type, page, author, contents and rectangle
+  remove  - Remove annotations
+  flatten - Draw annotations into the page content and remove them
+
+Form fields are not affected; use 'pdf form fill --flatten' for them.`,
+}
+
+var annotationsListCmd = &cobra.Command{
+	Use:   "list <file.pdf>",
+	Short: "List annotations",
+	Long: `List the annotations of a PDF with their type, page, author,
+contents and rectangle ([llx lly urx ury] in points).
+
+Examples:
+  pdf annotations list document.pdf
+  pdf annotations list document.pdf --type Text,Highlight -p 1-3
+  pdf annotations list document.pdf --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAnnotationsList,
+}
+
+var annotationsRemoveCmd = &cobra.Command{
+	Use:   "remove <file.pdf> [file2.pdf...]",
+	Short: "Remove annotations",
+	Long: `Remove annotations from PDF file(s), for example reviewers' comments
+before the files go to clients.
+
+Without --type, every annotation except form fields is removed. The
+pop-up window of a removed comment is removed with it.
+
+Supports batch processing of multiple files. When processing
+multiple files, output files are named with '_unannotated' suffix.
+
+Examples:
+  pdf annotations remove review.pdf -o clean.pdf
+  pdf annotations remove review.pdf --type Link,Highlight -p 1-3 -o out.pdf
+  pdf annotations remove *.pdf --type Text,Popup`,
+	Args: cli.BatchArgs,
+	RunE: runAnnotationsRemove,
+}
+
+var annotationsFlattenCmd = &cobra.Command{
+	Use:   "flatten <file.pdf> [file2.pdf...]",
+	Short: "Draw annotations into the page content",
+	Long: `Draw annotations into the page content of PDF file(s) and remove
+them, so that they look the same but can no longer be edited.
+
+Without --type, every annotation that has an appearance is flattened;
+annotations without one, such as most links, are kept. Hidden
+annotations are removed without being drawn. Form fields are not
+affected.
+
+Supports batch processing of multiple files. When processing
+multiple files, output files are named with '_flattened' suffix.
+
+Examples:
+  pdf annotations flatten review.pdf -o final.pdf
+  pdf annotations flatten review.pdf --type Stamp,Ink -o signed.pdf`,
+	Args: cli.BatchArgs,
+	RunE: runAnnotationsFlatten,
+}
+
+// annotationTypes returns the annotation types given with --type.
+func annotationTypes(cmd *cobra.Command) ([]string, error) {
+	names, _ := cmd.Flags().GetStringSlice("type")
+	types := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		t, err := pdf.AnnotationType(name)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+func runAnnotationsList(cmd *cobra.Command, args []string) error {
+	args, err := sanitizeInputArgs(args)
+	if err != nil {
+		return err
+	}
+
+	pagesStr := cli.GetPages(cmd)
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	formatter := output.NewOutputFormatter(cli.GetFormat(cmd))
+
+	types, err := annotationTypes(cmd)
+	if err != nil {
+		return err
+	}
+
+	inputFile := args[0]
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return err
+	}
+
+	pageNums, err := parseAndValidatePages(pagesStr, inputFile, password)
+	if err != nil {
+		return err
+	}
+
+	cli.PrintVerbose("Reading annotations from %s", inputFile)
+
+	annotations, err := pdf.ListAnnotations(inputFile, pdf.AnnotationFilter{Types: types, Pages: pageNums}, password)
+	if err != nil {
+		return pdferrors.WrapError("reading annotations", inputFile, err)
+	}
+
+	switch formatter.Format {
+	case output.FormatJSON, output.FormatYAML:
+		if annotations == nil {
+			annotations = []pdf.Annotation{}
+		}
+		return formatter.Print(annotations)
+	case output.FormatHuman:
+		if len(annotations) == 0 {
+			fmt.Println("No annotations found")
+			return nil
+		}
+	}
+
+	headers := []string{"page", "type", "author", "contents", "rect"}
+	rows := make([][]string, 0, len(annotations))
+	for _, a := range annotations {
+		contents := a.Contents
+		if formatter.Format == output.FormatHuman {
+			contents = strings.Join(strings.Fields(contents), " ")
+		}
+		rows = append(rows, []string{strconv.Itoa(a.Page), a.Type, a.Author, contents, a.Rect.String()})
+	}
+	return formatter.PrintTable(headers, rows)
+}
+
+func runAnnotationsRemove(cmd *cobra.Command, args []string) error {
+	return runAnnotationsEdit(cmd, args, false)
+}
+
+func runAnnotationsFlatten(cmd *cobra.Command, args []string) error {
+	return runAnnotationsEdit(cmd, args, true)
+}
+
+// runAnnotationsEdit removes or, with flatten, flattens the selected annotations.
+func runAnnotationsEdit(cmd *cobra.Command, args []string, flatten bool) error {
+	args, err := batchInputs(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	pagesStr := cli.GetPages(cmd)
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	output, err := sanitizeOutputPath(cli.GetOutput(cmd))
+	if err != nil {
+		return err
+	}
+
+	types, err := annotationTypes(cmd)
+	if err != nil {
+		return err
+	}
+	for _, t := range types {
+		if t == "Widget" {
+			return fmt.Errorf("form fields cannot be %s here; use 'pdf form fill --flatten'", annotationsVerb(flatten, "removed", "flattened"))
+		}
+	}
+
+	suffix := annotationsVerb(flatten, SuffixUnannotated, SuffixFlattened)
+
+	// Handle dry-run mode
+	if cli.IsDryRun() {
+		return annotationsDryRun(args, output, pagesStr, password, types, flatten)
+	}
+
+	if err := validateBatchOutput(args, output, suffix); err != nil {
+		return err
+	}
+
+	return processBatch(args, output, suffix, func(w io.Writer, inputFile, out string) error {
+		if err := fileio.ValidatePDFFile(inputFile); err != nil {
+			return err
+		}
+
+		pageNums, err := parseAndValidatePages(pagesStr, inputFile, password)
+		if err != nil {
+			return err
+		}
+
+		if err := checkOutputFile(out); err != nil {
+			return err
+		}
+
+		filter := pdf.AnnotationFilter{Types: types, Pages: pageNums}
+		if flatten {
+			cli.PrintVerbose("Flattening annotations of %s", inputFile)
+			n, err := pdf.FlattenAnnotations(inputFile, out, filter, password)
+			if err != nil {
+				return pdferrors.WrapError("flattening annotations", inputFile, err)
+			}
+			fmt.Fprintf(w, "Flattened %d annotations in %s\n", n, out)
+			return nil
+		}
+
+		cli.PrintVerbose("Removing annotations from %s", inputFile)
+		n, err := pdf.RemoveAnnotations(inputFile, out, filter, password)
+		if err != nil {
+			return pdferrors.WrapError("removing annotations", inputFile, err)
+		}
+		fmt.Fprintf(w, "Removed %d annotations from %s\n", n, out)
+		return nil
+	})
+}
+
+// annotationsVerb returns flattened if flatten is set and removed otherwise.
+func annotationsVerb(flatten bool, removed, flattened string) string {
+	if flatten {
+		return flattened
+	}
+	return removed
+}
+
+func annotationsDryRun(args []string, explicitOutput, pagesStr, password string, types []string, flatten bool) error {
+	verb := annotationsVerb(flatten, "remove", "flatten")
+	suffix := annotationsVerb(flatten, SuffixUnannotated, SuffixFlattened)
+	for _, inputFile := range args {
+		pageNums, err := parseAndValidatePages(pagesStr, inputFile, password)
+		if err != nil {
+			cli.DryRunPrint("Would %s annotations: %s (%v)", verb, inputFile, err)
+			continue
+		}
+		annotations, err := pdf.ListAnnotations(inputFile, pdf.AnnotationFilter{Types: types, Pages: pageNums}, password)
+		if err != nil {
+			cli.DryRunPrint("Would %s annotations: %s (unable to read annotations)", verb, inputFile)
+			continue
+		}
+
+		counts := map[string]int{}
+		total := 0
+		for _, a := range annotations {
+			if a.Type == "Widget" || (len(types) == 0 && a.Type == "Popup") {
+				continue
+			}
+			counts[a.Type]++
+			total++
+		}
+		cli.DryRunPrint("Would %s annotations: %s (%d selected)", verb, inputFile, total)
+		names := make([]string, 0, len(counts))
+		for name := range counts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			cli.DryRunPrint("  %s: %d", name, counts[name])
+		}
+		cli.DryRunPrint("  Output: %s", outputOrDefault(explicitOutput, inputFile, suffix))
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+)
+
+func annotationsPDF() string {
+	return filepath.Join(testdataDir(), "annotations.pdf")
+}
+
+func TestAnnotationsRemoveCommand(t *testing.T) {
+	resetFlags(t)
+	out := filepath.Join(t.TempDir(), "clean.pdf")
+	if err := executeCommand("annotations", "remove", annotationsPDF(), "--type", "text,popup", "-p", "1-3", "-o", out); err != nil {
+		t.Fatalf("annotations remove failed: %v", err)
+	}
+	annots, err := pdf.ListAnnotations(out, pdf.AnnotationFilter{}, "")
+	if err != nil {
+		t.Fatalf("ListAnnotations() error = %v", err)
+	}
+	for _, a := range annots {
+		if a.Type == "Text" || a.Type == "Popup" {
+			t.Errorf("comment not removed: %+v", a)
+		}
+	}
+	if len(annots) != 3 {
+		t.Errorf("got %d annotations, want 3", len(annots))
+	}
+}
+
+func TestAnnotationsFlattenCommand(t *testing.T) {
+	resetFlags(t)
+	out := filepath.Join(t.TempDir(), "flat.pdf")
+	if err := executeCommand("annotations", "flatten", annotationsPDF(), "-o", out); err != nil {
+		t.Fatalf("annotations flatten failed: %v", err)
+	}
+	annots, err := pdf.ListAnnotations(out, pdf.AnnotationFilter{}, "")
+	if err != nil {
+		t.Fatalf("ListAnnotations() error = %v", err)
+	}
+	if len(annots) != 2 {
+		t.Errorf("got %d annotations, want the 2 links", len(annots))
+	}
+}
+
+func TestAnnotationsCommandErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"unknown type", []string{"annotations", "list", annotationsPDF(), "--type", "Sticky"}, "unknown annotation type"},
+		{"widgets", []string{"annotations", "remove", annotationsPDF(), "--type", "Widget"}, "form fill"},
+		{"output with several files", []string{"annotations", "flatten", annotationsPDF(), samplePDF(), "-o", "out.pdf"}, "cannot use -o"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags(t)
+			err := executeCommand(tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
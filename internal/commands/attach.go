@@ -0,0 +1,406 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lgbarn/pdf-cli/internal/cli"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/output"
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cli.AddCommand(attachCmd)
+	attachCmd.AddCommand(attachListCmd)
+	attachCmd.AddCommand(attachAddCmd)
+	attachCmd.AddCommand(attachExtractCmd)
+	attachCmd.AddCommand(attachRemoveCmd)
+
+	cli.AddPasswordFlag(attachListCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(attachListCmd, "")
+	cli.AddAllowInsecurePasswordFlag(attachListCmd)
+	cli.AddFormatFlag(attachListCmd)
+
+	cli.AddOutputFlag(attachAddCmd, "Output file path")
+	cli.AddPasswordFlag(attachAddCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(attachAddCmd, "")
+	cli.AddAllowInsecurePasswordFlag(attachAddCmd)
+	attachAddCmd.Flags().String("name", "", "Name of the attachment in the PDF (only with a single file)")
+	attachAddCmd.Flags().String("description", "", "Description of the attachment(s)")
+	attachAddCmd.Flags().String("mime-type", "", "MIME type of the attachment(s) (default: from file extension)")
+	attachAddCmd.Flags().String("relationship", "", "Associate the file with the document: "+strings.Join(pdf.AttachmentRelationships, ", "))
+
+	cli.AddOutputFlag(attachExtractCmd, "Output directory for extracted files")
+	cli.AddPasswordFlag(attachExtractCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(attachExtractCmd, "")
+	cli.AddAllowInsecurePasswordFlag(attachExtractCmd)
+
+	cli.AddOutputFlag(attachRemoveCmd, "Output file path")
+	cli.AddPasswordFlag(attachRemoveCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(attachRemoveCmd, "")
+	cli.AddAllowInsecurePasswordFlag(attachRemoveCmd)
+	attachRemoveCmd.Flags().Bool("all", false, "Remove all attachments")
+}
+
+var attachCmd = &cobra.Command{
+	Use:   "attach",
+	Short: "Manage files embedded in a PDF",
+	Long: `Manage files embedded in a PDF (attachments), such as the XML invoice
+data of a ZUGFeRD or Factur-X invoice.
+
+Available subcommands:
+  list    - List the embedded files
+  add     - Embed files in a PDF
+  extract - Save embedded files to a directory
+  remove  - Remove embedded files`,
+}
+
+var attachListCmd = &cobra.Command{
+	Use:   "list <file.pdf>",
+	Short: "List embedded files",
+	Long: `List the files embedded in a PDF with their name, size, MIME type,
+relationship to the document, description and modification date.
+
+Examples:
+  pdf attach list invoice.pdf
+  pdf attach list invoice.pdf --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAttachList,
+}
+
+var attachAddCmd = &cobra.Command{
+	Use:   "add <file.pdf> <file> [file2...]",
+	Short: "Embed files in a PDF",
+	Long: `Embed one or more files in a PDF.
+
+Each file is attached under its base name, or the name given with --name.
+Adding a file under a name that is already attached is an error.
+
+--relationship records the file as an associated file of the document
+with the given relationship, as ZUGFeRD and Factur-X invoices require for
+their XML data (usually 'Alternative' or 'Data').
+
+Output defaults to the input name with the '_attached' suffix.
+
+Unlike batch commands, attach works on a single PDF and takes the files
+to embed as given: it has no --recursive, --include, --exclude or
+--files-from.
+
+Examples:
+  pdf attach add report.pdf data.csv -o report_with_data.pdf
+  pdf attach add report.pdf notes.txt --description "Review notes"
+  pdf attach add invoice.pdf factur-x.xml --relationship Alternative -o invoice_zugferd.pdf`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runAttachAdd,
+}
+
+var attachExtractCmd = &cobra.Command{
+	Use:   "extract <file.pdf> [name...]",
+	Short: "Save embedded files to a directory",
+	Long: `Save the files embedded in a PDF to the output directory (default:
+the current directory). Without names, all embedded files are saved.
+
+Files are written under the base name of the attachment; any directory
+components of the name are dropped.
+
+Examples:
+  pdf attach extract invoice.pdf -o attachments/
+  pdf attach extract invoice.pdf factur-x.xml`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runAttachExtract,
+}
+
+var attachRemoveCmd = &cobra.Command{
+	Use:   "remove <file.pdf> [name...]",
+	Short: "Remove embedded files",
+	Long: `Remove the named embedded files from a PDF, or all of them with --all.
+
+Output defaults to the input name with the '_detached' suffix.
+
+Examples:
+  pdf attach remove report.pdf data.csv -o report.pdf -f
+  pdf attach remove report.pdf --all`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runAttachRemove,
+}
+
+func runAttachList(cmd *cobra.Command, args []string) error {
+	args, err := sanitizeInputArgs(args)
+	if err != nil {
+		return err
+	}
+
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	formatter := output.NewOutputFormatter(cli.GetFormat(cmd))
+
+	inputFile := args[0]
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return err
+	}
+
+	cli.PrintVerbose("Reading attachments from %s", inputFile)
+
+	attachments, err := pdf.ListAttachments(inputFile, password)
+	if err != nil {
+		return pdferrors.WrapError("reading attachments", inputFile, err)
+	}
+
+	switch formatter.Format {
+	case output.FormatJSON, output.FormatYAML:
+		if attachments == nil {
+			attachments = []pdf.Attachment{}
+		}
+		return formatter.Print(attachments)
+	case output.FormatHuman:
+		if len(attachments) == 0 {
+			fmt.Println("No attachments found")
+			return nil
+		}
+	}
+
+	headers := []string{"name", "size", "type", "relationship", "description", "modified"}
+	rows := make([][]string, 0, len(attachments))
+	for _, a := range attachments {
+		size := strconv.FormatInt(a.Size, 10)
+		if formatter.Format == output.FormatHuman {
+			size = fileio.FormatFileSize(a.Size)
+		}
+		modified := ""
+		if a.Modified != nil {
+			modified = a.Modified.Format(time.RFC3339)
+		}
+		rows = append(rows, []string{a.Name, size, a.MIMEType, a.Relationship, a.Description, modified})
+	}
+	return formatter.PrintTable(headers, rows)
+}
+
+func runAttachAdd(cmd *cobra.Command, args []string) error {
+	args, err := sanitizeInputArgs(args)
+	if err != nil {
+		return err
+	}
+
+	outputFile, err := sanitizeOutputPath(cli.GetOutput(cmd))
+	if err != nil {
+		return err
+	}
+
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+
+	name, _ := cmd.Flags().GetString("name")
+	description, _ := cmd.Flags().GetString("description")
+	mimeType, _ := cmd.Flags().GetString("mime-type")
+	relationship, _ := cmd.Flags().GetString("relationship")
+
+	inputFile, paths := args[0], args[1:]
+	if name != "" && len(paths) > 1 {
+		return fmt.Errorf("cannot use --name with multiple files")
+	}
+	if relationship != "" {
+		relationship, err = attachmentRelationship(relationship)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return err
+	}
+	files := make([]pdf.AttachmentFile, len(paths))
+	for i, path := range paths {
+		if !fileio.FileExists(path) {
+			return fmt.Errorf("file not found: %s", path)
+		}
+		files[i] = pdf.AttachmentFile{Path: path, Name: name, Description: description, MIMEType: mimeType, Relationship: relationship}
+	}
+
+	outputFile = outputOrDefault(outputFile, inputFile, SuffixAttached)
+
+	if cli.IsDryRun() {
+		cli.DryRunPrint("Would attach %d file(s) to %s", len(files), inputFile)
+		for _, f := range files {
+			attached := f.Name
+			if attached == "" {
+				attached = filepath.Base(f.Path)
+			}
+			cli.DryRunPrint("  %s", attached)
+		}
+		cli.DryRunPrint("  Output: %s", outputFile)
+		return nil
+	}
+
+	if err := checkOutputFile(outputFile); err != nil {
+		return err
+	}
+
+	cli.PrintVerbose("Attaching %d file(s) to %s", len(files), inputFile)
+
+	if err := pdf.AddAttachments(inputFile, outputFile, files, password); err != nil {
+		return pdferrors.WrapError("adding attachments", inputFile, err)
+	}
+
+	fmt.Printf("Attached %d file(s): %s\n", len(files), outputFile)
+	return nil
+}
+
+// attachmentRelationship returns the relationship name matching s, ignoring case.
+func attachmentRelationship(s string) (string, error) {
+	for _, r := range pdf.AttachmentRelationships {
+		if strings.EqualFold(r, s) {
+			return r, nil
+		}
+	}
+	return "", fmt.Errorf("invalid relationship %q: use %s", s, strings.Join(pdf.AttachmentRelationships, ", "))
+}
+
+func runAttachExtract(cmd *cobra.Command, args []string) error {
+	// Sanitize input path
+	sanitizedPath, err := fileio.SanitizePath(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid file path: %w", err)
+	}
+	inputFile, names := sanitizedPath, args[1:]
+
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return err
+	}
+
+	outputDir := cli.GetOutput(cmd)
+	if outputDir == "" {
+		outputDir = "."
+	}
+
+	// Sanitize output directory path
+	outputDir, err = fileio.SanitizePath(outputDir)
+	if err != nil {
+		return fmt.Errorf("invalid output path: %w", err)
+	}
+
+	cli.PrintVerbose("Extracting attachments from %s to %s", inputFile, outputDir)
+
+	attachments, err := pdf.ExtractAttachments(inputFile, names, password)
+	if err != nil {
+		return pdferrors.WrapError("extracting attachments", inputFile, err)
+	}
+	if len(attachments) == 0 {
+		return fmt.Errorf("%s has no attachments", inputFile)
+	}
+
+	outputs, err := attachmentOutputs(outputDir, attachments)
+	if err != nil {
+		return err
+	}
+
+	if cli.IsDryRun() {
+		cli.DryRunPrint("Would extract %d file(s) from %s", len(attachments), inputFile)
+		for _, out := range outputs {
+			cli.DryRunPrint("  %s", out)
+		}
+		return nil
+	}
+
+	for _, out := range outputs {
+		if err := checkOutputFile(out); err != nil {
+			return err
+		}
+	}
+
+	if err := fileio.EnsureDir(outputDir); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for i, a := range attachments {
+		if err := os.WriteFile(outputs[i], a.Data, fileio.DefaultFilePerm); err != nil {
+			return fmt.Errorf("failed to write %s: %w", outputs[i], err)
+		}
+		fmt.Printf("Extracted: %s\n", outputs[i])
+	}
+	return nil
+}
+
+// attachmentOutputs returns the file path in outputDir for each attachment.
+// Attachments whose names map to the same file are an error.
+func attachmentOutputs(outputDir string, attachments []pdf.Attachment) ([]string, error) {
+	outputs := make([]string, len(attachments))
+	seen := map[string]string{}
+	for i, a := range attachments {
+		base := pdf.AttachmentFileName(a.Name)
+		if other, ok := seen[base]; ok {
+			return nil, fmt.Errorf("attachments %q and %q would both be saved as %s", other, a.Name, base)
+		}
+		seen[base] = a.Name
+		outputs[i] = filepath.Join(outputDir, base)
+	}
+	return outputs, nil
+}
+
+func runAttachRemove(cmd *cobra.Command, args []string) error {
+	// Sanitize input path
+	sanitizedPath, err := fileio.SanitizePath(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid file path: %w", err)
+	}
+	inputFile, names := sanitizedPath, args[1:]
+
+	outputFile, err := sanitizeOutputPath(cli.GetOutput(cmd))
+	if err != nil {
+		return err
+	}
+
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+
+	all, _ := cmd.Flags().GetBool("all")
+	if all == (len(names) > 0) {
+		return fmt.Errorf("specify the attachments to remove or --all")
+	}
+
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return err
+	}
+
+	outputFile = outputOrDefault(outputFile, inputFile, SuffixDetached)
+
+	if cli.IsDryRun() {
+		if all {
+			cli.DryRunPrint("Would remove all attachments from %s", inputFile)
+		} else {
+			cli.DryRunPrint("Would remove %s from %s", strings.Join(names, ", "), inputFile)
+		}
+		cli.DryRunPrint("  Output: %s", outputFile)
+		return nil
+	}
+
+	if err := checkOutputFile(outputFile); err != nil {
+		return err
+	}
+
+	cli.PrintVerbose("Removing attachments from %s", inputFile)
+
+	if err := pdf.RemoveAttachments(inputFile, outputFile, names, password); err != nil {
+		return pdferrors.WrapError("removing attachments", inputFile, err)
+	}
+
+	fmt.Printf("Removed attachments: %s\n", outputFile)
+	return nil
+}
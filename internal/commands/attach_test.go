@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+)
+
+func TestAttachCommands(t *testing.T) {
+	resetFlags(t)
+	tmpDir := t.TempDir()
+
+	xml := filepath.Join(tmpDir, "factur-x.xml")
+	if err := os.WriteFile(xml, []byte("<invoice/>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	attached := filepath.Join(tmpDir, "invoice.pdf")
+	if err := executeCommand("attach", "add", samplePDF(), xml, "--relationship", "data", "-o", attached); err != nil {
+		t.Fatalf("attach add failed: %v", err)
+	}
+
+	attachments, err := pdf.ListAttachments(attached, "")
+	if err != nil {
+		t.Fatalf("ListAttachments() error = %v", err)
+	}
+	if len(attachments) != 1 || attachments[0].Relationship != "Data" {
+		t.Errorf("attachments = %+v", attachments)
+	}
+
+	resetFlags(t)
+	outDir := filepath.Join(tmpDir, "out")
+	if err := executeCommand("attach", "extract", attached, "-o", outDir); err != nil {
+		t.Fatalf("attach extract failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(outDir, "factur-x.xml"))
+	if err != nil || string(data) != "<invoice/>" {
+		t.Errorf("extracted data = %q, %v", data, err)
+	}
+
+	resetFlags(t)
+	err = executeCommand("attach", "extract", attached, "-o", outDir)
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected overwrite error, got %v", err)
+	}
+
+	resetFlags(t)
+	detached := filepath.Join(tmpDir, "detached.pdf")
+	if err := executeCommand("attach", "remove", attached, "--all", "-o", detached); err != nil {
+		t.Fatalf("attach remove failed: %v", err)
+	}
+	if attachments, _ := pdf.ListAttachments(detached, ""); len(attachments) != 0 {
+		t.Errorf("attachments after remove = %+v", attachments)
+	}
+}
+
+func TestAttachCommandErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"invalid relationship", []string{"attach", "add", samplePDF(), samplePDF(), "--relationship", "owner"}, "invalid relationship"},
+		{"name with several files", []string{"attach", "add", samplePDF(), samplePDF(), samplePDF(), "--name", "x.pdf"}, "--name"},
+		{"remove without names", []string{"attach", "remove", samplePDF()}, "--all"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags(t)
+			err := executeCommand(tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestAttachmentOutputs(t *testing.T) {
+	attachments := []pdf.Attachment{{Name: "a/data.xml"}, {Name: "b/data.xml"}}
+	if _, err := attachmentOutputs("out", attachments); err == nil {
+		t.Error("expected error for attachments saved under the same name")
+	}
+
+	outputs, err := attachmentOutputs("out", attachments[:1])
+	if err != nil || outputs[0] != filepath.Join("out", "data.xml") {
+		t.Errorf("attachmentOutputs() = %v, %v", outputs, err)
+	}
+}
@@ -0,0 +1,234 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/lgbarn/pdf-cli/internal/cli"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/output"
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cli.AddCommand(batesCmd)
+	cli.AddOutputFlag(batesCmd, "Output file path (only with single file)")
+	cli.AddOutputDirFlag(batesCmd)
+	cli.AddBatchInputFlags(batesCmd)
+	cli.AddPasswordFlag(batesCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(batesCmd, "")
+	cli.AddAllowInsecurePasswordFlag(batesCmd)
+	batesCmd.Flags().String("prefix", "", "Text before each number (e.g., ACME)")
+	batesCmd.Flags().String("suffix", "", "Text after each number")
+	batesCmd.Flags().Int("start", 1, "First Bates number")
+	batesCmd.Flags().Int("digits", 6, "Minimum number of digits, padded with zeros")
+	batesCmd.Flags().String("position", "bottom-right", "Position: top-left, top, top-right, bottom-left, bottom, bottom-right")
+	addStampStyleFlags(batesCmd)
+	batesCmd.Flags().String("log", "", "Write a CSV log of the number range of each file to this path")
+}
+
+var batesCmd = &cobra.Command{
+	Use:   "bates <file.pdf> [file2.pdf...]",
+	Short: "Stamp Bates numbers on PDF(s)",
+	Long: `Stamp sequential Bates numbers on every page of PDF file(s).
+
+Numbers continue across files in the order they are given, so a
+set of documents is numbered as one production. The files below a
+directory (with -r) are numbered in lexical order. Each number is the
+prefix, the page number padded to --digits, and the suffix.
+
+With --log, a CSV log with the first and last number of each file
+is written to the given path.
+
+Processing stops at the first file that fails, so numbers are never
+skipped, unless --continue-on-error is given; the files after a failed
+file then continue the numbering without it. Output files are named
+with '_bates' suffix unless -o is given for a single file.
+
+Examples:
+  pdf bates production/*.pdf --prefix ACME --start 1 --digits 6
+  pdf bates contract.pdf --prefix "DEF-" --start 1001 -o contract-bates.pdf
+  pdf bates *.pdf --prefix ACME --position bottom --log acme-log.csv
+  pdf bates -r production --prefix ACME --output-dir stamped`,
+	Args: cli.BatchArgs,
+	RunE: runBates,
+}
+
+// batesLogEntry records the number range stamped on one file.
+type batesLogEntry struct {
+	file   string
+	output string
+	first  string
+	last   string
+	pages  int
+}
+
+func runBates(cmd *cobra.Command, args []string) error {
+	args, err := batchInputs(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	explicitOutput, err := sanitizeOutputPath(cli.GetOutput(cmd))
+	if err != nil {
+		return err
+	}
+
+	opts, start, err := batesOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	logPath, _ := cmd.Flags().GetString("log")
+	if logPath != "" {
+		if logPath, err = fileio.SanitizePath(logPath); err != nil {
+			return fmt.Errorf("invalid log path: %w", err)
+		}
+	}
+
+	// Handle dry-run mode
+	if cli.IsDryRun() {
+		return batesDryRun(args, explicitOutput, logPath, password, opts, start)
+	}
+
+	if err := validateBatchOutput(args, explicitOutput, SuffixBates); err != nil {
+		return err
+	}
+	if logPath != "" {
+		if err := checkOutputFile(logPath); err != nil {
+			return err
+		}
+	}
+
+	jobs := make([]batchJob, len(args))
+	for i, file := range args {
+		jobs[i] = batchJob{input: file, output: outputOrDefault(explicitOutput, file, SuffixBates)}
+	}
+	var entries []batesLogEntry
+	next := start
+	runErr := runBatch(jobs, true, !cli.ContinueOnError(), func(i int, w io.Writer) error {
+		entry, err := batesFile(w, jobs[i].input, jobs[i].output, password, opts, next)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+		next += entry.pages
+		return nil
+	})
+
+	if logPath != "" && len(entries) > 0 {
+		if err := writeBatesLog(logPath, entries); err != nil {
+			return err
+		}
+		cli.PrintVerbose("Wrote Bates log to %s", logPath)
+	}
+	return runErr
+}
+
+// batesOptions builds Bates options and the start number from the command flags.
+func batesOptions(cmd *cobra.Command) (pdf.BatesOptions, int, error) {
+	flags := cmd.Flags()
+	prefix, _ := flags.GetString("prefix")
+	suffix, _ := flags.GetString("suffix")
+	start, _ := flags.GetInt("start")
+	digits, _ := flags.GetInt("digits")
+	position, _ := flags.GetString("position")
+
+	if start < 0 {
+		return pdf.BatesOptions{}, 0, fmt.Errorf("invalid start number %d: must not be negative", start)
+	}
+	if digits < 1 || digits > 20 {
+		return pdf.BatesOptions{}, 0, fmt.Errorf("invalid digits %d: must be between 1 and 20", digits)
+	}
+
+	style, err := stampStyle(cmd, position)
+	if err != nil {
+		return pdf.BatesOptions{}, 0, err
+	}
+
+	return pdf.BatesOptions{Prefix: prefix, Suffix: suffix, Digits: digits, Style: style}, start, nil
+}
+
+func batesDryRun(args []string, explicitOutput, logPath, password string, opts pdf.BatesOptions, start int) error {
+	next := start
+	for _, inputFile := range args {
+		pageCount, err := pdf.PageCount(inputFile, password)
+		if err != nil {
+			cli.DryRunPrint("Would stamp Bates numbers: %s (unable to read info, numbering stops here)", inputFile)
+			return nil
+		}
+
+		output := outputOrDefault(explicitOutput, inputFile, SuffixBates)
+		cli.DryRunPrint("Would stamp Bates numbers: %s (%d pages)", inputFile, pageCount)
+		cli.DryRunPrint("  Range: %s - %s",
+			pdf.FormatBatesNumber(opts.Prefix, opts.Suffix, next, opts.Digits),
+			pdf.FormatBatesNumber(opts.Prefix, opts.Suffix, next+pageCount-1, opts.Digits))
+		cli.DryRunPrint("  Output: %s", output)
+		next += pageCount
+	}
+	if logPath != "" {
+		cli.DryRunPrint("Would write log: %s", logPath)
+	}
+	return nil
+}
+
+func batesFile(w io.Writer, inputFile, output, password string, opts pdf.BatesOptions, first int) (batesLogEntry, error) {
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return batesLogEntry{}, err
+	}
+
+	if err := checkOutputFile(output); err != nil {
+		return batesLogEntry{}, err
+	}
+	if cli.OutputDir() != "" {
+		if err := fileio.EnsureParentDir(output); err != nil {
+			return batesLogEntry{}, fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	cli.PrintVerbose("Stamping Bates numbers on %s starting at %s", inputFile,
+		pdf.FormatBatesNumber(opts.Prefix, opts.Suffix, first, opts.Digits))
+
+	pages, err := pdf.AddBatesNumbers(inputFile, output, first, opts, password)
+	if err != nil {
+		return batesLogEntry{}, pdferrors.WrapError("stamping Bates numbers", inputFile, err)
+	}
+
+	entry := batesLogEntry{
+		file:   inputFile,
+		output: output,
+		first:  pdf.FormatBatesNumber(opts.Prefix, opts.Suffix, first, opts.Digits),
+		last:   pdf.FormatBatesNumber(opts.Prefix, opts.Suffix, first+pages-1, opts.Digits),
+		pages:  pages,
+	}
+	fmt.Fprintf(w, "Stamped %s - %s on %s\n", entry.first, entry.last, output)
+	return entry, nil
+}
+
+// writeBatesLog writes the number range of each stamped file as CSV.
+func writeBatesLog(path string, entries []batesLogEntry) error {
+	var buf bytes.Buffer
+	formatter := &output.OutputFormatter{Format: output.FormatCSV, Writer: &buf}
+
+	headers := []string{"file", "output", "first", "last", "pages"}
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, []string{e.file, e.output, e.first, e.last, strconv.Itoa(e.pages)})
+	}
+	if err := formatter.PrintTable(headers, rows); err != nil {
+		return fmt.Errorf("failed to format Bates log: %w", err)
+	}
+
+	if err := fileio.AtomicWrite(path, buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write Bates log: %w", err)
+	}
+	return nil
+}
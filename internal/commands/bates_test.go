@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+)
+
+func TestBatesCommand_NumbersAcrossFiles(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	var inputs []string
+	for _, name := range []string{"a.pdf", "b.pdf"} {
+		data, err := os.ReadFile(samplePDF())
+		if err != nil {
+			t.Fatalf("Failed to read sample: %v", err)
+		}
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatalf("Failed to write input: %v", err)
+		}
+		inputs = append(inputs, path)
+	}
+
+	logPath := filepath.Join(tmpDir, "log.csv")
+	args := append([]string{"bates"}, inputs...)
+	args = append(args, "--prefix", "ACME", "--start", "41", "--digits", "6", "--log", logPath)
+	if err := executeCommand(args...); err != nil {
+		t.Fatalf("bates failed: %v", err)
+	}
+
+	for _, name := range []string{"a_bates.pdf", "b_bates.pdf"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); err != nil {
+			t.Errorf("expected output %s: %v", name, err)
+		}
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("Failed to read log: %v", err)
+	}
+	log := string(data)
+	for _, want := range []string{
+		"file,output,first,last,pages",
+		"ACME000041,ACME000043,3",
+		"ACME000044,ACME000046,3",
+	} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log missing %q:\n%s", want, log)
+		}
+	}
+}
+
+func TestBatesCommand_FailedFile(t *testing.T) {
+	data, err := os.ReadFile(samplePDF())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(t.TempDir())
+	for _, name := range []string{"a.pdf", "c.pdf"} {
+		if err := os.WriteFile(name, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile("b.pdf", []byte("not a pdf"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	defer resetFlags(t)
+
+	// Numbering stops at the failed file by default
+	resetFlags(t)
+	err = executeCommand("bates", "a.pdf", "b.pdf", "c.pdf", "--log", "stop.csv")
+	var batchErr *pdferrors.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("bates error = %v, want a BatchError", err)
+	}
+	if got := batchErr.Results[2].Status(); got != pdferrors.StatusSkipped {
+		t.Errorf("file after the failure has status %s, want skipped", got)
+	}
+	if _, err := os.Stat("c_bates.pdf"); err == nil {
+		t.Error("c.pdf was stamped after the failure")
+	}
+
+	// With --continue-on-error the next file continues the numbering
+	resetFlags(t)
+	err = executeCommand("bates", "a.pdf", "b.pdf", "c.pdf", "--log", "continue.csv", "-f", "--continue-on-error")
+	if !errors.As(err, &batchErr) || batchErr.Results[2].Status() != pdferrors.StatusOK {
+		t.Fatalf("bates --continue-on-error error = %v", err)
+	}
+	log, err := os.ReadFile("continue.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(log), "c.pdf,c_bates.pdf,000004,000006,3") {
+		t.Errorf("log does not continue the numbering after the failed file:\n%s", log)
+	}
+}
+
+func TestBatesCommand_NoLogByDefault(t *testing.T) {
+	data, err := os.ReadFile(samplePDF())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(t.TempDir())
+	for _, name := range []string{"a.pdf", "b.pdf"} {
+		if err := os.WriteFile(name, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer resetFlags(t)
+
+	resetFlags(t)
+	if err := executeCommand("bates", "a.pdf", "b.pdf", "--prefix", "X"); err != nil {
+		t.Fatalf("bates failed: %v", err)
+	}
+	resetFlags(t)
+	if err := executeCommand("bates", "a.pdf", "--prefix", "X", "--output-dir", "out"); err != nil {
+		t.Fatalf("second bates run into a new output directory failed: %v", err)
+	}
+	if _, err := os.Stat("out/a.pdf"); err != nil {
+		t.Errorf("expected output out/a.pdf: %v", err)
+	}
+	if _, err := os.Stat("bates.csv"); err == nil {
+		t.Error("bates wrote a log without --log")
+	}
+}
+
+func TestBatesCommand_InvalidDigits(t *testing.T) {
+	resetFlags(t)
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output := filepath.Join(tmpDir, "out.pdf")
+	if err := executeCommand("bates", samplePDF(), "--digits", "0", "-o", output, "--log", ""); err == nil {
+		t.Error("expected error for --digits 0")
+	}
+}
+
+func TestBatesCommand_DryRun(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	if err := executeCommand("bates", samplePDF(), "--prefix", "ACME", "--dry-run"); err != nil {
+		t.Fatalf("bates --dry-run failed: %v", err)
+	}
+}
+
+func TestHeaderFooterCommands(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"header", []string{"header", "--left", "ACME", "--right", "{date}"}},
+		{"footer", []string{"footer", "--center", "Page {page} of {pages}"}},
+		{"footer with pages", []string{"footer", "--right", "{filename}", "-p", "2-end"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags(t)
+			if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+				t.Skip("sample.pdf not found in testdata")
+			}
+
+			tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tmpDir)
+
+			output := filepath.Join(tmpDir, "stamped.pdf")
+			args := append([]string{tt.args[0], samplePDF(), "-o", output}, tt.args[1:]...)
+			if err := executeCommand(args...); err != nil {
+				t.Fatalf("%v failed: %v", tt.args, err)
+			}
+			if _, err := os.Stat(output); err != nil {
+				t.Errorf("expected output file: %v", err)
+			}
+		})
+	}
+}
+
+func TestFooterCommand_NoText(t *testing.T) {
+	resetFlags(t)
+	if err := executeCommand("footer", samplePDF(), "-o", "unused.pdf"); err == nil {
+		t.Error("expected error without --left, --center or --right")
+	}
+}
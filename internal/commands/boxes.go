@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/lgbarn/pdf-cli/internal/cli"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/output"
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cli.AddCommand(boxesCmd)
+	cli.AddPasswordFlag(boxesCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(boxesCmd, "")
+	cli.AddAllowInsecurePasswordFlag(boxesCmd)
+	cli.AddFormatFlag(boxesCmd)
+}
+
+var boxesCmd = &cobra.Command{
+	Use:   "boxes <file.pdf>",
+	Short: "List page boxes of a PDF",
+	Long: `List the page boundaries of every page in a PDF file.
+
+Shows the MediaBox, CropBox, TrimBox, BleedBox and ArtBox of each
+page as [llx lly urx ury] in points (1/72 inch). Boxes that are not
+set explicitly are reported with their inherited effective value.
+
+Use "-" to read from stdin.
+
+Examples:
+  pdf boxes document.pdf
+  pdf boxes document.pdf --format json
+  pdf boxes document.pdf --format csv > boxes.csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBoxes,
+}
+
+// PageBoxesOutput represents the page boundaries of one page for structured output.
+type PageBoxesOutput struct {
+	Page     int      `json:"page"`
+	Rotation int      `json:"rotation"`
+	MediaBox pdf.Rect `json:"media_box"`
+	CropBox  pdf.Rect `json:"crop_box"`
+	TrimBox  pdf.Rect `json:"trim_box"`
+	BleedBox pdf.Rect `json:"bleed_box"`
+	ArtBox   pdf.Rect `json:"art_box"`
+}
+
+func runBoxes(cmd *cobra.Command, args []string) error {
+	inputArg, err := fileio.SanitizePath(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid file path: %w", err)
+	}
+
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	formatter := output.NewOutputFormatter(cli.GetFormat(cmd))
+
+	inputFile, cleanup, err := fileio.ResolveInputPath(inputArg)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if !fileio.IsStdinInput(inputArg) {
+		if err := fileio.ValidatePDFFile(inputFile); err != nil {
+			return err
+		}
+	}
+
+	cli.PrintVerbose("Reading page boxes from %s", inputFile)
+
+	boxes, err := pdf.GetPageBoxes(inputFile, password)
+	if err != nil {
+		return pdferrors.WrapError("reading page boxes", inputArg, err)
+	}
+
+	if formatter.Format == output.FormatJSON {
+		outputs := make([]PageBoxesOutput, len(boxes))
+		for i, b := range boxes {
+			outputs[i] = PageBoxesOutput{
+				Page:     b.Page,
+				Rotation: b.Rotation,
+				MediaBox: b.MediaBox,
+				CropBox:  b.CropBox,
+				TrimBox:  b.TrimBox,
+				BleedBox: b.BleedBox,
+				ArtBox:   b.ArtBox,
+			}
+		}
+		return formatter.Print(outputs)
+	}
+
+	headers := []string{"page", "rotation", "media_box", "crop_box", "trim_box", "bleed_box", "art_box"}
+	rows := make([][]string, 0, len(boxes))
+	for _, b := range boxes {
+		rows = append(rows, []string{
+			strconv.Itoa(b.Page),
+			strconv.Itoa(b.Rotation),
+			b.MediaBox.String(),
+			b.CropBox.String(),
+			b.TrimBox.String(),
+			b.BleedBox.String(),
+			b.ArtBox.String(),
+		})
+	}
+	return formatter.PrintTable(headers, rows)
+}
@@ -1,10 +1,20 @@
 package commands
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/lgbarn/pdf-cli/internal/cli"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
 )
 
 func TestOutputOrDefault(t *testing.T) {
@@ -86,6 +96,23 @@ func TestAllCommandsRegistered(t *testing.T) {
 		"meta",
 		"watermark",
 		"pdfa",
+		"crop",
+		"resize",
+		"boxes",
+		"insert",
+		"delete",
+		"bates",
+		"header",
+		"footer",
+		"redact",
+		"sanitize",
+		"form",
+		"attach",
+		"annotations",
+		"links",
+		"signatures",
+		"sign",
+		"unlock",
 		"completion",
 	}
 
@@ -132,12 +159,22 @@ func TestCommandFlags(t *testing.T) {
 		{"extract", []string{"output", "pages", "stdout"}},
 		{"rotate", []string{"output", "angle", "pages"}},
 		{"compress", []string{"output", "stdout"}},
-		{"encrypt", []string{"output", "password", "owner-password"}},
-		{"decrypt", []string{"output", "password", "stdout"}},
+		{"encrypt", []string{"output", "password", "owner-password", "recipient"}},
+		{"decrypt", []string{"output", "password", "stdout", "cert", "key"}},
 		{"text", []string{"pages"}},
-		{"meta", []string{"format"}},
-		{"watermark", []string{"text", "image", "pages"}},
+		{"meta", []string{"format", "title", "created", "modified", "set", "clear", "xmp"}},
+		{"watermark", []string{"text", "image", "pages", "font", "font-size", "color", "opacity", "rotation", "scale", "position", "offset", "background", "remove", "list", "format"}},
 		{"reorder", []string{"output", "stdout"}},
+		{"crop", []string{"output", "box", "pages", "stdout"}},
+		{"resize", []string{"output", "size", "scale", "fit", "pages"}},
+		{"boxes", []string{"format", "password"}},
+		{"delete", []string{"output", "pages", "invert", "stdout"}},
+		{"insert", []string{"output", "at", "after", "from", "blank", "size", "stdout"}},
+		{"bates", []string{"output", "prefix", "suffix", "start", "digits", "position", "margin", "font", "font-size", "color", "log"}},
+		{"header", []string{"output", "pages", "left", "center", "right", "margin", "font", "font-size", "color"}},
+		{"redact", []string{"output", "pages", "pattern", "pattern-file", "color"}},
+		{"sanitize", []string{"output", "annotations"}},
+		{"footer", []string{"output", "pages", "left", "center", "right", "margin", "font", "font-size", "color"}},
 	}
 
 	rootCmd := cli.GetRootCmd()
@@ -179,20 +216,32 @@ func TestValidateBatchOutput(t *testing.T) {
 }
 
 func TestProcessBatch(t *testing.T) {
+	// The processors record calls in order, so process one file at a time
+	resetFlags(t)
+	if err := cli.GetRootCmd().PersistentFlags().Set("jobs", "1"); err != nil {
+		t.Fatal(err)
+	}
+	defer resetFlags(t)
+
 	// Test with all successful
-	files := []string{"file1", "file2", "file3"}
-	successProcessor := func(file string) error {
+	files := []string{"file1.pdf", "file2.pdf", "file3.pdf"}
+	var outputs []string
+	successProcessor := func(_ io.Writer, input, output string) error {
+		outputs = append(outputs, output)
 		return nil
 	}
 
-	err := processBatch(files, successProcessor)
+	err := processBatch(files, "", SuffixRotated, successProcessor)
 	if err != nil {
 		t.Errorf("processBatch() with all success should not error, got %v", err)
 	}
+	if len(outputs) != 3 || outputs[0] != "file1_rotated.pdf" {
+		t.Errorf("processBatch() outputs = %v", outputs)
+	}
 
 	// Test with some failures
 	failedCount := 0
-	failProcessor := func(file string) error {
+	failProcessor := func(_ io.Writer, input, output string) error {
 		failedCount++
 		if failedCount <= 2 {
 			return nil
@@ -200,8 +249,307 @@ func TestProcessBatch(t *testing.T) {
 		return os.ErrNotExist
 	}
 
-	err = processBatch(files, failProcessor)
+	err = processBatch(files, "", "", failProcessor)
 	if err == nil {
 		t.Error("processBatch() with failure should return error")
 	}
+	var batchErr *pdferrors.BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Results) != 3 || batchErr.Results[2].Status() != pdferrors.StatusFailed {
+		t.Errorf("processBatch() error = %#v, want the result of every file", err)
+	}
+	if code := pdferrors.ExitCode(err); code != pdferrors.ExitPartialFailure {
+		t.Errorf("exit code = %d, want %d", code, pdferrors.ExitPartialFailure)
+	}
+}
+
+func TestProcessBatchFailFast(t *testing.T) {
+	files := []string{"file1.pdf", "file2.pdf", "file3.pdf"}
+	var processed []string
+	processor := func(_ io.Writer, input, _ string) error {
+		processed = append(processed, input)
+		if input == "file2.pdf" {
+			return errors.New("boom")
+		}
+		return nil
+	}
+
+	resetFlags(t)
+	flags := cli.GetRootCmd().PersistentFlags()
+	if err := flags.Set("fail-fast", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := flags.Set("jobs", "1"); err != nil {
+		t.Fatal(err)
+	}
+	defer resetFlags(t)
+
+	err := processBatch(files, "", "", processor)
+	var batchErr *pdferrors.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("processBatch() error = %v, want a BatchError", err)
+	}
+	if len(processed) != 2 || batchErr.Results[2].Status() != pdferrors.StatusSkipped {
+		t.Errorf("--fail-fast processed %v, results %+v", processed, batchErr.Results)
+	}
+}
+
+func TestProcessBatchParallel(t *testing.T) {
+	resetFlags(t)
+	if err := cli.GetRootCmd().PersistentFlags().Set("jobs", "3"); err != nil {
+		t.Fatal(err)
+	}
+	defer resetFlags(t)
+
+	// Every file waits until all three run at once, which only
+	// happens with three workers
+	files := []string{"file1.pdf", "file2.pdf", "file3.pdf"}
+	var running sync.WaitGroup
+	running.Add(len(files))
+	err := processBatch(files, "", "", func(_ io.Writer, input, _ string) error {
+		running.Done()
+		running.Wait()
+		if input == "file1.pdf" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	var batchErr *pdferrors.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("processBatch() error = %v, want a BatchError", err)
+	}
+	for i, r := range batchErr.Results {
+		if r.Input != files[i] {
+			t.Errorf("result %d is for %s, want %s", i, r.Input, files[i])
+		}
+	}
+	if batchErr.Results[0].Status() != pdferrors.StatusFailed || batchErr.Results[2].Status() != pdferrors.StatusOK {
+		t.Errorf("results = %+v, want only file1.pdf failed", batchErr.Results)
+	}
+}
+
+func TestProcessBatchOutputOrder(t *testing.T) {
+	resetFlags(t)
+	if err := cli.GetRootCmd().PersistentFlags().Set("jobs", "3"); err != nil {
+		t.Fatal(err)
+	}
+	defer resetFlags(t)
+
+	stdout, err := os.Create(filepath.Join(t.TempDir(), "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdout.Close()
+	saved := os.Stdout
+	os.Stdout = stdout
+	defer func() { os.Stdout = saved }()
+
+	// file1.pdf finishes last, but its output still comes first
+	files := []string{"file1.pdf", "file2.pdf", "file3.pdf"}
+	lastDone := make(chan struct{})
+	err = processBatch(files, "", "", func(w io.Writer, input, _ string) error {
+		switch input {
+		case "file1.pdf":
+			<-lastDone
+		case "file3.pdf":
+			defer close(lastDone)
+		}
+		fmt.Fprintf(w, "%s: line 1\n%s: line 2\n", input, input)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("processBatch() error = %v", err)
+	}
+
+	os.Stdout = saved
+	got, err := os.ReadFile(stdout.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "file1.pdf: line 1\nfile1.pdf: line 2\nfile2.pdf: line 1\nfile2.pdf: line 2\nfile3.pdf: line 1\nfile3.pdf: line 2\n"
+	if string(got) != want {
+		t.Errorf("batch output = %q, want %q", got, want)
+	}
+}
+
+func TestProcessBatchCanceled(t *testing.T) {
+	resetFlags(t)
+	if err := cli.GetRootCmd().PersistentFlags().Set("jobs", "1"); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cli.GetRootCmd().SetContext(ctx)
+	defer func() {
+		cli.GetRootCmd().SetContext(context.Background())
+		resetFlags(t)
+	}()
+
+	files := []string{"file1.pdf", "file2.pdf", "file3.pdf"}
+	var processed []string
+	err := processBatch(files, "", "", func(_ io.Writer, input, _ string) error {
+		processed = append(processed, input)
+		cancel()
+		return nil
+	})
+
+	var batchErr *pdferrors.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("processBatch() error = %v, want a BatchError", err)
+	}
+	if len(processed) != 1 || !errors.Is(batchErr.Results[1].Err, context.Canceled) {
+		t.Errorf("canceled batch processed %v, results %+v", processed, batchErr.Results)
+	}
+}
+
+func TestBatchSwitchesExclusive(t *testing.T) {
+	resetFlags(t)
+	defer resetFlags(t)
+	err := executeCommand("info", samplePDF(), "--fail-fast", "--continue-on-error")
+	if err == nil || !strings.Contains(err.Error(), "fail-fast") {
+		t.Errorf("--fail-fast with --continue-on-error error = %v", err)
+	}
+}
+
+func TestInfoBatchReportsFailures(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.pdf")
+	for _, format := range []string{"", "json"} {
+		resetFlags(t)
+		args := []string{"info", samplePDF(), missing}
+		if format != "" {
+			args = append(args, "--format", format)
+		}
+		err := executeCommand(args...)
+		var batchErr *pdferrors.BatchError
+		if !errors.As(err, &batchErr) || batchErr.Results[1].Input != missing {
+			t.Errorf("info --format %q error = %v, want the missing file reported", format, err)
+		}
+	}
+}
+
+func TestCommandExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	corrupt := filepath.Join(dir, "corrupt.pdf")
+	if err := os.WriteFile(corrupt, []byte("not a pdf"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	existing := filepath.Join(dir, "existing.pdf")
+	notes := filepath.Join(dir, "notes.txt")
+	for _, f := range []string{existing, notes} {
+		if err := os.WriteFile(f, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sample := filepath.Join(dir, "sample.pdf")
+	data, err := os.ReadFile(samplePDF())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(sample, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	encrypted := encryptedSample(t, "tiger7")
+	output := filepath.Join(dir, "out.pdf")
+
+	tests := []struct {
+		name     string
+		password string
+		args     []string
+		want     int
+	}{
+		{"success", "", []string{"info", samplePDF()}, pdferrors.ExitOK},
+		{"file not found", "", []string{"info", filepath.Join(dir, "none.pdf")}, pdferrors.ExitFileNotFound},
+		{"not a PDF", "", []string{"info", notes}, pdferrors.ExitInvalidPDF},
+		{"corrupt PDF", "", []string{"info", corrupt}, pdferrors.ExitInvalidPDF},
+		{"wrong password", "lion", []string{"info", encrypted}, pdferrors.ExitWrongPassword},
+		{"encrypted input", "", []string{"merge", "-o", output, encrypted, samplePDF()}, pdferrors.ExitPasswordRequired},
+		{"output exists", "", []string{"rotate", samplePDF(), "-o", existing}, pdferrors.ExitOutputExists},
+		{"invalid pages", "", []string{"rotate", samplePDF(), "-p", "99", "-o", output}, pdferrors.ExitInvalidPages},
+		{"partial batch", "", []string{"compress", sample, corrupt}, pdferrors.ExitPartialFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PDF_CLI_PASSWORD", tt.password)
+			resetFlags(t)
+			err := executeCommand(tt.args...)
+			if got := pdferrors.ExitCode(err); got != tt.want {
+				t.Errorf("exit code = %d, want %d (error: %v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestBatchDirectoryInputs(t *testing.T) {
+	data, err := os.ReadFile(samplePDF())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(t.TempDir())
+	for _, f := range []string{"docs/a.pdf", "docs/sub/b.pdf", "docs/archive/c.pdf"} {
+		if err := os.MkdirAll(filepath.Dir(f), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(f, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer resetFlags(t)
+
+	resetFlags(t)
+	if err := executeCommand("rotate", "docs"); err == nil || !strings.Contains(err.Error(), "--recursive") {
+		t.Errorf("rotate of a directory without -r error = %v", err)
+	}
+
+	resetFlags(t)
+	if err := executeCommand("rotate", "-r", "docs", "--exclude", "archive", "--output-dir", "out"); err != nil {
+		t.Fatalf("rotate -r --output-dir error = %v", err)
+	}
+	for _, f := range []string{"out/docs/a.pdf", "out/docs/sub/b.pdf"} {
+		if !fileio.FileExists(f) {
+			t.Errorf("%s was not written", f)
+		}
+	}
+	if fileio.FileExists("out/docs/archive/c.pdf") || fileio.FileExists("docs/a_rotated.pdf") {
+		t.Error("rotate wrote outputs for excluded files or next to the inputs")
+	}
+
+	resetFlags(t)
+	if err := os.WriteFile("list.txt", []byte("docs/a.pdf\ndocs/archive/c.pdf\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := executeCommand("compress", "--files-from", "list.txt"); err != nil {
+		t.Fatalf("compress --files-from error = %v", err)
+	}
+	for _, f := range []string{"docs/a_compressed.pdf", "docs/archive/c_compressed.pdf"} {
+		if !fileio.FileExists(f) {
+			t.Errorf("%s was not written", f)
+		}
+	}
+
+	resetFlags(t)
+	if err := executeCommand("rotate", "docs/a.pdf", "--output-dir", "."); err == nil || !strings.Contains(err.Error(), "overwrite") {
+		t.Errorf("--output-dir over the input error = %v", err)
+	}
+
+	resetFlags(t)
+	if err := executeCommand("merge", "-r", "docs", "--exclude", "archive", "--exclude", "*_compressed.pdf", "-o", "merged.pdf"); err != nil {
+		t.Fatalf("merge -r error = %v", err)
+	}
+	if n, err := pdf.PageCount("merged.pdf", ""); err != nil || n != 6 {
+		t.Errorf("merged page count = %d, %v; want 6", n, err)
+	}
+
+	resetFlags(t)
+	if err := executeCommand("merge", "docs/sub/*.pdf", "-o", "one.pdf"); err == nil {
+		t.Error("merge of a single found file: expected error")
+	}
+
+	resetFlags(t)
+	if err := executeCommand("bates", "-r", "docs", "--exclude", "*_compressed.pdf", "--output-dir", "stamped", "--log", "stamped/log.csv"); err != nil {
+		t.Fatalf("bates -r --output-dir error = %v", err)
+	}
+	for _, f := range []string{"stamped/docs/a.pdf", "stamped/docs/archive/c.pdf", "stamped/docs/sub/b.pdf", "stamped/log.csv"} {
+		if !fileio.FileExists(f) {
+			t.Errorf("%s was not written", f)
+		}
+	}
 }
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/lgbarn/pdf-cli/internal/cli"
@@ -15,6 +16,8 @@ import (
 func init() {
 	cli.AddCommand(compressCmd)
 	cli.AddOutputFlag(compressCmd, "Output file path (only with single file)")
+	cli.AddOutputDirFlag(compressCmd)
+	cli.AddBatchInputFlags(compressCmd)
 	cli.AddPasswordFlag(compressCmd, "Password for encrypted PDFs")
 	cli.AddPasswordFileFlag(compressCmd, "")
 	cli.AddAllowInsecurePasswordFlag(compressCmd)
@@ -38,13 +41,14 @@ Examples:
   pdf compress large.pdf -o smaller.pdf
   pdf compress document.pdf
   pdf compress *.pdf                      # Batch compress
+  pdf compress -r docs --output-dir small # Compress a tree into small/docs/...
   cat input.pdf | pdf compress - --stdout > out.pdf  # stdin/stdout`,
-	Args: cobra.MinimumNArgs(1),
+	Args: cli.BatchArgs,
 	RunE: runCompress,
 }
 
 func runCompress(cmd *cobra.Command, args []string) error {
-	args, err := sanitizeInputArgs(args)
+	args, err := batchInputs(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -75,8 +79,8 @@ func runCompress(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	return processBatch(args, func(inputFile string) error {
-		return compressFile(inputFile, output, password)
+	return processBatch(args, output, SuffixCompressed, func(w io.Writer, inputFile, out string) error {
+		return compressFile(w, inputFile, out, password)
 	})
 }
 
@@ -137,7 +141,7 @@ func compressWithStdio(inputArg, explicitOutput, password string, toStdout bool)
 	return nil
 }
 
-func compressFile(inputFile, explicitOutput, password string) error {
+func compressFile(w io.Writer, inputFile, explicitOutput, password string) error {
 	if err := fileio.ValidatePDFFile(inputFile); err != nil {
 		return err
 	}
@@ -159,13 +163,13 @@ func compressFile(inputFile, explicitOutput, password string) error {
 	savings := originalSize - newSize
 	savingsPercent := float64(savings) / float64(originalSize) * 100
 
-	fmt.Printf("Compressed %s to %s\n", inputFile, output)
-	fmt.Printf("Original:   %s\n", fileio.FormatFileSize(originalSize))
-	fmt.Printf("Compressed: %s\n", fileio.FormatFileSize(newSize))
+	fmt.Fprintf(w, "Compressed %s to %s\n", inputFile, output)
+	fmt.Fprintf(w, "Original:   %s\n", fileio.FormatFileSize(originalSize))
+	fmt.Fprintf(w, "Compressed: %s\n", fileio.FormatFileSize(newSize))
 	if savings > 0 {
-		fmt.Printf("Saved:      %s (%.1f%%)\n", fileio.FormatFileSize(savings), savingsPercent)
+		fmt.Fprintf(w, "Saved:      %s (%.1f%%)\n", fileio.FormatFileSize(savings), savingsPercent)
 	} else {
-		fmt.Println("Note: File size increased (already optimized)")
+		fmt.Fprintln(w, "Note: File size increased (already optimized)")
 	}
 
 	return nil
@@ -142,15 +142,15 @@ func TestMetaCommand_SetMultipleOnBatch(t *testing.T) {
 		t.Skip("sample.pdf not found in testdata")
 	}
 
-	// Setting metadata on multiple files should fail
+	// Setting metadata on multiple files cannot use a single output file
 	rootCmd := cli.GetRootCmd()
-	rootCmd.SetArgs([]string{"meta", samplePDF(), samplePDF(), "--title", "Test"})
+	rootCmd.SetArgs([]string{"meta", samplePDF(), samplePDF(), "--title", "Test", "-o", "out.pdf"})
 	rootCmd.SetOut(&bytes.Buffer{})
 	rootCmd.SetErr(&bytes.Buffer{})
 
 	err := rootCmd.Execute()
 	if err == nil {
-		t.Error("meta --title on multiple files should fail")
+		t.Error("meta --title -o on multiple files should fail")
 	}
 }
 func TestCompressCommand_BatchWithOutputFlag(t *testing.T) {
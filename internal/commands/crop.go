@@ -0,0 +1,191 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/lgbarn/pdf-cli/internal/cli"
+	"github.com/lgbarn/pdf-cli/internal/commands/patterns"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cli.AddCommand(cropCmd)
+	cli.AddOutputFlag(cropCmd, "Output file path (only with single file)")
+	cli.AddOutputDirFlag(cropCmd)
+	cli.AddBatchInputFlags(cropCmd)
+	cli.AddPagesFlag(cropCmd, "Pages to crop (default: all pages)")
+	cli.AddPasswordFlag(cropCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(cropCmd, "")
+	cli.AddAllowInsecurePasswordFlag(cropCmd)
+	cli.AddStdoutFlag(cropCmd)
+	cropCmd.Flags().String("box", "", "Crop box: margins in points (top right bottom left) or [llx lly urx ury] (required)")
+	_ = cropCmd.MarkFlagRequired("box")
+}
+
+var cropCmd = &cobra.Command{
+	Use:   "crop <file.pdf> [file2.pdf...]",
+	Short: "Crop pages in PDF(s)",
+	Long: `Crop pages in PDF file(s) by setting their crop box.
+
+The --box value is given in points (1/72 inch) and is either a list
+of margins relative to the media box or an absolute rectangle:
+  - "10"            10pt from every edge
+  - "10 20"         10pt top/bottom, 20pt left/right
+  - "10 10 10 10"   top, right, bottom, left
+  - "[0 0 500 700]" absolute rectangle (llx lly urx ury)
+
+By default, all pages are cropped. Use -p to specify specific pages.
+
+Supports batch processing of multiple files. When processing
+multiple files, output files are named with '_cropped' suffix.
+Use "-" to read from stdin. Use --stdout for binary output.
+
+Examples:
+  pdf crop document.pdf --box "10 10 10 10" -o cropped.pdf
+  pdf crop document.pdf --box "36" -p 1-5 -o cropped.pdf
+  pdf crop document.pdf --box "[0 0 420 595]" -o cropped.pdf`,
+	Args: cli.BatchArgs,
+	RunE: runCrop,
+}
+
+func runCrop(cmd *cobra.Command, args []string) error {
+	args, err := batchInputs(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	pagesStr := cli.GetPages(cmd)
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	output := cli.GetOutput(cmd)
+	toStdout := cli.GetStdout(cmd)
+
+	output, err = sanitizeOutputPath(output)
+	if err != nil {
+		return err
+	}
+
+	box, _ := cmd.Flags().GetString("box")
+	if err := pdf.ValidateBox(box); err != nil {
+		return fmt.Errorf("invalid crop box %q: %w", box, err)
+	}
+
+	// Handle dry-run mode
+	if cli.IsDryRun() {
+		return cropDryRun(args, output, pagesStr, password, box)
+	}
+
+	// Handle stdin/stdout for single file
+	if len(args) == 1 && (fileio.IsStdinInput(args[0]) || toStdout) {
+		return cropWithStdio(args[0], output, pagesStr, password, box, toStdout)
+	}
+
+	if err := validateBatchOutput(args, output, SuffixCropped); err != nil {
+		return err
+	}
+
+	return processBatch(args, output, SuffixCropped, func(w io.Writer, inputFile, out string) error {
+		return cropFile(w, inputFile, out, pagesStr, password, box)
+	})
+}
+
+func cropDryRun(args []string, explicitOutput, pagesStr, password, box string) error {
+	for _, inputFile := range args {
+		if fileio.IsStdinInput(inputFile) {
+			cli.DryRunPrint("Would crop: stdin with box %s", box)
+			continue
+		}
+
+		info, err := pdf.GetInfo(inputFile, password)
+		if err != nil {
+			cli.DryRunPrint("Would crop: %s (unable to read info)", inputFile)
+			continue
+		}
+
+		output := outputOrDefault(explicitOutput, inputFile, SuffixCropped)
+		pageDesc := describePages(pagesStr)
+
+		cli.DryRunPrint("Would crop: %s (%d pages)", inputFile, info.Pages)
+		cli.DryRunPrint("  Box: %s", box)
+		cli.DryRunPrint("  Pages: %s", pageDesc)
+		cli.DryRunPrint("  Output: %s", output)
+	}
+	return nil
+}
+
+func cropWithStdio(inputArg, explicitOutput, pagesStr, password, box string, toStdout bool) error {
+	handler := &patterns.StdioHandler{
+		InputArg:       inputArg,
+		ExplicitOutput: explicitOutput,
+		ToStdout:       toStdout,
+		DefaultSuffix:  SuffixCropped,
+		Operation:      "crop",
+	}
+	defer handler.Cleanup()
+
+	input, output, err := handler.Setup()
+	if err != nil {
+		return err
+	}
+
+	pages, err := parseAndValidatePages(pagesStr, input, password)
+	if err != nil {
+		return err
+	}
+
+	if !toStdout {
+		if err := checkOutputFile(output); err != nil {
+			return err
+		}
+	}
+
+	if err := pdf.Crop(input, output, box, pages, password); err != nil {
+		return pdferrors.WrapError("cropping pages", inputArg, err)
+	}
+
+	if err := handler.Finalize(); err != nil {
+		return err
+	}
+
+	if !toStdout {
+		fmt.Fprintf(os.Stderr, "Cropped to %s\n", output)
+	}
+	return nil
+}
+
+func cropFile(w io.Writer, inputFile, explicitOutput, pagesStr, password, box string) error {
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return err
+	}
+
+	pages, err := parseAndValidatePages(pagesStr, inputFile, password)
+	if err != nil {
+		return err
+	}
+
+	output := outputOrDefault(explicitOutput, inputFile, SuffixCropped)
+
+	if err := checkOutputFile(output); err != nil {
+		return err
+	}
+
+	pageDesc := "all pages"
+	if len(pages) > 0 {
+		pageDesc = fmt.Sprintf("%d pages", len(pages))
+	}
+	cli.PrintVerbose("Cropping %s of %s with box %s", pageDesc, inputFile, box)
+
+	if err := pdf.Crop(inputFile, output, box, pages, password); err != nil {
+		return pdferrors.WrapError("cropping pages", inputFile, err)
+	}
+
+	fmt.Fprintf(w, "Cropped %s to %s\n", pageDesc, output)
+	return nil
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/lgbarn/pdf-cli/internal/cli"
@@ -15,20 +16,30 @@ import (
 func init() {
 	cli.AddCommand(decryptCmd)
 	cli.AddOutputFlag(decryptCmd, "Output file path (only with single file)")
-	cli.AddPasswordFlag(decryptCmd, "Password for the encrypted PDF (required)")
+	cli.AddOutputDirFlag(decryptCmd)
+	cli.AddBatchInputFlags(decryptCmd)
+	cli.AddPasswordFlag(decryptCmd, "Password for the encrypted PDF (required unless --cert is given)")
 	cli.AddPasswordFileFlag(decryptCmd, "")
 	cli.AddAllowInsecurePasswordFlag(decryptCmd)
 	cli.AddStdoutFlag(decryptCmd)
+	decryptCmd.Flags().String("cert", "", "Recipient certificate of a certificate-encrypted PDF: PKCS#12 file or PEM file")
+	decryptCmd.Flags().String("key", "", "PEM private key of the recipient (default: read from the --cert file)")
 }
 
 var decryptCmd = &cobra.Command{
 	Use:   "decrypt <file.pdf> [file2.pdf...]",
-	Short: "Remove password protection from PDF(s)",
+	Short: "Remove password or certificate protection from PDF(s)",
 	Long: `Remove password protection from encrypted PDF file(s).
 
 Requires the correct password to decrypt the files.
 The output files will be unprotected PDFs.
 
+Files encrypted for certificate recipients (see 'pdf encrypt --recipient')
+are decrypted with --cert and the recipient's RSA private key, read from
+--key or from the --cert file. With a PKCS#12 file (.p12 or .pfx) as
+--cert, the password opens the PKCS#12 file. Only AES-256 certificate
+encryption is supported.
+
 Supports batch processing of multiple files. When processing
 multiple files, output files are named with '_decrypted' suffix.
 Use "-" to read from stdin. Use --stdout for binary output.
@@ -36,17 +47,33 @@ Use "-" to read from stdin. Use --stdout for binary output.
 Examples:
   pdf decrypt secure.pdf --password secret -o unlocked.pdf
   pdf decrypt protected.pdf --password mypassword
+  pdf decrypt report.pdf --cert my.pem --key my.key
   cat secure.pdf | pdf decrypt - --password secret --stdout > unlocked.pdf`,
-	Args: cobra.MinimumNArgs(1),
+	Args: cli.BatchArgs,
 	RunE: runDecrypt,
 }
 
 func runDecrypt(cmd *cobra.Command, args []string) error {
-	args, err := sanitizeInputArgs(args)
+	args, err := batchInputs(cmd, args)
 	if err != nil {
 		return err
 	}
 
+	certFile, _ := cmd.Flags().GetString("cert")
+	keyFile, _ := cmd.Flags().GetString("key")
+	if certFile != "" {
+		recipient, err := loadDecryptRecipient(cmd, certFile, keyFile)
+		if err != nil {
+			return err
+		}
+		return runDecryptWith(cmd, args, "", func(input, output string) error {
+			return pdf.DecryptWithKey(input, output, recipient)
+		})
+	}
+	if keyFile != "" {
+		return fmt.Errorf("--key requires --cert")
+	}
+
 	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
 	if err != nil {
 		return fmt.Errorf("failed to read password: %w", err)
@@ -54,11 +81,39 @@ func runDecrypt(cmd *cobra.Command, args []string) error {
 	if password == "" {
 		return fmt.Errorf("password is required (use --password-file, PDF_CLI_PASSWORD env var, or interactive prompt)")
 	}
+	return runDecryptWith(cmd, args, password, func(input, output string) error {
+		return pdf.Decrypt(input, output, password)
+	})
+}
+
+// loadDecryptRecipient loads the certificate and private key given by --cert
+// and --key. Only PKCS#12 files are protected by a password.
+func loadDecryptRecipient(cmd *cobra.Command, certFile, keyFile string) (*pdf.Recipient, error) {
+	certFile, err := fileio.SanitizePath(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate path: %w", err)
+	}
+	if keyFile != "" {
+		if keyFile, err = fileio.SanitizePath(keyFile); err != nil {
+			return nil, fmt.Errorf("invalid key path: %w", err)
+		}
+	}
+	password := ""
+	if pdf.IsPKCS12File(certFile) {
+		if password, err = cli.ReadPassword(cmd, "Enter certificate password: "); err != nil {
+			return nil, fmt.Errorf("failed to read password: %w", err)
+		}
+	}
+	return pdf.LoadRecipient(certFile, keyFile, password)
+}
 
+// runDecryptWith decrypts args with decrypt. password is only used to read
+// the page count for --dry-run.
+func runDecryptWith(cmd *cobra.Command, args []string, password string, decrypt func(input, output string) error) error {
 	output := cli.GetOutput(cmd)
 	toStdout := cli.GetStdout(cmd)
 
-	output, err = sanitizeOutputPath(output)
+	output, err := sanitizeOutputPath(output)
 	if err != nil {
 		return err
 	}
@@ -70,15 +125,15 @@ func runDecrypt(cmd *cobra.Command, args []string) error {
 
 	// Handle stdin/stdout for single file
 	if len(args) == 1 && (fileio.IsStdinInput(args[0]) || toStdout) {
-		return decryptWithStdio(args[0], output, password, toStdout)
+		return decryptWithStdio(args[0], output, decrypt, toStdout)
 	}
 
 	if err := validateBatchOutput(args, output, SuffixDecrypted); err != nil {
 		return err
 	}
 
-	return processBatch(args, func(inputFile string) error {
-		return decryptFile(inputFile, output, password)
+	return processBatch(args, output, SuffixDecrypted, func(w io.Writer, inputFile, out string) error {
+		return decryptFile(w, inputFile, out, decrypt)
 	})
 }
 
@@ -103,7 +158,7 @@ func decryptDryRun(args []string, explicitOutput, password string) error {
 	return nil
 }
 
-func decryptWithStdio(inputArg, explicitOutput, password string, toStdout bool) error {
+func decryptWithStdio(inputArg, explicitOutput string, decrypt func(input, output string) error, toStdout bool) error {
 	handler := &patterns.StdioHandler{
 		InputArg:       inputArg,
 		ExplicitOutput: explicitOutput,
@@ -124,7 +179,7 @@ func decryptWithStdio(inputArg, explicitOutput, password string, toStdout bool)
 		}
 	}
 
-	if err := pdf.Decrypt(input, output, password); err != nil {
+	if err := decrypt(input, output); err != nil {
 		return pdferrors.WrapError("decrypting file", inputArg, err)
 	}
 
@@ -138,7 +193,7 @@ func decryptWithStdio(inputArg, explicitOutput, password string, toStdout bool)
 	return nil
 }
 
-func decryptFile(inputFile, explicitOutput, password string) error {
+func decryptFile(w io.Writer, inputFile, explicitOutput string, decrypt func(input, output string) error) error {
 	if err := fileio.ValidatePDFFile(inputFile); err != nil {
 		return err
 	}
@@ -151,10 +206,10 @@ func decryptFile(inputFile, explicitOutput, password string) error {
 
 	cli.PrintVerbose("Decrypting %s to %s", inputFile, output)
 
-	if err := pdf.Decrypt(inputFile, output, password); err != nil {
+	if err := decrypt(inputFile, output); err != nil {
 		return pdferrors.WrapError("decrypting file", inputFile, err)
 	}
 
-	fmt.Printf("Decrypted %s to %s\n", inputFile, output)
+	fmt.Fprintf(w, "Decrypted %s to %s\n", inputFile, output)
 	return nil
 }
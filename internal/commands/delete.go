@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/lgbarn/pdf-cli/internal/cli"
+	"github.com/lgbarn/pdf-cli/internal/commands/patterns"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/pages"
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cli.AddCommand(deleteCmd)
+	cli.AddOutputFlag(deleteCmd, "Output file path (only with single file)")
+	cli.AddOutputDirFlag(deleteCmd)
+	cli.AddBatchInputFlags(deleteCmd)
+	cli.AddPagesFlag(deleteCmd, "Pages to delete (e.g., 2,5-7)")
+	cli.AddPasswordFlag(deleteCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(deleteCmd, "")
+	cli.AddAllowInsecurePasswordFlag(deleteCmd)
+	cli.AddStdoutFlag(deleteCmd)
+	_ = deleteCmd.MarkFlagRequired("pages")
+}
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <file.pdf> [file2.pdf...]",
+	Short: "Delete pages from PDF(s)",
+	Long: `Delete specific pages from PDF file(s).
+
+Specify pages using ranges and individual numbers:
+  - Single pages: 2,5
+  - Ranges: 5-7
+  - Odd and even pages: even
+  - From the end: end (last page), end-2, r3 (third from last)
+  - Steps: 1-20:2 (every other page)
+  - Exclusions: !1-3 (delete every page except 1-3), or use --invert
+
+At least one page must remain in the document.
+
+Supports batch processing of multiple files. When processing
+multiple files, output files are named with '_deleted' suffix.
+Use "-" to read from stdin. Use --stdout for binary output.
+
+Examples:
+  pdf delete document.pdf -p 2,5-7 -o trimmed.pdf
+  pdf delete document.pdf -p 1 --invert -o first-only.pdf
+  pdf delete scans.pdf -p even -o no-backs.pdf
+  cat input.pdf | pdf delete - -p 1 --stdout > no-cover.pdf`,
+	Args: cli.BatchArgs,
+	RunE: runDelete,
+}
+
+func runDelete(cmd *cobra.Command, args []string) error {
+	args, err := batchInputs(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	pagesStr := cli.GetPages(cmd)
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	output := cli.GetOutput(cmd)
+	toStdout := cli.GetStdout(cmd)
+
+	output, err = sanitizeOutputPath(output)
+	if err != nil {
+		return err
+	}
+
+	// Handle dry-run mode
+	if cli.IsDryRun() {
+		return deleteDryRun(args, output, pagesStr, password)
+	}
+
+	// Handle stdin/stdout for single file
+	if len(args) == 1 && (fileio.IsStdinInput(args[0]) || toStdout) {
+		return deleteWithStdio(args[0], output, pagesStr, password, toStdout)
+	}
+
+	if err := validateBatchOutput(args, output, SuffixDeleted); err != nil {
+		return err
+	}
+
+	return processBatch(args, output, SuffixDeleted, func(w io.Writer, inputFile, out string) error {
+		return deleteFile(w, inputFile, out, pagesStr, password)
+	})
+}
+
+// pagesToDelete parses the page selection and ensures at least one page is kept.
+func pagesToDelete(pagesStr, inputFile, password string) ([]int, int, error) {
+	pageCount, err := pdf.PageCount(inputFile, password)
+	if err != nil {
+		return nil, 0, pdferrors.WrapError("reading file", inputFile, err)
+	}
+
+	pageNums, err := selectPages(pagesStr, pageCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(pageNums) == 0 {
+		return nil, 0, fmt.Errorf("%w: no pages selected to delete", pdferrors.ErrInvalidPages)
+	}
+	if len(pageNums) >= pageCount {
+		return nil, 0, fmt.Errorf("cannot delete all %d pages: at least one page must remain", pageCount)
+	}
+	return pageNums, pageCount, nil
+}
+
+func deleteDryRun(args []string, explicitOutput, pagesStr, password string) error {
+	for _, inputFile := range args {
+		if fileio.IsStdinInput(inputFile) {
+			cli.DryRunPrint("Would delete: %s from stdin", describePages(pagesStr))
+			continue
+		}
+
+		pageNums, pageCount, err := pagesToDelete(pagesStr, inputFile, password)
+		if err != nil {
+			cli.DryRunPrint("Would delete: %s from %s (%v)", describePages(pagesStr), inputFile, err)
+			continue
+		}
+
+		output := outputOrDefault(explicitOutput, inputFile, SuffixDeleted)
+		cli.DryRunPrint("Would delete: %d pages from %s (%d pages)", len(pageNums), inputFile, pageCount)
+		cli.DryRunPrint("  Pages: %s", pages.FormatPageRanges(pageNums))
+		cli.DryRunPrint("  Result: %d pages", pageCount-len(pageNums))
+		cli.DryRunPrint("  Output: %s", output)
+	}
+	return nil
+}
+
+func deleteWithStdio(inputArg, explicitOutput, pagesStr, password string, toStdout bool) error {
+	handler := &patterns.StdioHandler{
+		InputArg:       inputArg,
+		ExplicitOutput: explicitOutput,
+		ToStdout:       toStdout,
+		DefaultSuffix:  SuffixDeleted,
+		Operation:      "delete",
+	}
+	defer handler.Cleanup()
+
+	input, output, err := handler.Setup()
+	if err != nil {
+		return err
+	}
+
+	pageNums, _, err := pagesToDelete(pagesStr, input, password)
+	if err != nil {
+		return err
+	}
+
+	if !toStdout {
+		if err := checkOutputFile(output); err != nil {
+			return err
+		}
+	}
+
+	if err := pdf.DeletePages(input, output, pageNums, password); err != nil {
+		return pdferrors.WrapError("deleting pages", inputArg, err)
+	}
+
+	if err := handler.Finalize(); err != nil {
+		return err
+	}
+
+	if !toStdout {
+		fmt.Fprintf(os.Stderr, "Deleted %d pages to %s\n", len(pageNums), output)
+	}
+	return nil
+}
+
+func deleteFile(w io.Writer, inputFile, explicitOutput, pagesStr, password string) error {
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return err
+	}
+
+	pageNums, pageCount, err := pagesToDelete(pagesStr, inputFile, password)
+	if err != nil {
+		return err
+	}
+
+	output := outputOrDefault(explicitOutput, inputFile, SuffixDeleted)
+
+	if err := checkOutputFile(output); err != nil {
+		return err
+	}
+
+	cli.PrintVerbose("Deleting pages %s from %s", pages.FormatPageRanges(pageNums), inputFile)
+
+	if err := pdf.DeletePages(inputFile, output, pageNums, password); err != nil {
+		return pdferrors.WrapError("deleting pages", inputFile, err)
+	}
+
+	fmt.Fprintf(w, "Deleted %d pages to %s (%d pages remaining)\n", len(pageNums), output, pageCount-len(pageNums))
+	return nil
+}
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+)
+
+func TestDeleteCommand(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantPages int
+	}{
+		{"single page", []string{"-p", "2"}, 2},
+		{"range", []string{"-p", "1-2"}, 1},
+		{"inverted syntax", []string{"-p", "!1"}, 1},
+		{"invert flag", []string{"-p", "1-2", "--invert"}, 2},
+		{"invert mixed selection", []string{"-p", "1-3,!2", "--invert"}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags(t)
+			if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+				t.Skip("sample.pdf not found in testdata")
+			}
+
+			tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tmpDir)
+
+			output := filepath.Join(tmpDir, "deleted.pdf")
+			args := append([]string{"delete", samplePDF(), "-o", output}, tt.args...)
+			if err := executeCommand(args...); err != nil {
+				t.Fatalf("delete %v failed: %v", tt.args, err)
+			}
+
+			count, err := pdf.PageCount(output, "")
+			if err != nil {
+				t.Fatalf("PageCount() error = %v", err)
+			}
+			if count != tt.wantPages {
+				t.Errorf("PageCount() = %d, want %d", count, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestDeleteCommand_AllPages(t *testing.T) {
+	resetFlags(t)
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output := filepath.Join(tmpDir, "deleted.pdf")
+	if err := executeCommand("delete", samplePDF(), "-p", "1-3", "-o", output); err == nil {
+		t.Error("deleting every page should fail")
+	}
+}
+
+func TestDeleteCommand_EmptySelection(t *testing.T) {
+	resetFlags(t)
+	output := filepath.Join(t.TempDir(), "deleted.pdf")
+	for _, sel := range []string{"", ","} {
+		err := executeCommand("delete", samplePDF(), "-p", sel, "-o", output)
+		if !errors.Is(err, pdferrors.ErrInvalidPages) {
+			t.Errorf("delete -p %q: error = %v, want ErrInvalidPages", sel, err)
+		}
+	}
+}
+
+func TestDeleteCommand_DryRun(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	if err := executeCommand("delete", samplePDF(), "-p", "2", "--dry-run"); err != nil {
+		t.Fatalf("delete --dry-run failed: %v", err)
+	}
+}
+
+func TestExtractCommand_Invert(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output := filepath.Join(tmpDir, "extracted.pdf")
+	if err := executeCommand("extract", samplePDF(), "-p", "1", "--invert", "-o", output); err != nil {
+		t.Fatalf("extract --invert failed: %v", err)
+	}
+
+	count, err := pdf.PageCount(output, "")
+	if err != nil {
+		t.Fatalf("PageCount() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("PageCount() = %d, want 2", count)
+	}
+}
@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"crypto/x509"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/lgbarn/pdf-cli/internal/cli"
@@ -15,21 +17,29 @@ import (
 func init() {
 	cli.AddCommand(encryptCmd)
 	cli.AddOutputFlag(encryptCmd, "Output file path (only with single file)")
+	cli.AddOutputDirFlag(encryptCmd)
+	cli.AddBatchInputFlags(encryptCmd)
 	cli.AddPasswordFlag(encryptCmd, "User password (required)")
 	cli.AddPasswordFileFlag(encryptCmd, "")
 	cli.AddAllowInsecurePasswordFlag(encryptCmd)
 	cli.AddStdoutFlag(encryptCmd)
 	encryptCmd.Flags().String("owner-password", "", "Owner password (defaults to user password)")
+	encryptCmd.Flags().StringArray("recipient", nil, "Encrypt for the owner of this certificate instead of a password (repeatable)")
 }
 
 var encryptCmd = &cobra.Command{
 	Use:   "encrypt <file.pdf> [file2.pdf...]",
-	Short: "Add password protection to PDF(s)",
+	Short: "Add password or certificate protection to PDF(s)",
 	Long: `Add password protection to PDF file(s).
 
 The user password is required to open the document.
 The owner password (optional) controls editing permissions.
 
+With --recipient, the files are encrypted with AES-256 for the owners of
+the given certificates (.pem, .crt, .cer or .p7c) instead: each recipient
+opens them with their private key, e.g. 'pdf decrypt --cert --key'.
+Recipient certificates must have RSA keys.
+
 Supports batch processing of multiple files. When processing
 multiple files, output files are named with '_encrypted' suffix.
 Use "-" to read from stdin. Use --stdout for binary output.
@@ -37,26 +47,54 @@ Use "-" to read from stdin. Use --stdout for binary output.
 Examples:
   pdf encrypt document.pdf --password secret -o secure.pdf
   pdf encrypt document.pdf --password user123 --owner-password admin456
+  pdf encrypt report.pdf --recipient alice.pem --recipient bob.pem
   cat in.pdf | pdf encrypt - --password secret --stdout > secure.pdf`,
-	Args: cobra.MinimumNArgs(1),
+	Args: cli.BatchArgs,
 	RunE: runEncrypt,
 }
 
 func runEncrypt(cmd *cobra.Command, args []string) error {
-	args, err := sanitizeInputArgs(args)
+	args, err := batchInputs(cmd, args)
 	if err != nil {
 		return err
 	}
 
-	userPassword, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
-	if err != nil {
-		return fmt.Errorf("failed to read password: %w", err)
-	}
-	if userPassword == "" {
-		return fmt.Errorf("password is required (use --password-file, PDF_CLI_PASSWORD env var, or interactive prompt)")
+	ownerPassword, _ := cmd.Flags().GetString("owner-password")
+	recipientFiles, _ := cmd.Flags().GetStringArray("recipient")
+
+	var encrypt func(input, output string) error
+	var recipients []*x509.Certificate
+	if len(recipientFiles) > 0 {
+		if ownerPassword != "" || cmd.Flags().Changed("password") || cmd.Flags().Changed("password-file") {
+			return fmt.Errorf("--recipient cannot be combined with passwords")
+		}
+		for _, file := range recipientFiles {
+			file, err := fileio.SanitizePath(file)
+			if err != nil {
+				return fmt.Errorf("invalid recipient path: %w", err)
+			}
+			certs, err := pdf.LoadRecipientCertificates(file)
+			if err != nil {
+				return err
+			}
+			recipients = append(recipients, certs...)
+		}
+		encrypt = func(input, output string) error {
+			return pdf.EncryptForRecipients(input, output, recipients)
+		}
+	} else {
+		userPassword, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+		if err != nil {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+		if userPassword == "" {
+			return fmt.Errorf("password is required (use --password-file, PDF_CLI_PASSWORD env var, or interactive prompt)")
+		}
+		encrypt = func(input, output string) error {
+			return pdf.Encrypt(input, output, userPassword, ownerPassword)
+		}
 	}
 
-	ownerPassword, _ := cmd.Flags().GetString("owner-password")
 	output := cli.GetOutput(cmd)
 	toStdout := cli.GetStdout(cmd)
 
@@ -67,24 +105,24 @@ func runEncrypt(cmd *cobra.Command, args []string) error {
 
 	// Handle dry-run mode
 	if cli.IsDryRun() {
-		return encryptDryRun(args, output, ownerPassword != "")
+		return encryptDryRun(args, output, ownerPassword != "", recipients)
 	}
 
 	// Handle stdin/stdout for single file
 	if len(args) == 1 && (fileio.IsStdinInput(args[0]) || toStdout) {
-		return encryptWithStdio(args[0], output, userPassword, ownerPassword, toStdout)
+		return encryptWithStdio(args[0], output, encrypt, toStdout)
 	}
 
 	if err := validateBatchOutput(args, output, SuffixEncrypted); err != nil {
 		return err
 	}
 
-	return processBatch(args, func(inputFile string) error {
-		return encryptFile(inputFile, output, userPassword, ownerPassword)
+	return processBatch(args, output, SuffixEncrypted, func(w io.Writer, inputFile, out string) error {
+		return encryptFile(w, inputFile, out, encrypt)
 	})
 }
 
-func encryptDryRun(args []string, explicitOutput string, hasOwnerPassword bool) error {
+func encryptDryRun(args []string, explicitOutput string, hasOwnerPassword bool, recipients []*x509.Certificate) error {
 	for _, inputFile := range args {
 		if fileio.IsStdinInput(inputFile) {
 			cli.DryRunPrint("Would encrypt: stdin")
@@ -103,11 +141,14 @@ func encryptDryRun(args []string, explicitOutput string, hasOwnerPassword bool)
 		if hasOwnerPassword {
 			cli.DryRunPrint("  Owner password: set")
 		}
+		for _, c := range recipients {
+			cli.DryRunPrint("  Recipient: %s", c.Subject)
+		}
 	}
 	return nil
 }
 
-func encryptWithStdio(inputArg, explicitOutput, userPassword, ownerPassword string, toStdout bool) error {
+func encryptWithStdio(inputArg, explicitOutput string, encrypt func(input, output string) error, toStdout bool) error {
 	handler := &patterns.StdioHandler{
 		InputArg:       inputArg,
 		ExplicitOutput: explicitOutput,
@@ -128,7 +169,7 @@ func encryptWithStdio(inputArg, explicitOutput, userPassword, ownerPassword stri
 		}
 	}
 
-	if err := pdf.Encrypt(input, output, userPassword, ownerPassword); err != nil {
+	if err := encrypt(input, output); err != nil {
 		return pdferrors.WrapError("encrypting file", inputArg, err)
 	}
 
@@ -142,7 +183,7 @@ func encryptWithStdio(inputArg, explicitOutput, userPassword, ownerPassword stri
 	return nil
 }
 
-func encryptFile(inputFile, explicitOutput, userPassword, ownerPassword string) error {
+func encryptFile(w io.Writer, inputFile, explicitOutput string, encrypt func(input, output string) error) error {
 	if err := fileio.ValidatePDFFile(inputFile); err != nil {
 		return err
 	}
@@ -155,10 +196,10 @@ func encryptFile(inputFile, explicitOutput, userPassword, ownerPassword string)
 
 	cli.PrintVerbose("Encrypting %s to %s", inputFile, output)
 
-	if err := pdf.Encrypt(inputFile, output, userPassword, ownerPassword); err != nil {
+	if err := encrypt(inputFile, output); err != nil {
 		return pdferrors.WrapError("encrypting file", inputFile, err)
 	}
 
-	fmt.Printf("Encrypted %s to %s\n", inputFile, output)
+	fmt.Fprintf(w, "Encrypted %s to %s\n", inputFile, output)
 	return nil
 }
@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+)
+
+// testRecipient writes a self-signed RSA certificate and its private key to
+// PEM files.
+func testRecipient(t *testing.T, name string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, name+".pem")
+	keyFile = filepath.Join(dir, name+".key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestEncryptRecipientsCommand(t *testing.T) {
+	aliceCert, aliceKey := testRecipient(t, "alice")
+	bobCert, _ := testRecipient(t, "bob")
+
+	input, err := os.ReadFile(samplePDF())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	pdf1, pdf2 := filepath.Join(dir, "report1.pdf"), filepath.Join(dir, "report2.pdf")
+	for _, f := range []string{pdf1, pdf2} {
+		if err := os.WriteFile(f, input, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resetFlags(t)
+	if err := executeCommand("encrypt", pdf1, pdf2, "--recipient", aliceCert, "--recipient", bobCert); err != nil {
+		t.Fatalf("encrypt --recipient failed: %v", err)
+	}
+
+	encrypted := filepath.Join(dir, "report1_encrypted.pdf")
+	if _, err := os.Stat(filepath.Join(dir, "report2_encrypted.pdf")); err != nil {
+		t.Errorf("batch output missing: %v", err)
+	}
+	decrypted := filepath.Join(dir, "report1_encrypted_decrypted.pdf")
+	resetFlags(t)
+	if err := executeCommand("decrypt", encrypted, "--cert", aliceCert, "--key", aliceKey); err != nil {
+		t.Fatalf("decrypt --cert --key failed: %v", err)
+	}
+	if _, err := os.Stat(decrypted); err != nil {
+		t.Errorf("decrypted output missing: %v", err)
+	}
+}
+
+func TestEncryptRecipientsCommandErrors(t *testing.T) {
+	certFile, keyFile := testRecipient(t, "alice")
+	output := filepath.Join(t.TempDir(), "out.pdf")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"recipient with password", []string{"encrypt", samplePDF(), "--recipient", certFile, "--password", "secret", "-o", output}, "cannot be combined"},
+		{"missing recipient", []string{"encrypt", samplePDF(), "--recipient", filepath.Join(t.TempDir(), "none.pem"), "-o", output}, "failed to load certificate"},
+		{"key without cert", []string{"decrypt", samplePDF(), "--key", keyFile, "-o", output}, "--key requires --cert"},
+		{"not encrypted", []string{"decrypt", samplePDF(), "--cert", certFile, "--key", keyFile, "-o", output}, "does not use certificate security"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags(t)
+			if err := executeCommand(tt.args...); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestEncryptedInputPasswordErrors(t *testing.T) {
+	encrypted := encryptedSample(t, "tiger7")
+	output := filepath.Join(t.TempDir(), "out.pdf")
+
+	t.Setenv("PDF_CLI_PASSWORD", "lion")
+	resetFlags(t)
+	err := executeCommand("rotate", encrypted, "-a", "90", "-o", output)
+	if !pdferrors.IsWrongPassword(err) {
+		t.Errorf("rotate with a wrong password error = %v, want incorrect password", err)
+	}
+
+	resetFlags(t)
+	err = executeCommand("merge", "-o", output, encrypted, samplePDF())
+	if !pdferrors.IsPasswordRequired(err) || !strings.Contains(err.Error(), "pdf decrypt") {
+		t.Errorf("merge of an encrypted PDF error = %v, want a hint to decrypt it", err)
+	}
+
+	t.Setenv("PDF_CLI_PASSWORD", "tiger7")
+	resetFlags(t)
+	if err := executeCommand("rotate", encrypted, "-a", "90", "-o", output); err != nil {
+		t.Errorf("rotate with the password failed: %v", err)
+	}
+}
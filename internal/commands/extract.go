@@ -31,12 +31,18 @@ Specify pages using ranges and individual numbers:
   - Single pages: 1,3,5
   - Ranges: 1-5,10-15
   - Combined: 1-3,7,10-12
+  - Odd and even pages: odd, even
+  - From the end: end (last page), end-2, r3 (third from last)
+  - Steps and reversal: 1-20:2 (every other page), z-1 (all, reversed)
+  - Exclusions: !1-3 (every page except 1-3), 1-10,!5, or use --invert
 
 Use "-" to read from stdin. Use --stdout for binary output.
 
 Examples:
   pdf extract document.pdf -p 1-5 -o first5.pdf
   pdf extract document.pdf -p 1,3,5,7 -o odds.pdf
+  pdf extract document.pdf -p '!1' -o no-cover.pdf
+  pdf extract document.pdf -p odd -o fronts.pdf
   cat input.pdf | pdf extract - -p 1-5 --stdout > pages.pdf`,
 	Args: cobra.ExactArgs(1),
 	RunE: runExtract,
@@ -106,7 +112,7 @@ func runExtract(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	cli.PrintVerbose("Extracting pages %s from %s to %s", pagesStr, inputArg, output)
+	cli.PrintVerbose("Extracting %s from %s to %s", describePages(pagesStr), inputArg, output)
 
 	if err := pdf.ExtractPages(input, output, pages, password); err != nil {
 		return pdferrors.WrapError("extracting pages", inputArg, err)
@@ -124,19 +130,19 @@ func runExtract(cmd *cobra.Command, args []string) error {
 
 func extractDryRun(inputArg, explicitOutput, pagesStr, password string) error {
 	if fileio.IsStdinInput(inputArg) {
-		cli.DryRunPrint("Would extract pages %s from: stdin", pagesStr)
+		cli.DryRunPrint("Would extract %s from: stdin", describePages(pagesStr))
 		return nil
 	}
 
 	info, err := pdf.GetInfo(inputArg, password)
 	if err != nil {
-		cli.DryRunPrint("Would extract pages %s from: %s (unable to read info)", pagesStr, inputArg)
+		cli.DryRunPrint("Would extract %s from: %s (unable to read info)", describePages(pagesStr), inputArg)
 		return nil
 	}
 
 	output := outputOrDefault(explicitOutput, inputArg, "_extracted")
 	cli.DryRunPrint("Would extract from: %s (%d pages total)", inputArg, info.Pages)
-	cli.DryRunPrint("  Pages: %s", pagesStr)
+	cli.DryRunPrint("  Pages: %s", describePages(pagesStr))
 	cli.DryRunPrint("  Output: %s", output)
 	return nil
 }
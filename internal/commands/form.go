@@ -0,0 +1,511 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lgbarn/pdf-cli/internal/cli"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/output"
+	"github.com/lgbarn/pdf-cli/internal/pages"
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	cli.AddCommand(formCmd)
+	formCmd.AddCommand(formListCmd)
+	formCmd.AddCommand(formFillCmd)
+	formCmd.AddCommand(formExportCmd)
+
+	cli.AddPasswordFlag(formListCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(formListCmd, "")
+	cli.AddAllowInsecurePasswordFlag(formListCmd)
+	cli.AddFormatFlag(formListCmd)
+
+	cli.AddOutputFlag(formFillCmd, "Output file path (with several records, the base name)")
+	cli.AddPasswordFlag(formFillCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(formFillCmd, "")
+	cli.AddAllowInsecurePasswordFlag(formFillCmd)
+	formFillCmd.Flags().String("data", "", "File with field values as JSON, YAML, CSV or TSV")
+	formFillCmd.Flags().String("data-format", "", "Format of the data file: json, yaml, csv, tsv (default: from file extension)")
+	formFillCmd.Flags().String("name-field", "", "Name each output after this field's value instead of the record number")
+	formFillCmd.Flags().Bool("flatten", false, "Merge the filled fields into the page content and remove the form")
+
+	cli.AddOutputFlag(formExportCmd, "Write the template to a file instead of stdout")
+	cli.AddPasswordFlag(formExportCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(formExportCmd, "")
+	cli.AddAllowInsecurePasswordFlag(formExportCmd)
+	formExportCmd.Flags().String("format", "json", "Template format: json, yaml, csv, tsv")
+}
+
+var formCmd = &cobra.Command{
+	Use:   "form",
+	Short: "List, fill and flatten PDF form fields",
+	Long: `Work with interactive PDF forms (AcroForms).
+
+Available subcommands:
+  list   - List the fields of a form
+  export - Write a data template with the current field values
+  fill   - Fill a form from JSON, YAML, CSV or TSV data`,
+}
+
+var formListCmd = &cobra.Command{
+	Use:   "list <file.pdf>",
+	Short: "List form fields",
+	Long: `List the fields of a PDF form with their name, type, value, options
+and pages.
+
+Examples:
+  pdf form list application.pdf
+  pdf form list application.pdf --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFormList,
+}
+
+var formExportCmd = &cobra.Command{
+	Use:   "export <file.pdf>",
+	Short: "Export a form data template",
+	Long: `Export the fields of a PDF form as a data template for 'pdf form fill'.
+
+The template maps each field name to its current value: check boxes are
+true or false, list boxes hold a list of values, all other fields hold
+text. A CSV or TSV template has one column per field and a single row.
+
+Examples:
+  pdf form export application.pdf > values.json
+  pdf form export application.pdf --format csv -o employees.csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFormExport,
+}
+
+var formFillCmd = &cobra.Command{
+	Use:   "fill <file.pdf>",
+	Short: "Fill form fields from a data file",
+	Long: `Fill the fields of a PDF form from a data file.
+
+The data maps field names to values, as written by 'pdf form export'.
+Check boxes take true/false, yes/no or on/off; radio buttons, combo
+boxes and list boxes take one of their options. In CSV and TSV, separate
+several list box values with ';'. Fields that are not named keep their
+value; unknown field names are an error.
+
+A JSON object fills one form. A JSON or YAML list of objects, or a CSV or
+TSV file with a header row of field names, fills one form per record.
+Each output is named after the output file (default: the input with the
+'_filled' suffix) and the record number, or the value of --name-field.
+
+--flatten merges the field appearances into the page content and
+removes the form, so that the values can no longer be edited.
+
+Examples:
+  pdf form fill application.pdf --data values.json -o filled.pdf
+  pdf form fill application.pdf --data values.json --flatten -o final.pdf
+  pdf form fill onboarding.pdf --data employees.csv --name-field employee_id -o out/onboarding.pdf`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFormFill,
+}
+
+func runFormList(cmd *cobra.Command, args []string) error {
+	args, err := sanitizeInputArgs(args)
+	if err != nil {
+		return err
+	}
+
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	formatter := output.NewOutputFormatter(cli.GetFormat(cmd))
+
+	inputFile := args[0]
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return err
+	}
+
+	cli.PrintVerbose("Reading form fields from %s", inputFile)
+
+	fields, err := pdf.ListFormFields(inputFile, password)
+	if err != nil {
+		return pdferrors.WrapError("reading form fields", inputFile, err)
+	}
+
+	switch formatter.Format {
+	case output.FormatJSON, output.FormatYAML:
+		if fields == nil {
+			fields = []pdf.FormField{}
+		}
+		return formatter.Print(fields)
+	case output.FormatHuman:
+		if len(fields) == 0 {
+			fmt.Println("No form fields found")
+			return nil
+		}
+	}
+
+	headers := []string{"name", "type", "value", "options", "pages", "locked"}
+	rows := make([][]string, 0, len(fields))
+	for _, f := range fields {
+		rows = append(rows, []string{
+			f.Name, f.Type, f.Value, strings.Join(f.Options, ", "),
+			pages.FormatPageRanges(f.Pages), strconv.FormatBool(f.Locked),
+		})
+	}
+	return formatter.PrintTable(headers, rows)
+}
+
+func runFormExport(cmd *cobra.Command, args []string) error {
+	args, err := sanitizeInputArgs(args)
+	if err != nil {
+		return err
+	}
+
+	outputFile, err := sanitizeOutputPath(cli.GetOutput(cmd))
+	if err != nil {
+		return err
+	}
+
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	formatter := output.NewOutputFormatter(format)
+	if !formatter.IsStructured() {
+		return fmt.Errorf("invalid format %q: use json, yaml, csv or tsv", format)
+	}
+
+	inputFile := args[0]
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return err
+	}
+
+	fields, err := pdf.ListFormFields(inputFile, password)
+	if err != nil {
+		return pdferrors.WrapError("reading form fields", inputFile, err)
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("%s has no form fields", inputFile)
+	}
+
+	if outputFile != "" {
+		if err := checkOutputFile(outputFile); err != nil {
+			return err
+		}
+		f, err := os.Create(outputFile) // #nosec G304 -- path is sanitized
+		if err != nil {
+			return fmt.Errorf("failed to create template: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		formatter.Writer = f
+	}
+
+	if err := printFormTemplate(formatter, fields); err != nil {
+		return err
+	}
+	if outputFile != "" {
+		fmt.Printf("Form template written to %s\n", outputFile)
+	}
+	return nil
+}
+
+// printFormTemplate writes a fill data template holding the current field values.
+func printFormTemplate(formatter *output.OutputFormatter, fields []pdf.FormField) error {
+	if formatter.Format == output.FormatCSV || formatter.Format == output.FormatTSV {
+		headers := make([]string, len(fields))
+		row := make([]string, len(fields))
+		for i, f := range fields {
+			headers[i] = f.Name
+			row[i] = f.Value
+			if f.Type == pdf.FieldListBox {
+				row[i] = strings.Join(f.Values, ";")
+			}
+		}
+		return formatter.PrintTable(headers, [][]string{row})
+	}
+
+	// Build the object by hand to keep the form order of the fields.
+	var b strings.Builder
+	b.WriteString("{")
+	for i, f := range fields {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		var value interface{} = f.Value
+		switch f.Type {
+		case pdf.FieldCheckBox:
+			value = f.Value == "true"
+		case pdf.FieldListBox:
+			value = append([]string{}, f.Values...)
+		}
+		k, _ := json.Marshal(f.Name)
+		v, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		b.Write(k)
+		b.WriteString(":")
+		b.Write(v)
+	}
+	b.WriteString("}")
+	return formatter.Print(json.RawMessage(b.String()))
+}
+
+func runFormFill(cmd *cobra.Command, args []string) error {
+	args, err := sanitizeInputArgs(args)
+	if err != nil {
+		return err
+	}
+
+	outputFile, err := sanitizeOutputPath(cli.GetOutput(cmd))
+	if err != nil {
+		return err
+	}
+
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+
+	dataFile, _ := cmd.Flags().GetString("data")
+	dataFormat, _ := cmd.Flags().GetString("data-format")
+	nameField, _ := cmd.Flags().GetString("name-field")
+	flatten, _ := cmd.Flags().GetBool("flatten")
+	if dataFile == "" && !flatten {
+		return fmt.Errorf("must specify --data or --flatten")
+	}
+
+	inputFile := args[0]
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return err
+	}
+
+	records := []map[string][]string{nil}
+	single := true
+	if dataFile != "" {
+		if records, single, err = readFormData(dataFile, dataFormat); err != nil {
+			return err
+		}
+	}
+	outputs, err := formOutputs(inputFile, outputFile, records, single, nameField)
+	if err != nil {
+		return err
+	}
+	if err := checkFormRecords(inputFile, password, records, nameField); err != nil {
+		return err
+	}
+
+	if cli.IsDryRun() {
+		for i, values := range records {
+			cli.DryRunPrint("Would fill %d field(s) of %s", len(values), inputFile)
+			if flatten {
+				cli.DryRunPrint("  Flatten form")
+			}
+			cli.DryRunPrint("  Output: %s", outputs[i])
+		}
+		return nil
+	}
+
+	opts := pdf.FillFormOptions{Flatten: flatten}
+	jobs := make([]batchJob, len(records))
+	for i := range records {
+		jobs[i] = batchJob{input: inputFile, output: outputs[i]}
+	}
+	return runBatch(jobs, false, cli.FailFast(), func(i int, w io.Writer) error {
+		return fillFormRecord(w, inputFile, outputs[i], records[i], opts, password)
+	})
+}
+
+// fillFormRecord writes the form of inputFile filled with one record to output.
+func fillFormRecord(w io.Writer, inputFile, output string, values map[string][]string, opts pdf.FillFormOptions, password string) error {
+	if err := checkOutputFile(output); err != nil {
+		return err
+	}
+	cli.PrintVerbose("Filling %d field(s) of %s", len(values), inputFile)
+	if err := pdf.FillForm(inputFile, output, values, opts, password); err != nil {
+		return pdferrors.WrapError("filling form", inputFile, err)
+	}
+	fmt.Fprintf(w, "Filled form: %s\n", output)
+	return nil
+}
+
+// checkFormRecords verifies that every record names fields of the form before
+// anything is written. The --name-field column may name a field that is not
+// in the form; it is then only used for the output names.
+func checkFormRecords(inputFile, password string, records []map[string][]string, nameField string) error {
+	fields, err := pdf.ListFormFields(inputFile, password)
+	if err != nil {
+		return pdferrors.WrapError("reading form fields", inputFile, err)
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("%s has no form fields", inputFile)
+	}
+
+	known := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		known[f.Name] = true
+	}
+	for i, values := range records {
+		for name := range values {
+			switch {
+			case known[name]:
+			case name == nameField:
+				delete(values, name)
+			default:
+				return fmt.Errorf("record %d: unknown form field %q", i+1, name)
+			}
+		}
+	}
+	return nil
+}
+
+// formOutputs returns the output file for each record. A single record is
+// written to the output file; several records get the record number or the
+// value of nameField appended to it.
+func formOutputs(inputFile, outputFile string, records []map[string][]string, single bool, nameField string) ([]string, error) {
+	base := outputOrDefault(outputFile, inputFile, SuffixFilled)
+	if single {
+		return []string{base}, nil
+	}
+
+	outputs := make([]string, len(records))
+	seen := map[string]bool{}
+	for i, values := range records {
+		name := strconv.Itoa(i + 1)
+		if nameField != "" {
+			v := strings.TrimSpace(strings.Join(values[nameField], "_"))
+			if v == "" {
+				return nil, fmt.Errorf("record %d: no value for --name-field %q", i+1, nameField)
+			}
+			name = strings.NewReplacer("/", "_", "\\", "_").Replace(v)
+		}
+		out := fileio.GenerateOutputFilename(base, "_"+name)
+		if seen[out] {
+			return nil, fmt.Errorf("record %d: duplicate output file %s", i+1, out)
+		}
+		seen[out] = true
+		outputs[i] = out
+	}
+	return outputs, nil
+}
+
+// readFormData reads the records of a form data file. single reports whether
+// the file holds a single JSON or YAML object rather than a list of records.
+func readFormData(path, format string) (records []map[string][]string, single bool, err error) {
+	path, err = fileio.SanitizePath(path)
+	if err != nil {
+		return nil, false, err
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- path is sanitized
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read form data: %w", err)
+	}
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	var decoded interface{}
+	switch f := output.ParseOutputFormat(format); f {
+	case output.FormatCSV, output.FormatTSV:
+		records, err = parseFormCSV(data, f == output.FormatTSV)
+	case output.FormatJSON:
+		if err = json.Unmarshal(data, &decoded); err == nil {
+			records, single, err = formRecords(decoded)
+		}
+	case output.FormatYAML:
+		if err = yaml.Unmarshal(data, &decoded); err == nil {
+			records, single, err = formRecords(decoded)
+		}
+	default:
+		return nil, false, fmt.Errorf("cannot tell the format of %s: use --data-format json, yaml, csv or tsv", path)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse form data %s: %w", path, err)
+	}
+	if len(records) == 0 {
+		return nil, false, fmt.Errorf("%s holds no form data", path)
+	}
+	return records, single, nil
+}
+
+// parseFormCSV parses CSV or TSV form data with a header row of field names.
+func parseFormCSV(data []byte, tsv bool) ([]map[string][]string, error) {
+	r := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(data), "\ufeff")))
+	if tsv {
+		r.Comma = '\t'
+		r.LazyQuotes = true
+	}
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) < 2 {
+		return nil, nil
+	}
+
+	var records []map[string][]string
+	for _, row := range rows[1:] {
+		values := map[string][]string{}
+		for i, name := range rows[0] {
+			if name = strings.TrimSpace(name); name != "" && i < len(row) {
+				values[name] = []string{row[i]}
+			}
+		}
+		records = append(records, values)
+	}
+	return records, nil
+}
+
+// formRecords converts decoded JSON or YAML form data, an object or a list of
+// objects, to records.
+func formRecords(decoded interface{}) ([]map[string][]string, bool, error) {
+	var objects []interface{}
+	single := false
+	switch d := decoded.(type) {
+	case map[string]interface{}:
+		objects, single = []interface{}{d}, true
+	case []interface{}:
+		objects = d
+	default:
+		return nil, false, fmt.Errorf("expected an object or a list of objects")
+	}
+
+	records := make([]map[string][]string, 0, len(objects))
+	for i, o := range objects {
+		obj, ok := o.(map[string]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("record %d is not an object", i+1)
+		}
+		values := map[string][]string{}
+		for name, value := range obj {
+			switch v := value.(type) {
+			case nil:
+				// null leaves the field unchanged.
+			case []interface{}:
+				list := make([]string, 0, len(v))
+				for _, item := range v {
+					if _, nested := item.(map[string]interface{}); nested {
+						return nil, false, fmt.Errorf("record %d: field %q has a nested object", i+1, name)
+					}
+					list = append(list, cellText(item))
+				}
+				values[name] = list
+			case map[string]interface{}:
+				return nil, false, fmt.Errorf("record %d: field %q has a nested object", i+1, name)
+			default:
+				values[name] = []string{cellText(v)}
+			}
+		}
+		records = append(records, values)
+	}
+	return records, single, nil
+}
@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+)
+
+func formPDF() string {
+	return filepath.Join(testdataDir(), "form.pdf")
+}
+
+func TestFormRecords(t *testing.T) {
+	tests := []struct {
+		name       string
+		decoded    interface{}
+		wantSingle bool
+		want       []map[string][]string
+		wantErr    bool
+	}{
+		{
+			name:       "object",
+			decoded:    map[string]interface{}{"name": "Ann", "agree": true, "langs": []interface{}{"Go", "C"}, "size": nil},
+			wantSingle: true,
+			want:       []map[string][]string{{"name": {"Ann"}, "agree": {"true"}, "langs": {"Go", "C"}}},
+		},
+		{
+			name:    "list",
+			decoded: []interface{}{map[string]interface{}{"id": 7.0}, map[string]interface{}{"id": 8.0}},
+			want:    []map[string][]string{{"id": {"7"}}, {"id": {"8"}}},
+		},
+		{"scalar", "text", false, nil, true},
+		{"nested object", map[string]interface{}{"a": map[string]interface{}{}}, false, nil, true},
+		{"list of scalars", []interface{}{"a"}, false, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, single, err := formRecords(tt.decoded)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("formRecords() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if single != tt.wantSingle {
+				t.Errorf("single = %v, want %v", single, tt.wantSingle)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d records, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				for k, v := range tt.want[i] {
+					if !slices.Equal(got[i][k], v) {
+						t.Errorf("record %d %s = %v, want %v", i, k, got[i][k], v)
+					}
+				}
+				if len(got[i]) != len(tt.want[i]) {
+					t.Errorf("record %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFormOutputs(t *testing.T) {
+	records := []map[string][]string{{"id": {"a1"}}, {"id": {"b/2"}}}
+
+	got, err := formOutputs("in.pdf", "", records[:1], true, "")
+	if err != nil || !slices.Equal(got, []string{"in_filled.pdf"}) {
+		t.Errorf("single = %v, %v", got, err)
+	}
+
+	got, err = formOutputs("in.pdf", "out.pdf", records, false, "")
+	if err != nil || !slices.Equal(got, []string{"out_1.pdf", "out_2.pdf"}) {
+		t.Errorf("numbered = %v, %v", got, err)
+	}
+
+	got, err = formOutputs("in.pdf", "out.pdf", records, false, "id")
+	if err != nil || !slices.Equal(got, []string{"out_a1.pdf", "out_b_2.pdf"}) {
+		t.Errorf("named = %v, %v", got, err)
+	}
+
+	if _, err := formOutputs("in.pdf", "", records, false, "missing"); err == nil {
+		t.Error("expected error for missing name field")
+	}
+	dup := []map[string][]string{{"id": {"x"}}, {"id": {"x"}}}
+	if _, err := formOutputs("in.pdf", "", dup, false, "id"); err == nil {
+		t.Error("expected error for duplicate output names")
+	}
+}
+
+func TestFormFillCommand(t *testing.T) {
+	resetFlags(t)
+	tmpDir := t.TempDir()
+
+	data := filepath.Join(tmpDir, "employees.csv")
+	csv := "employee_id,name,agree,langs\ne1,Ann,yes,Go;C\ne2,Bob,no,Rust\n"
+	if err := os.WriteFile(data, []byte(csv), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	base := filepath.Join(tmpDir, "onboarding.pdf")
+	if err := executeCommand("form", "fill", formPDF(), "--data", data, "--name-field", "employee_id", "-o", base); err != nil {
+		t.Fatalf("form fill failed: %v", err)
+	}
+
+	fields, err := pdf.ListFormFields(filepath.Join(tmpDir, "onboarding_e2.pdf"), "")
+	if err != nil {
+		t.Fatalf("ListFormFields() error = %v", err)
+	}
+	values := map[string]string{}
+	for _, f := range fields {
+		values[f.Name] = f.Value
+	}
+	if values["name"] != "Bob" || values["agree"] != "false" || values["langs"] != "Rust" {
+		t.Errorf("filled values = %v", values)
+	}
+
+	resetFlags(t)
+	bad := filepath.Join(tmpDir, "bad.json")
+	if err := os.WriteFile(bad, []byte(`{"nmae": "x"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err = executeCommand("form", "fill", formPDF(), "--data", bad, "-o", filepath.Join(tmpDir, "bad.pdf"))
+	if err == nil || !strings.Contains(err.Error(), "unknown form field") {
+		t.Errorf("expected unknown field error, got %v", err)
+	}
+}
+
+func TestFormExportCommand(t *testing.T) {
+	resetFlags(t)
+	out := filepath.Join(t.TempDir(), "template.csv")
+	if err := executeCommand("form", "export", formPDF(), "--format", "csv", "-o", out); err != nil {
+		t.Fatalf("form export failed: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || lines[0] != "name,agree,size,city,langs" {
+		t.Errorf("template = %q", data)
+	}
+}
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+)
+
+func TestCropCommand(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output := filepath.Join(tmpDir, "cropped.pdf")
+	if err := executeCommand("crop", samplePDF(), "--box", "10 10 10 10", "-p", "1-2", "-o", output); err != nil {
+		t.Fatalf("crop command failed: %v", err)
+	}
+
+	boxes, err := pdf.GetPageBoxes(output, "")
+	if err != nil {
+		t.Fatalf("GetPageBoxes() error = %v", err)
+	}
+	if boxes[0].CropBox.Width() != 592 || boxes[2].CropBox.Width() != 612 {
+		t.Errorf("unexpected crop boxes: page 1 %v, page 3 %v", boxes[0].CropBox, boxes[2].CropBox)
+	}
+}
+
+func TestCropCommand_InvalidBox(t *testing.T) {
+	resetFlags(t)
+	if err := executeCommand("crop", samplePDF(), "--box", "abc"); err == nil {
+		t.Error("crop with invalid box should fail")
+	}
+}
+
+func TestCropCommand_DryRun(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	if err := executeCommand("crop", samplePDF(), "--box", "36", "--dry-run"); err != nil {
+		t.Fatalf("crop --dry-run failed: %v", err)
+	}
+}
+
+func TestResizeCommand(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output := filepath.Join(tmpDir, "resized.pdf")
+	if err := executeCommand("resize", samplePDF(), "--size", "A4", "--fit", "-p", "2", "-o", output); err != nil {
+		t.Fatalf("resize command failed: %v", err)
+	}
+
+	info, err := pdf.GetInfo(output, "")
+	if err != nil {
+		t.Fatalf("GetInfo() error = %v", err)
+	}
+	if len(info.PageSizes) != 2 {
+		t.Errorf("expected 2 distinct page sizes after resizing page 2, got %+v", info.PageSizes)
+	}
+}
+
+func TestResizeCommand_InvalidFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no size or scale", []string{"resize", samplePDF()}},
+		{"size and scale", []string{"resize", samplePDF(), "--size", "A4", "--scale", "0.5"}},
+		{"unknown size", []string{"resize", samplePDF(), "--size", "Huge"}},
+		{"negative scale", []string{"resize", samplePDF(), "--scale", "-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags(t)
+			if err := executeCommand(tt.args...); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestBoxesCommand(t *testing.T) {
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	for _, format := range []string{"", "json", "csv", "tsv"} {
+		t.Run("format "+format, func(t *testing.T) {
+			resetFlags(t)
+			if err := executeCommand("boxes", samplePDF(), "--format", format); err != nil {
+				t.Fatalf("boxes command failed: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/lgbarn/pdf-cli/internal/cli"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{headerCmd, footerCmd} {
+		cli.AddCommand(cmd)
+		cli.AddOutputFlag(cmd, "Output file path (only with single file)")
+		cli.AddOutputDirFlag(cmd)
+		cli.AddBatchInputFlags(cmd)
+		cli.AddPagesFlag(cmd, "Pages to stamp (default: all)")
+		cli.AddPasswordFlag(cmd, "Password for encrypted PDFs")
+		cli.AddPasswordFileFlag(cmd, "")
+		cli.AddAllowInsecurePasswordFlag(cmd)
+		cmd.Flags().String("left", "", "Text at the left edge")
+		cmd.Flags().String("center", "", "Text in the center")
+		cmd.Flags().String("right", "", "Text at the right edge")
+		addStampStyleFlags(cmd)
+	}
+}
+
+var headerCmd = &cobra.Command{
+	Use:   "header <file.pdf> [file2.pdf...]",
+	Short: "Add a header to PDF(s)",
+	Long: `Add a header line to the top of each page of PDF file(s).
+
+The header has up to three parts: --left, --center and --right.
+Each part is a template that may contain placeholders:
+  {page}      current page number
+  {pages}     total number of pages
+  {date}      current date (YYYY-MM-DD)
+  {filename}  name of the input file
+
+Supports batch processing of multiple files. When processing
+multiple files, output files are named with '_stamped' suffix.
+
+Examples:
+  pdf header report.pdf --left "ACME Corp" --right "{date}" -o report-header.pdf
+  pdf header *.pdf --center "{filename}" --font-size 8`,
+	Args: cli.BatchArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStamp(cmd, args, "top")
+	},
+}
+
+var footerCmd = &cobra.Command{
+	Use:   "footer <file.pdf> [file2.pdf...]",
+	Short: "Add a footer to PDF(s)",
+	Long: `Add a footer line to the bottom of each page of PDF file(s).
+
+The footer has up to three parts: --left, --center and --right.
+Each part is a template that may contain placeholders:
+  {page}      current page number
+  {pages}     total number of pages
+  {date}      current date (YYYY-MM-DD)
+  {filename}  name of the input file
+
+Supports batch processing of multiple files. When processing
+multiple files, output files are named with '_stamped' suffix.
+
+Examples:
+  pdf footer report.pdf --center "Page {page} of {pages}" -o numbered.pdf
+  pdf footer *.pdf --left "Confidential" --right "{page}/{pages}" -p 2-end`,
+	Args: cli.BatchArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStamp(cmd, args, "bottom")
+	},
+}
+
+// addStampStyleFlags adds the font and placement flags shared by bates, header and footer.
+func addStampStyleFlags(cmd *cobra.Command) {
+	cmd.Flags().String("font", "Helvetica", "Font name (e.g., Helvetica, Times-Roman, Courier)")
+	cmd.Flags().Int("font-size", 10, "Font size in points")
+	cmd.Flags().String("color", "black", "Text color: name (red), #RRGGBB or \"r g b\" (0-1)")
+	cmd.Flags().Float64("margin", 20, "Distance from the page edges in points")
+}
+
+// stampStyle builds the options for text placed at position from the style flags.
+func stampStyle(cmd *cobra.Command, position string) (pdf.WatermarkOptions, error) {
+	flags := cmd.Flags()
+	margin, _ := flags.GetFloat64("margin")
+	if margin < 0 {
+		return pdf.WatermarkOptions{}, fmt.Errorf("invalid margin %g: must not be negative", margin)
+	}
+
+	opts := pdf.DefaultStampOptions(position, margin)
+	opts.FontName, _ = flags.GetString("font")
+	opts.FontSize, _ = flags.GetInt("font-size")
+	opts.Color, _ = flags.GetString("color")
+	if opts.FontSize < 1 {
+		return opts, fmt.Errorf("invalid font size %d: must be positive", opts.FontSize)
+	}
+	if err := pdf.ValidateWatermarkOptions(opts, true); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// stampTemplates builds one stamp per non-empty --left, --center and --right
+// template, anchored to the given edge ("top" or "bottom").
+func stampTemplates(cmd *cobra.Command, edge string) ([]pdf.TextStamp, error) {
+	var stamps []pdf.TextStamp
+	for _, part := range []struct{ flag, position string }{
+		{"left", edge + "-left"},
+		{"center", edge},
+		{"right", edge + "-right"},
+	} {
+		text, _ := cmd.Flags().GetString(part.flag)
+		if text == "" {
+			continue
+		}
+		opts, err := stampStyle(cmd, part.position)
+		if err != nil {
+			return nil, err
+		}
+		stamps = append(stamps, pdf.TextStamp{Text: text, Options: opts})
+	}
+	if len(stamps) == 0 {
+		return nil, fmt.Errorf("must specify at least one of --left, --center or --right")
+	}
+	return stamps, nil
+}
+
+func runStamp(cmd *cobra.Command, args []string, edge string) error {
+	args, err := batchInputs(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	pagesStr := cli.GetPages(cmd)
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	output, err := sanitizeOutputPath(cli.GetOutput(cmd))
+	if err != nil {
+		return err
+	}
+
+	stamps, err := stampTemplates(cmd, edge)
+	if err != nil {
+		return err
+	}
+
+	// Handle dry-run mode
+	if cli.IsDryRun() {
+		return stampDryRun(cmd.Name(), args, output, pagesStr, password, stamps)
+	}
+
+	if err := validateBatchOutput(args, output, SuffixStamped); err != nil {
+		return err
+	}
+
+	return processBatch(args, output, SuffixStamped, func(w io.Writer, inputFile, out string) error {
+		return stampFile(w, cmd.Name(), inputFile, out, pagesStr, password, stamps)
+	})
+}
+
+func stampDryRun(name string, args []string, explicitOutput, pagesStr, password string, stamps []pdf.TextStamp) error {
+	for _, inputFile := range args {
+		info, err := pdf.GetInfo(inputFile, password)
+		if err != nil {
+			cli.DryRunPrint("Would add %s: %s (unable to read info)", name, inputFile)
+			continue
+		}
+
+		output := outputOrDefault(explicitOutput, inputFile, SuffixStamped)
+		cli.DryRunPrint("Would add %s: %s (%d pages)", name, inputFile, info.Pages)
+		for _, s := range stamps {
+			cli.DryRunPrint("  %s: %q", s.Options.Position, s.Text)
+		}
+		if pagesStr != "" || cli.InvertPages() {
+			cli.DryRunPrint("  Pages: %s", describePages(pagesStr))
+		}
+		cli.DryRunPrint("  Output: %s", output)
+	}
+	return nil
+}
+
+func stampFile(w io.Writer, name, inputFile, explicitOutput, pagesStr, password string, stamps []pdf.TextStamp) error {
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return err
+	}
+
+	pageNums, err := parseAndValidatePages(pagesStr, inputFile, password)
+	if err != nil {
+		return err
+	}
+
+	output := outputOrDefault(explicitOutput, inputFile, SuffixStamped)
+	if err := checkOutputFile(output); err != nil {
+		return err
+	}
+
+	cli.PrintVerbose("Adding %s to %s", name, inputFile)
+
+	if err := pdf.AddTextStamps(inputFile, output, stamps, pageNums, password); err != nil {
+		return pdferrors.WrapError("adding "+name, inputFile, err)
+	}
+
+	fmt.Fprintf(w, "Added %s to %s\n", name, output)
+	return nil
+}
@@ -1,31 +1,60 @@
 package commands
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/lgbarn/pdf-cli/internal/cli"
 	"github.com/lgbarn/pdf-cli/internal/fileio"
 	"github.com/lgbarn/pdf-cli/internal/pages"
 	"github.com/lgbarn/pdf-cli/internal/pdf"
 	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/lgbarn/pdf-cli/internal/progress"
+	"github.com/schollz/progressbar/v3"
+	"github.com/spf13/cobra"
 )
 
 // Output filename suffixes for batch operations.
 const (
-	SuffixEncrypted   = "_encrypted"
-	SuffixDecrypted   = "_decrypted"
-	SuffixCompressed  = "_compressed"
-	SuffixRotated     = "_rotated"
-	SuffixWatermarked = "_watermarked"
-	SuffixReordered   = "_reordered"
+	SuffixEncrypted     = "_encrypted"
+	SuffixDecrypted     = "_decrypted"
+	SuffixCompressed    = "_compressed"
+	SuffixRotated       = "_rotated"
+	SuffixWatermarked   = "_watermarked"
+	SuffixUnwatermarked = "_unwatermarked"
+	SuffixReordered     = "_reordered"
+	SuffixCropped       = "_cropped"
+	SuffixResized       = "_resized"
+	SuffixInserted      = "_inserted"
+	SuffixDeleted       = "_deleted"
+	SuffixBates         = "_bates"
+	SuffixStamped       = "_stamped"
+	SuffixRedacted      = "_redacted"
+	SuffixSanitized     = "_sanitized"
+	SuffixUpdated       = "_updated"
+	SuffixFilled        = "_filled"
+	SuffixAttached      = "_attached"
+	SuffixDetached      = "_detached"
+	SuffixUnannotated   = "_unannotated"
+	SuffixFlattened     = "_flattened"
+	SuffixRelinked      = "_relinked"
+	SuffixSigned        = "_signed"
+	SuffixUnlocked      = "_unlocked"
 )
 
 // checkOutputFile verifies the output file can be written.
 // Returns an error if the file exists and force mode is not enabled.
 func checkOutputFile(output string) error {
 	if fileio.FileExists(output) && !cli.Force() {
-		return fmt.Errorf("output file already exists: %s (use -f to overwrite)", output)
+		return fmt.Errorf("%w: %s (use -f to overwrite)", pdferrors.ErrOutputExists, output)
 	}
 	return nil
 }
@@ -33,32 +62,53 @@ func checkOutputFile(output string) error {
 // parseAndValidatePages parses the pages string and validates against the PDF.
 // Returns nil slice if pagesStr is empty (meaning "all pages").
 func parseAndValidatePages(pagesStr, inputFile, password string) ([]int, error) {
-	if pagesStr == "" {
+	if pagesStr == "" && !cli.InvertPages() {
 		return nil, nil
 	}
 
-	pageNums, err := pages.ParseAndExpandPages(pagesStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid page specification: %w", err)
-	}
-
 	pageCount, err := pdf.PageCount(inputFile, password)
 	if err != nil {
 		return nil, pdferrors.WrapError("reading file", inputFile, err)
 	}
 
-	if err := pages.ValidatePageNumbers(pageNums, pageCount); err != nil {
-		return nil, err
-	}
+	return selectPages(pagesStr, pageCount)
+}
 
+// selectPages returns the pages of a document with pageCount pages selected
+// by pagesStr, or with --invert the pages it leaves out.
+func selectPages(pagesStr string, pageCount int) ([]int, error) {
+	parse := pages.ParsePageSelection
+	if cli.InvertPages() {
+		parse = pages.ParseInvertedSelection
+	}
+	pageNums, err := parse(pagesStr, pageCount)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pdferrors.ErrInvalidPages, err)
+	}
 	return pageNums, nil
 }
 
-// outputOrDefault returns output if non-empty, otherwise generates a default filename.
+// describePages describes the page selection for dry-run output.
+func describePages(pagesStr string) string {
+	switch {
+	case cli.InvertPages():
+		return "all pages except " + pagesStr
+	case pagesStr == "":
+		return "all pages"
+	default:
+		return "pages " + pagesStr
+	}
+}
+
+// outputOrDefault returns output if non-empty, otherwise the input path below
+// --output-dir or, without it, a default filename with the suffix.
 func outputOrDefault(output, inputFile, suffix string) string {
 	if output != "" {
 		return output
 	}
+	if dir := cli.OutputDir(); dir != "" {
+		return fileio.MirrorPath(dir, inputFile)
+	}
 	return fileio.GenerateOutputFilename(inputFile, suffix)
 }
 
@@ -80,6 +130,76 @@ func sanitizeInputArgs(args []string) ([]string, error) {
 	return sanitized, nil
 }
 
+// batchInputs returns the input files of a batch command: the arguments and
+// the entries of the --files-from list, with directories and glob patterns
+// expanded as set by --recursive, --include and --exclude.
+func batchInputs(cmd *cobra.Command, args []string) ([]string, error) {
+	if list := cli.GetFilesFrom(cmd); list != "" {
+		if fileio.IsStdinInput(list) && slices.Contains(args, "-") {
+			return nil, errors.New("cannot read both the file list and a PDF from stdin")
+		}
+		list, err := fileio.SanitizePath(list)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file list path: %w", err)
+		}
+		listed, err := fileio.ReadFileList(list)
+		if err != nil {
+			return nil, err
+		}
+		args = append(slices.Clip(args), listed...)
+	}
+
+	args, err := sanitizeInputArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	files, err := fileio.ExpandInputs(args, cli.GetInputOptions(cmd))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.New("no input files found")
+	}
+	if err := checkOutputDir(files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// checkOutputDir verifies that the outputs of files below --output-dir are
+// distinct and do not overwrite any of the inputs.
+func checkOutputDir(files []string) error {
+	dir := cli.OutputDir()
+	if dir == "" {
+		return nil
+	}
+	if _, err := fileio.SanitizePath(dir); err != nil {
+		return fmt.Errorf("invalid output directory: %w", err)
+	}
+
+	inputs := make(map[string]string, len(files))
+	for _, file := range files {
+		if fileio.IsStdinInput(file) {
+			return errors.New("cannot use --output-dir with stdin input")
+		}
+		abs, _ := filepath.Abs(file)
+		inputs[abs] = file
+	}
+	outputs := make(map[string]string, len(files))
+	for _, file := range files {
+		output := fileio.MirrorPath(dir, file)
+		abs, _ := filepath.Abs(output)
+		if input, ok := inputs[abs]; ok {
+			return fmt.Errorf("--output-dir would overwrite the input %s", input)
+		}
+		if other, ok := outputs[abs]; ok {
+			return fmt.Errorf("%s and %s would both be written to %s", other, file, output)
+		}
+		outputs[abs] = file
+	}
+	return nil
+}
+
 // sanitizeOutputPath validates and cleans an output file path from flags.
 // Returns the path unchanged if empty or stdin marker "-".
 func sanitizeOutputPath(output string) (string, error) {
@@ -94,13 +214,225 @@ func sanitizeOutputPath(output string) (string, error) {
 }
 
 // processBatch processes multiple files with the given processor function.
-// Each file is processed independently, and all errors are collected and joined.
-func processBatch(files []string, processor func(file string) error) error {
-	var errs []error
-	for _, file := range files {
-		if err := processor(file); err != nil {
-			errs = append(errs, fmt.Errorf("%s: %w", file, err))
+// Each file is processed independently and gets the output path built from
+// output and suffix. Up to --jobs files are processed at a time, so the
+// processor must not share state between files, and prints its report to w
+// rather than stdout.
+func processBatch(files []string, output, suffix string, processor func(w io.Writer, input, output string) error) error {
+	jobs := make([]batchJob, len(files))
+	for i, file := range files {
+		jobs[i] = batchJob{input: file, output: batchOutput(output, file, suffix)}
+	}
+	return runBatch(jobs, false, cli.FailFast(), func(i int, w io.Writer) error {
+		if cli.OutputDir() != "" {
+			if err := fileio.EnsureParentDir(jobs[i].output); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+		}
+		return processor(w, jobs[i].input, jobs[i].output)
+	})
+}
+
+// processBatchInOrder processes files one after the other, for commands that
+// print or collect their results as they go and write no files.
+func processBatchInOrder(files []string, processor func(input string) error) error {
+	jobs := make([]batchJob, len(files))
+	for i, file := range files {
+		jobs[i] = batchJob{input: file}
+	}
+	return runBatch(jobs, true, cli.FailFast(), func(i int, _ io.Writer) error {
+		return processor(jobs[i].input)
+	})
+}
+
+// batchOutput returns the output path of input, or "" for commands that
+// write no files.
+func batchOutput(output, input, suffix string) string {
+	if output == "" && suffix == "" {
+		return ""
+	}
+	return outputOrDefault(output, input, suffix)
+}
+
+// batchJob is one file of a batch and the output it is written to.
+type batchJob struct {
+	input  string
+	output string
+}
+
+// runBatch runs process for each job and records its result. The jobs are
+// processed by a pool of --jobs workers, or one after the other if inOrder
+// is set, and their results are kept in job order. Each job writes its
+// output to its own buffer, which is copied to stdout in job order as the
+// jobs finish. With failFast, usually --fail-fast, no jobs are started after
+// the first failure, and after the command context is canceled the
+// remaining jobs fail with the context error. The results are
+// reported with reportBatch, and returned in a pdferrors.BatchError if any
+// job failed.
+func runBatch(jobs []batchJob, inOrder, failFast bool, process func(i int, w io.Writer) error) error {
+	ctx := cli.Context()
+	start := time.Now()
+	results := make([]pdferrors.FileResult, len(jobs))
+	for i, job := range jobs {
+		results[i] = pdferrors.FileResult{Input: job.input, Output: job.output}
+	}
+
+	workers := 1
+	var bar *progressbar.ProgressBar
+	if !inOrder {
+		workers = min(cli.Jobs(), len(jobs))
+		if cli.Progress() && !cli.JSONOutput() {
+			bar = progress.NewProgressBar("Processing files", len(jobs), 1)
+		}
+	}
+
+	outs := newBatchOutputs(len(jobs), bar)
+	var failed atomic.Bool
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					outs.finish(i, false)
+					continue
+				}
+				if failed.Load() && failFast {
+					results[i].Skipped = true
+					outs.finish(i, false)
+					continue
+				}
+				runJob(&results[i], jobs[i], func() error { return process(i, &outs.bufs[i]) })
+				results[i].Warnings = outs.bufs[i].warnings
+				if results[i].Err != nil {
+					failed.Store(true)
+				}
+				outs.finish(i, true)
+			}
+		}()
+	}
+
+	started := 0
+dispatch:
+	for started < len(jobs) && ctx.Err() == nil && !(failed.Load() && failFast) {
+		select {
+		case next <- started:
+			started++
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(next)
+	wg.Wait()
+	progress.FinishProgressBar(bar)
+
+	for i := started; i < len(jobs); i++ {
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+		} else {
+			results[i].Skipped = true
+		}
+	}
+
+	elapsed := time.Since(start)
+	reportBatch(results, elapsed)
+	return pdferrors.NewBatchError(results, elapsed)
+}
+
+// jobOutput is the writer a batch job prints its report to, together with
+// the warnings recorded with batchWarning.
+type jobOutput struct {
+	bytes.Buffer
+	warnings []string
+}
+
+// batchWarning reports a problem with input that does not fail the job
+// writing to w. The warning is printed to stderr, and recorded in the
+// result of the job for the JSON results, where it is not printed.
+func batchWarning(w io.Writer, input, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if out, ok := w.(*jobOutput); ok {
+		out.warnings = append(out.warnings, msg)
+	}
+	if !cli.JSONOutput() {
+		cli.PrintStatus("Warning: %s: %s", input, msg)
+	}
+}
+
+// batchOutputs holds the output of the jobs of a batch until it can be
+// written to stdout in job order.
+type batchOutputs struct {
+	mu   sync.Mutex
+	bufs []jobOutput
+	done []bool
+	next int // first job whose output has not been written
+	bar  *progressbar.ProgressBar
+}
+
+func newBatchOutputs(n int, bar *progressbar.ProgressBar) *batchOutputs {
+	return &batchOutputs{bufs: make([]jobOutput, n), done: make([]bool, n), bar: bar}
+}
+
+// finish marks job i as finished, advancing the progress bar if the job was
+// processed, and writes the output of the finished jobs that no unfinished
+// job precedes. The progress bar is cleared while writing, so that the
+// output does not mix with it.
+func (o *batchOutputs) finish(i int, processed bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.done[i] = true
+	first := o.next
+	for o.next < len(o.done) && o.done[o.next] {
+		o.next++
+	}
+	var out []byte
+	for j := first; j < o.next; j++ {
+		out = append(out, o.bufs[j].Bytes()...)
+		o.bufs[j].Buffer = bytes.Buffer{}
+	}
+	if len(out) > 0 {
+		if o.bar != nil {
+			_ = o.bar.Clear()
+		}
+		_, _ = os.Stdout.Write(out)
+	}
+
+	switch {
+	case o.bar == nil:
+	case processed:
+		_ = o.bar.Add(1)
+	case len(out) > 0:
+		_ = o.bar.RenderBlank()
+	}
+}
+
+// runJob runs process for job and records its error, duration and sizes in
+// result.
+func runJob(result *pdferrors.FileResult, job batchJob, process func() error) {
+	result.SizeBefore, _ = fileio.GetFileSize(job.input)
+	jobStart := time.Now()
+	result.Err = process()
+	result.Duration = time.Since(jobStart)
+	if result.Err == nil && job.output != "" {
+		result.SizeAfter, _ = fileio.GetFileSize(job.output)
+	}
+}
+
+// reportBatch prints the results of a batch to stderr: as a JSON envelope
+// in JSON mode when all files succeeded, since failures are reported in the
+// error envelope, and as a summary line for more than one file otherwise.
+func reportBatch(results []pdferrors.FileResult, elapsed time.Duration) {
+	summary := pdferrors.Summarize(results, elapsed)
+	switch {
+	case cli.JSONOutput():
+		if summary.Failed == 0 {
+			fmt.Fprintln(os.Stderr, string(pdferrors.NewBatchEnvelope(results, elapsed).JSON()))
 		}
+	case len(results) > 1:
+		cli.PrintStatus("Batch: %s", summary)
 	}
-	return errors.Join(errs...)
 }
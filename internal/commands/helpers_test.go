@@ -8,6 +8,7 @@ import (
 
 	"github.com/lgbarn/pdf-cli/internal/cli"
 	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/spf13/pflag"
 )
 
 // testdataDir returns the absolute path to the testdata directory
@@ -34,6 +35,15 @@ func resetFlags(t *testing.T) {
 	_ = rootCmd.PersistentFlags().Set("verbose", "false")
 	_ = rootCmd.PersistentFlags().Set("force", "false")
 	_ = rootCmd.PersistentFlags().Set("dry-run", "false")
+	// Batch switches are mutually exclusive, so they must not stay marked as changed
+	for _, name := range []string{"fail-fast", "continue-on-error"} {
+		f := rootCmd.PersistentFlags().Lookup(name)
+		_ = f.Value.Set("false")
+		f.Changed = false
+	}
+	jobs := rootCmd.PersistentFlags().Lookup("jobs")
+	_ = jobs.Value.Set(jobs.DefValue)
+	jobs.Changed = false
 
 	// Reset subcommand flags by finding and resetting each one
 	for _, cmd := range rootCmd.Commands() {
@@ -49,6 +59,9 @@ func resetFlags(t *testing.T) {
 				_ = cmd.Flags().Set("pages", "")
 			}
 		}
+		if f := cmd.Flags().Lookup("invert"); f != nil {
+			_ = cmd.Flags().Set("invert", "false")
+		}
 		if f := cmd.Flags().Lookup("password"); f != nil {
 			_ = cmd.Flags().Set("password", "")
 		}
@@ -89,6 +102,67 @@ func resetFlags(t *testing.T) {
 		if f := cmd.Flags().Lookup("creator"); f != nil {
 			_ = cmd.Flags().Set("creator", "")
 		}
+		// Reset page geometry flags
+		if f := cmd.Flags().Lookup("box"); f != nil {
+			_ = cmd.Flags().Set("box", "")
+		}
+		if f := cmd.Flags().Lookup("size"); f != nil {
+			_ = cmd.Flags().Set("size", "")
+		}
+		if f := cmd.Flags().Lookup("scale"); f != nil {
+			_ = cmd.Flags().Set("scale", "0")
+		}
+		if f := cmd.Flags().Lookup("fit"); f != nil {
+			_ = cmd.Flags().Set("fit", "false")
+		}
+		// Reset insert flags
+		if f := cmd.Flags().Lookup("at"); f != nil {
+			_ = cmd.Flags().Set("at", "")
+		}
+		if f := cmd.Flags().Lookup("after"); f != nil {
+			_ = cmd.Flags().Set("after", "")
+		}
+		if f := cmd.Flags().Lookup("from"); f != nil {
+			_ = cmd.Flags().Set("from", "")
+		}
+		if f := cmd.Flags().Lookup("blank"); f != nil {
+			_ = cmd.Flags().Set("blank", "false")
+		}
+		// Reset watermark, stamp and bates flags; defaults differ between commands
+		for _, name := range []string{
+			"font", "font-size", "color", "opacity", "rotation", "position", "offset", "background",
+			"left", "center", "right", "margin", "prefix", "suffix", "start", "digits", "log",
+			"remove", "list", "pattern-file", "annotations", "created", "modified", "xmp", "trust-store",
+			"cert", "key", "type", "field", "name", "reason", "location", "contact", "visible", "page", "rect", "wordlist",
+			"recursive", "files-from", "output-dir",
+		} {
+			if f := cmd.Flags().Lookup(name); f != nil {
+				_ = cmd.Flags().Set(name, f.DefValue)
+			}
+		}
+		for _, name := range []string{"pattern", "set", "clear", "recipient", "include", "exclude"} {
+			if f := cmd.Flags().Lookup(name); f != nil {
+				if sv, ok := f.Value.(pflag.SliceValue); ok {
+					_ = sv.Replace(nil)
+				}
+			}
+		}
+		// Setting a flag marks it as changed; clear that so defaults apply again
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			f.Changed = false
+		})
+
+		// Subcommands such as "form fill" are reset to their defaults wholesale
+		for _, sub := range cmd.Commands() {
+			sub.Flags().VisitAll(func(f *pflag.Flag) {
+				if sv, ok := f.Value.(pflag.SliceValue); ok {
+					_ = sv.Replace(nil)
+				} else {
+					_ = f.Value.Set(f.DefValue)
+				}
+				f.Changed = false
+			})
+		}
 	}
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/lgbarn/pdf-cli/internal/cli"
 	"github.com/lgbarn/pdf-cli/internal/fileio"
 	"github.com/lgbarn/pdf-cli/internal/output"
+	"github.com/lgbarn/pdf-cli/internal/pages"
 	"github.com/lgbarn/pdf-cli/internal/pdf"
 	"github.com/lgbarn/pdf-cli/internal/pdferrors"
 	"github.com/spf13/cobra"
@@ -20,6 +21,7 @@ func init() {
 	cli.AddPasswordFileFlag(infoCmd, "")
 	cli.AddAllowInsecurePasswordFlag(infoCmd)
 	cli.AddFormatFlag(infoCmd)
+	cli.AddBatchInputFlags(infoCmd)
 }
 
 var infoCmd = &cobra.Command{
@@ -39,12 +41,12 @@ Examples:
   pdf info *.pdf                           # Batch mode: show summary table
   cat document.pdf | pdf info -            # Read from stdin
   pdf info document.pdf --format json      # JSON output`,
-	Args: cobra.MinimumNArgs(1),
+	Args: cli.BatchArgs,
 	RunE: runInfo,
 }
 
 func runInfo(cmd *cobra.Command, args []string) error {
-	args, err := sanitizeInputArgs(args)
+	args, err := batchInputs(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -83,9 +85,19 @@ type InfoOutput struct {
 	Pages     int               `json:"pages"`
 	Version   string            `json:"version"`
 	Encrypted bool              `json:"encrypted"`
+	PageSizes []PageSizeOutput  `json:"page_sizes,omitempty"`
 	Metadata  map[string]string `json:"metadata,omitempty"`
 }
 
+// PageSizeOutput represents a distinct page size and the pages using it.
+type PageSizeOutput struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Name   string  `json:"name,omitempty"`
+	Pages  string  `json:"pages"`
+	Count  int     `json:"count"`
+}
+
 func displaySingleInfo(inputFile, password string, formatter *output.OutputFormatter, isStdin bool) error {
 	if !isStdin {
 		if err := fileio.ValidatePDFFile(inputFile); err != nil {
@@ -109,6 +121,7 @@ func displaySingleInfo(inputFile, password string, formatter *output.OutputForma
 			Pages:     info.Pages,
 			Version:   info.Version,
 			Encrypted: info.Encrypted,
+			PageSizes: pageSizeOutputs(info.PageSizes),
 			Metadata:  make(map[string]string),
 		}
 		if info.Title != "" {
@@ -129,6 +142,12 @@ func displaySingleInfo(inputFile, password string, formatter *output.OutputForma
 		if info.Producer != "" {
 			output.Metadata["producer"] = info.Producer
 		}
+		if info.CreatedDate != "" {
+			output.Metadata["created"] = info.CreatedDate
+		}
+		if info.ModDate != "" {
+			output.Metadata["modified"] = info.ModDate
+		}
 		return formatter.Print(output)
 	}
 
@@ -138,6 +157,7 @@ func displaySingleInfo(inputFile, password string, formatter *output.OutputForma
 	fmt.Printf("Pages:      %d\n", info.Pages)
 	fmt.Printf("Version:    PDF %s\n", info.Version)
 	fmt.Printf("Encrypted:  %t\n", info.Encrypted)
+	printPageSizes(info.PageSizes)
 
 	printIfSet("Title", info.Title)
 	printIfSet("Author", info.Author)
@@ -145,21 +165,31 @@ func displaySingleInfo(inputFile, password string, formatter *output.OutputForma
 	printIfSet("Keywords", info.Keywords)
 	printIfSet("Creator", info.Creator)
 	printIfSet("Producer", info.Producer)
+	printIfSet("Created", info.CreatedDate)
+	printIfSet("Modified", info.ModDate)
 
 	return nil
 }
 
 func displayBatchInfo(files []string, password string, formatter *output.OutputFormatter) error {
+	readInfo := func(file string) (*pdf.Info, error) {
+		if err := fileio.ValidatePDFFile(file); err != nil {
+			return nil, err
+		}
+		info, err := pdf.GetInfo(file, password)
+		if err != nil {
+			return nil, pdferrors.WrapError("reading info", file, err)
+		}
+		return info, nil
+	}
+
 	// Structured output (JSON/CSV/TSV)
 	if formatter.IsStructured() {
 		var outputs []InfoOutput
-		for _, file := range files {
-			if err := fileio.ValidatePDFFile(file); err != nil {
-				continue
-			}
-			info, err := pdf.GetInfo(file, password)
+		batchErr := processBatchInOrder(files, func(file string) error {
+			info, err := readInfo(file)
 			if err != nil {
-				continue
+				return err
 			}
 			output := InfoOutput{
 				File:      info.FilePath,
@@ -168,6 +198,7 @@ func displayBatchInfo(files []string, password string, formatter *output.OutputF
 				Pages:     info.Pages,
 				Version:   info.Version,
 				Encrypted: info.Encrypted,
+				PageSizes: pageSizeOutputs(info.PageSizes),
 				Metadata:  make(map[string]string),
 			}
 			if info.Title != "" {
@@ -177,10 +208,14 @@ func displayBatchInfo(files []string, password string, formatter *output.OutputF
 				output.Metadata["author"] = info.Author
 			}
 			outputs = append(outputs, output)
-		}
+			return nil
+		})
 
 		if formatter.Format == output.FormatJSON {
-			return formatter.Print(outputs)
+			if err := formatter.Print(outputs); err != nil {
+				return err
+			}
+			return batchErr
 		}
 
 		// CSV/TSV: use table format
@@ -195,7 +230,10 @@ func displayBatchInfo(files []string, password string, formatter *output.OutputF
 				strconv.FormatBool(o.Encrypted),
 			})
 		}
-		return formatter.PrintTable(headers, rows)
+		if err := formatter.PrintTable(headers, rows); err != nil {
+			return err
+		}
+		return batchErr
 	}
 
 	// Human-readable output
@@ -203,18 +241,12 @@ func displayBatchInfo(files []string, password string, formatter *output.OutputF
 	fmt.Println(strings.Repeat("-", 70))
 
 	var hasErrors bool
-	for _, file := range files {
-		if err := fileio.ValidatePDFFile(file); err != nil {
-			fmt.Printf("%-40s ERROR: %v\n", truncateString(filepath.Base(file), 40), err)
-			hasErrors = true
-			continue
-		}
-
-		info, err := pdf.GetInfo(file, password)
+	batchErr := processBatchInOrder(files, func(file string) error {
+		info, err := readInfo(file)
 		if err != nil {
 			fmt.Printf("%-40s ERROR: %v\n", truncateString(filepath.Base(file), 40), err)
 			hasErrors = true
-			continue
+			return err
 		}
 
 		fmt.Printf("%-40s %8d %6s %10s\n",
@@ -222,14 +254,56 @@ func displayBatchInfo(files []string, password string, formatter *output.OutputF
 			info.Pages,
 			info.Version,
 			fileio.FormatFileSize(info.FileSize))
-	}
+		return nil
+	})
 
 	if hasErrors {
 		fmt.Println()
-		fmt.Println("Some files had errors. Use -v for details.")
 	}
+	return batchErr
+}
 
-	return nil
+// pageSizeOutputs converts page sizes for structured output.
+func pageSizeOutputs(sizes []pdf.PageSize) []PageSizeOutput {
+	outputs := make([]PageSizeOutput, 0, len(sizes))
+	for _, ps := range sizes {
+		outputs = append(outputs, PageSizeOutput{
+			Width:  ps.Width,
+			Height: ps.Height,
+			Name:   ps.Name,
+			Pages:  pages.FormatPageRanges(ps.Pages),
+			Count:  len(ps.Pages),
+		})
+	}
+	return outputs
+}
+
+// printPageSizes prints each distinct page size with the pages that use it.
+// A single size is printed on one line; mixed sizes are listed individually.
+func printPageSizes(sizes []pdf.PageSize) {
+	switch len(sizes) {
+	case 0:
+		return
+	case 1:
+		fmt.Printf("Page size:  %s\n", formatPageSize(sizes[0]))
+		return
+	}
+
+	fmt.Printf("Page sizes: %d distinct\n", len(sizes))
+	for _, ps := range sizes {
+		fmt.Printf("  %-28s pages %s\n", formatPageSize(ps), pages.FormatPageRanges(ps.Pages))
+	}
+}
+
+// formatPageSize formats a page size as "W x H pt (Name)".
+func formatPageSize(ps pdf.PageSize) string {
+	s := fmt.Sprintf("%s x %s pt",
+		strconv.FormatFloat(ps.Width, 'f', -1, 64),
+		strconv.FormatFloat(ps.Height, 'f', -1, 64))
+	if ps.Name != "" {
+		s += " (" + ps.Name + ")"
+	}
+	return s
 }
 
 func printIfSet(label, value string) {
@@ -0,0 +1,327 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lgbarn/pdf-cli/internal/cli"
+	"github.com/lgbarn/pdf-cli/internal/commands/patterns"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/pages"
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cli.AddCommand(insertCmd)
+	cli.AddOutputFlag(insertCmd, "Output file path")
+	cli.AddPasswordFlag(insertCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(insertCmd, "")
+	cli.AddAllowInsecurePasswordFlag(insertCmd)
+	cli.AddStdoutFlag(insertCmd)
+	insertCmd.Flags().String("at", "", "Insert before these pages (e.g., 5 or 1,end)")
+	insertCmd.Flags().String("after", "", "Insert after these pages (e.g., 3,7 or end)")
+	insertCmd.Flags().String("from", "", "PDF to insert pages from, optionally with pages (file.pdf:1-2)")
+	insertCmd.Flags().Bool("blank", false, "Insert a blank page at each position")
+	insertCmd.Flags().String("size", "", "Blank page size: A4, Letter, A4L (landscape), or WxH in points (default: size of the adjacent page)")
+}
+
+var insertCmd = &cobra.Command{
+	Use:   "insert <file.pdf>",
+	Short: "Insert pages into a PDF",
+	Long: `Insert blank pages or pages from another PDF into a PDF file.
+
+Use --at to insert before the given pages, or --after to insert after
+them. Positions use the same syntax as reorder: individual pages (3,7),
+ranges (1-5) and end for the last page. Pages are inserted at every
+listed position.
+
+Use --from to insert pages from another PDF. Append :PAGES to the file
+name to insert only some of its pages (cover.pdf:1-2). Use --blank to
+insert one blank page at each position instead. Blank pages match the
+size of the page they are inserted next to unless --size is given.
+
+Use "-" to read from stdin. Use --stdout for binary output.
+
+Examples:
+  pdf insert contract.pdf --at 5 --from signature.pdf -o signed.pdf
+  pdf insert report.pdf --at 1 --from cover.pdf:1-2 -o report-cover.pdf
+  pdf insert notes.pdf --blank --after 3,7 --size Letter -o spaced.pdf
+  pdf insert doc.pdf --blank --after end -o padded.pdf`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInsert,
+}
+
+// insertOptions holds the parsed flags of the insert command.
+type insertOptions struct {
+	positions   string // position spec from --at or --after
+	before      bool   // true for --at
+	source      string // source PDF from --from, empty for blank pages
+	sourcePages string // page spec following the source file name
+	size        string // blank page size
+}
+
+func runInsert(cmd *cobra.Command, args []string) error {
+	// Sanitize input path
+	sanitizedPath, err := fileio.SanitizePath(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid file path: %w", err)
+	}
+	inputArg := sanitizedPath
+
+	explicitOutput, err := sanitizeOutputPath(cli.GetOutput(cmd))
+	if err != nil {
+		return err
+	}
+
+	opts, err := parseInsertFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	toStdout := cli.GetStdout(cmd)
+
+	// Handle dry-run mode early
+	if cli.IsDryRun() {
+		return insertDryRun(inputArg, explicitOutput, opts, password)
+	}
+
+	if opts.source != "" {
+		if err := fileio.ValidatePDFFile(opts.source); err != nil {
+			return err
+		}
+	}
+
+	handler := &patterns.StdioHandler{
+		InputArg:       inputArg,
+		ExplicitOutput: explicitOutput,
+		ToStdout:       toStdout,
+		DefaultSuffix:  SuffixInserted,
+		Operation:      "insert",
+	}
+	defer handler.Cleanup()
+
+	input, output, err := handler.Setup()
+	if err != nil {
+		return err
+	}
+
+	if !fileio.IsStdinInput(inputArg) {
+		if err := fileio.ValidatePDFFile(input); err != nil {
+			return err
+		}
+	}
+
+	pageCount, err := pdf.PageCount(input, password)
+	if err != nil {
+		return pdferrors.WrapError("reading file", inputArg, err)
+	}
+
+	positions, err := parseInsertPositions(opts.positions, pageCount)
+	if err != nil {
+		return err
+	}
+
+	sourcePages, err := resolveSourcePages(opts, password)
+	if err != nil {
+		return err
+	}
+
+	if !toStdout {
+		if err := checkOutputFile(output); err != nil {
+			return err
+		}
+	}
+
+	cli.PrintVerbose("Inserting %s %s pages %v of %s -> %s", describeInsertion(opts), positionWord(opts), positions, inputArg, output)
+
+	if opts.source == "" {
+		err = pdf.InsertBlankPages(input, output, positions, opts.before, opts.size, password)
+	} else {
+		err = pdf.InsertPagesFrom(input, output, opts.source, sourcePages, positions, opts.before, password)
+	}
+	if err != nil {
+		return pdferrors.WrapError("inserting pages", inputArg, err)
+	}
+
+	if err := handler.Finalize(); err != nil {
+		return err
+	}
+
+	if !toStdout {
+		inserted := len(positions)
+		if opts.source != "" {
+			inserted *= len(sourcePages)
+		}
+		fmt.Printf("Inserted %d pages into %s (%d pages)\n", inserted, output, pageCount+inserted)
+	}
+	return nil
+}
+
+// parseInsertFlags reads and validates the insert command flags.
+func parseInsertFlags(cmd *cobra.Command) (insertOptions, error) {
+	at, _ := cmd.Flags().GetString("at")
+	after, _ := cmd.Flags().GetString("after")
+	from, _ := cmd.Flags().GetString("from")
+	blank, _ := cmd.Flags().GetBool("blank")
+	size, _ := cmd.Flags().GetString("size")
+
+	var opts insertOptions
+	switch {
+	case at == "" && after == "":
+		return opts, fmt.Errorf("must specify either --at or --after")
+	case at != "" && after != "":
+		return opts, fmt.Errorf("cannot specify both --at and --after")
+	case at != "":
+		opts.positions, opts.before = at, true
+	default:
+		opts.positions = after
+	}
+
+	switch {
+	case from == "" && !blank:
+		return opts, fmt.Errorf("must specify either --from or --blank")
+	case from != "" && blank:
+		return opts, fmt.Errorf("cannot specify both --from and --blank")
+	case from != "" && size != "":
+		return opts, fmt.Errorf("--size can only be used with --blank")
+	}
+
+	if size != "" {
+		if _, _, err := pdf.ParsePageDim(size); err != nil {
+			return opts, err
+		}
+		opts.size = size
+	}
+
+	if from != "" {
+		path, pageSpec := splitSourceSpec(from)
+		path, err := fileio.SanitizePath(path)
+		if err != nil {
+			return opts, fmt.Errorf("invalid source path: %w", err)
+		}
+		opts.source, opts.sourcePages = path, pageSpec
+	}
+	return opts, nil
+}
+
+// splitSourceSpec splits "file.pdf:1-2" into the file path and page spec.
+// A colon only starts a page spec when it follows a .pdf file name, so
+// paths containing colons are left untouched.
+func splitSourceSpec(spec string) (path, pageSpec string) {
+	idx := strings.LastIndex(spec, ":")
+	if idx < 0 || !strings.EqualFold(filepath.Ext(spec[:idx]), ".pdf") {
+		return spec, ""
+	}
+	return spec[:idx], spec[idx+1:]
+}
+
+// parseInsertPositions expands a position spec into sorted, unique page numbers.
+func parseInsertPositions(spec string, pageCount int) ([]int, error) {
+	list, err := pages.ParseReorderSequence(spec, pageCount)
+	if err != nil {
+		return nil, fmt.Errorf("%w for the position: %w", pdferrors.ErrInvalidPages, err)
+	}
+
+	seen := make(map[int]bool, len(list))
+	positions := make([]int, 0, len(list))
+	for _, p := range list {
+		if !seen[p] {
+			seen[p] = true
+			positions = append(positions, p)
+		}
+	}
+	sort.Ints(positions)
+	return positions, nil
+}
+
+// resolveSourcePages expands the source page spec against the source PDF.
+// Returns nil for blank pages, and all source pages when no spec was given.
+func resolveSourcePages(opts insertOptions, password string) ([]int, error) {
+	if opts.source == "" {
+		return nil, nil
+	}
+
+	count, err := pdf.PageCount(opts.source, password)
+	if err != nil {
+		return nil, pdferrors.WrapError("reading file", opts.source, err)
+	}
+
+	spec := opts.sourcePages
+	if spec == "" {
+		spec = "1-end"
+	}
+	list, err := pages.ParseReorderSequence(spec, count)
+	if err != nil {
+		return nil, fmt.Errorf("%w for %s: %w", pdferrors.ErrInvalidPages, opts.source, err)
+	}
+	return list, nil
+}
+
+// describeInsertion returns a short description of what is being inserted.
+func describeInsertion(opts insertOptions) string {
+	switch {
+	case opts.source == "" && opts.size != "":
+		return "blank " + opts.size + " page"
+	case opts.source == "":
+		return "blank page"
+	case opts.sourcePages != "":
+		return opts.source + " pages " + opts.sourcePages
+	default:
+		return opts.source
+	}
+}
+
+// positionWord returns "before" or "after" for messages.
+func positionWord(opts insertOptions) string {
+	if opts.before {
+		return "before"
+	}
+	return "after"
+}
+
+func insertDryRun(inputArg, explicitOutput string, opts insertOptions, password string) error {
+	if fileio.IsStdinInput(inputArg) {
+		cli.DryRunPrint("Would insert: %s into stdin", describeInsertion(opts))
+		cli.DryRunPrint("  Position: %s %s", positionWord(opts), opts.positions)
+		return nil
+	}
+
+	info, err := pdf.GetInfo(inputArg, password)
+	if err != nil {
+		cli.DryRunPrint("Would insert: %s into %s (unable to read info)", describeInsertion(opts), inputArg)
+		return nil
+	}
+
+	cli.DryRunPrint("Would insert: %s into %s (%d pages)", describeInsertion(opts), inputArg, info.Pages)
+
+	positions, err := parseInsertPositions(opts.positions, info.Pages)
+	if err != nil {
+		cli.DryRunPrint("  Position: %s %s (invalid: %v)", positionWord(opts), opts.positions, err)
+		return nil
+	}
+	cli.DryRunPrint("  Position: %s pages %v", positionWord(opts), positions)
+
+	inserted := len(positions)
+	if opts.source != "" {
+		sourcePages, err := resolveSourcePages(opts, password)
+		if err != nil {
+			cli.DryRunPrint("  Source: %s (invalid: %v)", opts.source, err)
+			return nil
+		}
+		cli.DryRunPrint("  Source pages: %v", sourcePages)
+		inserted *= len(sourcePages)
+	}
+
+	output := outputOrDefault(explicitOutput, inputArg, SuffixInserted)
+	cli.DryRunPrint("  Result: %d pages (%d inserted)", info.Pages+inserted, inserted)
+	cli.DryRunPrint("  Output: %s", output)
+	return nil
+}
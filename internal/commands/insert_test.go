@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+)
+
+func TestInsertCommand_Blank(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output := filepath.Join(tmpDir, "blank.pdf")
+	if err := executeCommand("insert", samplePDF(), "--blank", "--after", "1,end", "-o", output); err != nil {
+		t.Fatalf("insert --blank failed: %v", err)
+	}
+
+	count, err := pdf.PageCount(output, "")
+	if err != nil {
+		t.Fatalf("PageCount() error = %v", err)
+	}
+	if count != 5 {
+		t.Errorf("PageCount() = %d, want 5", count)
+	}
+}
+
+func TestInsertCommand_From(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output := filepath.Join(tmpDir, "inserted.pdf")
+	if err := executeCommand("insert", samplePDF(), "--at", "2", "--from", samplePDF()+":1-2", "-o", output); err != nil {
+		t.Fatalf("insert --from failed: %v", err)
+	}
+
+	count, err := pdf.PageCount(output, "")
+	if err != nil {
+		t.Fatalf("PageCount() error = %v", err)
+	}
+	if count != 5 {
+		t.Errorf("PageCount() = %d, want 5", count)
+	}
+}
+
+func TestInsertCommand_InvalidFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no position", []string{"--blank"}},
+		{"both positions", []string{"--blank", "--at", "1", "--after", "2"}},
+		{"no source", []string{"--at", "1"}},
+		{"both sources", []string{"--at", "1", "--blank", "--from", "x.pdf"}},
+		{"size with from", []string{"--at", "1", "--from", "x.pdf", "--size", "A4"}},
+		{"unknown size", []string{"--at", "1", "--blank", "--size", "huge"}},
+		{"position out of range", []string{"--at", "9", "--blank"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags(t)
+			tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tmpDir)
+
+			args := append([]string{"insert", samplePDF(), "-o", filepath.Join(tmpDir, "out.pdf")}, tt.args...)
+			if err := executeCommand(args...); err == nil {
+				t.Errorf("insert %v should fail", tt.args)
+			}
+		})
+	}
+}
+
+func TestInsertCommand_DryRun(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	if err := executeCommand("insert", samplePDF(), "--blank", "--at", "2", "--dry-run"); err != nil {
+		t.Fatalf("insert --dry-run failed: %v", err)
+	}
+}
+
+func TestSplitSourceSpec(t *testing.T) {
+	tests := []struct {
+		spec     string
+		wantPath string
+		wantSpec string
+	}{
+		{"cover.pdf", "cover.pdf", ""},
+		{"cover.pdf:1-2", "cover.pdf", "1-2"},
+		{"dir/Cover.PDF:end", "dir/Cover.PDF", "end"},
+		{"C:/docs/cover.pdf", "C:/docs/cover.pdf", ""},
+		{"odd:name.pdf", "odd:name.pdf", ""},
+	}
+
+	for _, tt := range tests {
+		path, spec := splitSourceSpec(tt.spec)
+		if path != tt.wantPath || spec != tt.wantSpec {
+			t.Errorf("splitSourceSpec(%q) = (%q, %q), want (%q, %q)", tt.spec, path, spec, tt.wantPath, tt.wantSpec)
+		}
+	}
+}
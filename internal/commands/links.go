@@ -0,0 +1,218 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/lgbarn/pdf-cli/internal/cli"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/output"
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cli.AddCommand(linksCmd)
+	linksCmd.AddCommand(linksRewriteCmd)
+
+	cli.AddPagesFlag(linksCmd, "Pages to list links from (default: all)")
+	cli.AddPasswordFlag(linksCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(linksCmd, "")
+	cli.AddAllowInsecurePasswordFlag(linksCmd)
+	cli.AddFormatFlag(linksCmd)
+	linksCmd.Flags().Bool("broken", false, "Only list internal links whose destination does not exist")
+
+	cli.AddOutputFlag(linksRewriteCmd, "Output file path (only with single file)")
+	cli.AddOutputDirFlag(linksRewriteCmd)
+	cli.AddBatchInputFlags(linksRewriteCmd)
+	cli.AddPasswordFlag(linksRewriteCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(linksRewriteCmd, "")
+	cli.AddAllowInsecurePasswordFlag(linksRewriteCmd)
+	linksRewriteCmd.Flags().StringArray("map", nil, "Replace a URI prefix as old=new (repeatable)")
+}
+
+var linksCmd = &cobra.Command{
+	Use:   "links <file.pdf>",
+	Short: "List and rewrite links",
+	Long: `List the links of a PDF with their page, type, target and rectangle.
+
+Link types are uri (web and mail links), goto (a destination in the
+document), remote (a destination in another PDF), launch (opens a file)
+and other. Internal links are resolved to their target page; links to a
+destination that does not exist are flagged as broken.
+
+Available subcommands:
+  rewrite - Replace URI prefixes, e.g. after a domain change
+
+Examples:
+  pdf links document.pdf
+  pdf links document.pdf -p 1-5 --format json
+  pdf links document.pdf --broken`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLinks,
+}
+
+var linksRewriteCmd = &cobra.Command{
+	Use:   "rewrite <file.pdf> [file2.pdf...]",
+	Short: "Replace URI prefixes in links",
+	Long: `Replace the beginning of URI links, for example when a domain changes.
+
+Each --map old=new replaces the prefix old with new. When several
+mappings match a link, the longest prefix wins. Only URI links are
+changed; use --dry-run to see the changes per page.
+
+Supports batch processing of multiple files. When processing
+multiple files, output files are named with '_relinked' suffix.
+
+Examples:
+  pdf links rewrite manual.pdf --map http://intranet.old.com=https://intranet.new.com -o manual_new.pdf
+  pdf links rewrite *.pdf --map http://=https:// --dry-run`,
+	Args: cli.BatchArgs,
+	RunE: runLinksRewrite,
+}
+
+func runLinks(cmd *cobra.Command, args []string) error {
+	args, err := sanitizeInputArgs(args)
+	if err != nil {
+		return err
+	}
+
+	pagesStr := cli.GetPages(cmd)
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	formatter := output.NewOutputFormatter(cli.GetFormat(cmd))
+	brokenOnly, _ := cmd.Flags().GetBool("broken")
+
+	inputFile := args[0]
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return err
+	}
+
+	pageNums, err := parseAndValidatePages(pagesStr, inputFile, password)
+	if err != nil {
+		return err
+	}
+
+	cli.PrintVerbose("Reading links from %s", inputFile)
+
+	links, err := pdf.ListLinks(inputFile, pageNums, password)
+	if err != nil {
+		return pdferrors.WrapError("reading links", inputFile, err)
+	}
+	if brokenOnly {
+		var broken []pdf.Link
+		for _, l := range links {
+			if l.Broken {
+				broken = append(broken, l)
+			}
+		}
+		links = broken
+	}
+
+	switch formatter.Format {
+	case output.FormatJSON, output.FormatYAML:
+		if links == nil {
+			links = []pdf.Link{}
+		}
+		return formatter.Print(links)
+	case output.FormatHuman:
+		if len(links) == 0 {
+			fmt.Println("No links found")
+			return nil
+		}
+	}
+
+	headers := []string{"page", "type", "target", "dest_page", "rect", "status"}
+	rows := make([][]string, 0, len(links))
+	for _, l := range links {
+		destPage, status := "", "ok"
+		if l.DestPage > 0 {
+			destPage = strconv.Itoa(l.DestPage)
+		}
+		if l.Broken {
+			status = "broken"
+		}
+		rows = append(rows, []string{strconv.Itoa(l.Page), l.Type, l.Target, destPage, l.Rect.String(), status})
+	}
+	return formatter.PrintTable(headers, rows)
+}
+
+func runLinksRewrite(cmd *cobra.Command, args []string) error {
+	args, err := batchInputs(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	output, err := sanitizeOutputPath(cli.GetOutput(cmd))
+	if err != nil {
+		return err
+	}
+
+	specs, _ := cmd.Flags().GetStringArray("map")
+	if len(specs) == 0 {
+		return fmt.Errorf("must specify at least one --map old=new")
+	}
+	mappings := make([]pdf.LinkMapping, len(specs))
+	for i, spec := range specs {
+		if mappings[i], err = pdf.ParseLinkMapping(spec); err != nil {
+			return err
+		}
+	}
+
+	// Handle dry-run mode
+	if cli.IsDryRun() {
+		return linksRewriteDryRun(args, output, password, mappings)
+	}
+
+	if err := validateBatchOutput(args, output, SuffixRelinked); err != nil {
+		return err
+	}
+
+	return processBatch(args, output, SuffixRelinked, func(w io.Writer, inputFile, out string) error {
+		if err := fileio.ValidatePDFFile(inputFile); err != nil {
+			return err
+		}
+
+		if err := checkOutputFile(out); err != nil {
+			return err
+		}
+
+		cli.PrintVerbose("Rewriting links of %s", inputFile)
+
+		rewrites, err := pdf.RewriteLinks(inputFile, out, mappings, password)
+		if err != nil {
+			return pdferrors.WrapError("rewriting links", inputFile, err)
+		}
+		for _, r := range rewrites {
+			cli.PrintVerbose("  Page %d: %s -> %s", r.Page, r.Old, r.New)
+		}
+
+		fmt.Fprintf(w, "Rewrote %d links in %s\n", len(rewrites), out)
+		return nil
+	})
+}
+
+func linksRewriteDryRun(args []string, explicitOutput, password string, mappings []pdf.LinkMapping) error {
+	for _, inputFile := range args {
+		rewrites, err := pdf.FindLinkRewrites(inputFile, mappings, password)
+		if err != nil {
+			cli.DryRunPrint("Would rewrite links: %s (unable to read links)", inputFile)
+			continue
+		}
+
+		cli.DryRunPrint("Would rewrite %d links: %s", len(rewrites), inputFile)
+		for _, r := range rewrites {
+			cli.DryRunPrint("  Page %d: %s -> %s", r.Page, r.Old, r.New)
+		}
+		cli.DryRunPrint("  Output: %s", outputOrDefault(explicitOutput, inputFile, SuffixRelinked))
+	}
+	return nil
+}
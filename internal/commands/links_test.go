@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+)
+
+func linksPDF() string {
+	return filepath.Join(testdataDir(), "links.pdf")
+}
+
+func TestLinksCommand(t *testing.T) {
+	resetFlags(t)
+	if err := executeCommand("links", linksPDF(), "--broken", "--format", "json"); err != nil {
+		t.Fatalf("links failed: %v", err)
+	}
+}
+
+func TestLinksRewriteCommand(t *testing.T) {
+	resetFlags(t)
+	out := filepath.Join(t.TempDir(), "relinked.pdf")
+	if err := executeCommand("links", "rewrite", linksPDF(), "--map", "http://intranet.example.com=https://intranet.example.org", "-o", out); err != nil {
+		t.Fatalf("links rewrite failed: %v", err)
+	}
+	links, err := pdf.ListLinks(out, nil, "")
+	if err != nil {
+		t.Fatalf("ListLinks() error = %v", err)
+	}
+	for _, l := range links {
+		if strings.Contains(l.Target, "intranet.example.com") {
+			t.Errorf("link not rewritten: %+v", l)
+		}
+	}
+
+	resetFlags(t)
+	if err := executeCommand("links", "rewrite", linksPDF()); err == nil || !strings.Contains(err.Error(), "--map") {
+		t.Errorf("expected missing --map error, got %v", err)
+	}
+}
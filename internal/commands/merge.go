@@ -16,6 +16,7 @@ func init() {
 	cli.AddPasswordFlag(mergeCmd, "Password for encrypted input PDFs")
 	cli.AddPasswordFileFlag(mergeCmd, "")
 	cli.AddAllowInsecurePasswordFlag(mergeCmd)
+	cli.AddBatchInputFlags(mergeCmd)
 	_ = mergeCmd.MarkFlagRequired("output")
 }
 
@@ -24,22 +25,28 @@ var mergeCmd = &cobra.Command{
 	Short: "Merge multiple PDFs into one",
 	Long: `Merge multiple PDF files into a single PDF.
 
-Files are merged in the order they are specified.
+Files are merged in the order they are specified. Directories (with -r)
+and glob patterns are expanded in place, with their files in lexical
+order, and the files of a --files-from list follow the arguments.
 The output file must be specified with the -o flag.
 
 Examples:
   pdf merge -o combined.pdf file1.pdf file2.pdf
   pdf merge -o output.pdf *.pdf
-  pdf merge -o combined.pdf doc1.pdf doc2.pdf doc3.pdf`,
-	Args: cobra.MinimumNArgs(2),
+  pdf merge -o combined.pdf doc1.pdf doc2.pdf doc3.pdf
+  pdf merge -o book.pdf -r chapters`,
+	Args: cli.BatchArgs,
 	RunE: runMerge,
 }
 
 func runMerge(cmd *cobra.Command, args []string) error {
-	args, err := sanitizeInputArgs(args)
+	args, err := batchInputs(cmd, args)
 	if err != nil {
 		return err
 	}
+	if len(args) < 2 {
+		return fmt.Errorf("merge needs at least 2 input files, got %d", len(args))
+	}
 
 	output := cli.GetOutput(cmd)
 	output, err = sanitizeOutputPath(output)
@@ -2,7 +2,10 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/lgbarn/pdf-cli/internal/cli"
 	"github.com/lgbarn/pdf-cli/internal/fileio"
@@ -15,6 +18,8 @@ import (
 func init() {
 	cli.AddCommand(metaCmd)
 	cli.AddOutputFlag(metaCmd, "Output file path (for setting metadata)")
+	cli.AddOutputDirFlag(metaCmd)
+	cli.AddBatchInputFlags(metaCmd)
 	cli.AddPasswordFlag(metaCmd, "Password for encrypted PDFs")
 	cli.AddPasswordFileFlag(metaCmd, "")
 	cli.AddAllowInsecurePasswordFlag(metaCmd)
@@ -24,6 +29,11 @@ func init() {
 	metaCmd.Flags().String("subject", "", "Set document subject")
 	metaCmd.Flags().String("keywords", "", "Set document keywords")
 	metaCmd.Flags().String("creator", "", "Set document creator")
+	metaCmd.Flags().String("created", "", "Set creation date (YYYY-MM-DD, \"YYYY-MM-DD HH:MM:SS\", RFC 3339 or now)")
+	metaCmd.Flags().String("modified", "", "Set modification date (default: now when changing metadata)")
+	metaCmd.Flags().StringArray("set", nil, "Set a field as Key=Value; custom keys are allowed (repeatable)")
+	metaCmd.Flags().StringArray("clear", nil, "Remove a field, e.g. author or a custom key (repeatable)")
+	metaCmd.Flags().Bool("xmp", false, "Show the XMP metadata, or rewrite it to match when changing metadata")
 }
 
 var metaCmd = &cobra.Command{
@@ -31,23 +41,41 @@ var metaCmd = &cobra.Command{
 	Short: "View or modify PDF metadata",
 	Long: `View or modify PDF document metadata.
 
-Without options, displays current metadata.
-With options, sets the specified metadata fields.
+Without options, displays current metadata, including creation and
+modification dates and custom fields. Use --xmp to also show the XMP
+metadata packet.
 
-When viewing multiple files, displays metadata for each file.
-Setting metadata only works with a single file.
+With options, changes the metadata:
+  --title, --author, --subject, --keywords, --creator
+                      set a standard field
+  --created, --modified
+                      set a date (YYYY-MM-DD, "YYYY-MM-DD HH:MM:SS",
+                      RFC 3339 or now)
+  --set Key=Value     set any field, including custom keys
+  --clear key         remove a field
+  --xmp               rewrite the XMP metadata to match the fields
+
+The modification date is set to now unless given or cleared; the
+creation date and producer are kept.
+
+Metadata can be changed on several files at once. When processing
+multiple files, output files are named with '_updated' suffix.
 
 Examples:
   pdf meta document.pdf
+  pdf meta document.pdf --xmp
   pdf meta document.pdf --title "My Document" -o updated.pdf
   pdf meta document.pdf --author "John Doe" --subject "Report" -o updated.pdf
+  pdf meta document.pdf --clear author --set Department=Legal -o updated.pdf
+  pdf meta document.pdf --created 2024-03-01 --xmp -o updated.pdf
+  pdf meta *.pdf --set Client=ACME                              # Update all
   pdf meta *.pdf                                                # View all`,
-	Args: cobra.MinimumNArgs(1),
+	Args: cli.BatchArgs,
 	RunE: runMeta,
 }
 
 func runMeta(cmd *cobra.Command, args []string) error {
-	args, err := sanitizeInputArgs(args)
+	args, err := batchInputs(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -65,40 +93,119 @@ func runMeta(cmd *cobra.Command, args []string) error {
 	format := cli.GetFormat(cmd)
 	formatter := output.NewOutputFormatter(format)
 
-	title, _ := cmd.Flags().GetString("title")
-	author, _ := cmd.Flags().GetString("author")
-	subject, _ := cmd.Flags().GetString("subject")
-	keywords, _ := cmd.Flags().GetString("keywords")
-	creator, _ := cmd.Flags().GetString("creator")
-
-	isSettingMeta := title != "" || author != "" || subject != "" || keywords != "" || creator != ""
+	update, err := metadataUpdate(cmd)
+	if err != nil {
+		return err
+	}
+	showXMP, _ := cmd.Flags().GetBool("xmp")
 
-	if isSettingMeta {
-		if len(args) > 1 {
-			return fmt.Errorf("cannot set metadata on multiple files; use a single file")
+	if len(update.Set) > 0 || len(update.Clear) > 0 {
+		update.SyncXMP = showXMP
+		if cli.IsDryRun() {
+			return metaDryRun(args, outputFile, update)
+		}
+		if err := validateBatchOutput(args, outputFile, SuffixUpdated); err != nil {
+			return err
 		}
-		return setMetadata(args[0], outputFile, password, title, author, subject, keywords, creator)
+		return processBatch(args, outputFile, SuffixUpdated, func(w io.Writer, inputFile, out string) error {
+			return setMetadata(w, inputFile, out, password, update)
+		})
 	}
 
 	if len(args) == 1 {
-		return viewMetadata(args[0], password, formatter)
+		return viewMetadata(args[0], password, formatter, showXMP)
+	}
+
+	return viewBatchMetadata(args, password, formatter, showXMP)
+}
+
+// metadataUpdate collects the changes requested by the field, --set and --clear flags.
+func metadataUpdate(cmd *cobra.Command) (pdf.MetadataUpdate, error) {
+	update := pdf.MetadataUpdate{Set: map[string]string{}}
+	flags := cmd.Flags()
+
+	for _, field := range []struct{ flag, key string }{
+		{"title", "Title"},
+		{"author", "Author"},
+		{"subject", "Subject"},
+		{"keywords", "Keywords"},
+		{"creator", "Creator"},
+		{"created", "CreationDate"},
+		{"modified", "ModDate"},
+	} {
+		if value, _ := flags.GetString(field.flag); value != "" {
+			update.Set[field.key] = value
+		}
+	}
+
+	sets, _ := flags.GetStringArray("set")
+	for _, kv := range sets {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			return update, fmt.Errorf("invalid --set %q: use Key=Value", kv)
+		}
+		key, err := pdf.InfoKey(strings.TrimSpace(name))
+		if err != nil {
+			return update, err
+		}
+		update.Set[key] = value
+	}
+
+	clears, _ := flags.GetStringArray("clear")
+	for _, name := range clears {
+		key, err := pdf.InfoKey(strings.TrimSpace(name))
+		if err != nil {
+			return update, err
+		}
+		if _, set := update.Set[key]; set {
+			return update, fmt.Errorf("cannot both set and clear %s", key)
+		}
+		update.Clear = append(update.Clear, key)
 	}
 
-	return viewBatchMetadata(args, password, formatter)
+	// Validate values up front so that batches fail before writing anything.
+	for _, key := range []string{"CreationDate", "ModDate"} {
+		if value, set := update.Set[key]; set {
+			if _, err := pdf.ParseDate(value); err != nil {
+				return update, err
+			}
+		}
+	}
+	return update, nil
 }
 
 // MetadataOutput represents PDF metadata for structured output.
 type MetadataOutput struct {
-	File     string `json:"file"`
-	Title    string `json:"title,omitempty"`
-	Author   string `json:"author,omitempty"`
-	Subject  string `json:"subject,omitempty"`
-	Keywords string `json:"keywords,omitempty"`
-	Creator  string `json:"creator,omitempty"`
-	Producer string `json:"producer,omitempty"`
+	File     string            `json:"file"`
+	Title    string            `json:"title,omitempty"`
+	Author   string            `json:"author,omitempty"`
+	Subject  string            `json:"subject,omitempty"`
+	Keywords string            `json:"keywords,omitempty"`
+	Creator  string            `json:"creator,omitempty"`
+	Producer string            `json:"producer,omitempty"`
+	Created  string            `json:"created,omitempty"`
+	Modified string            `json:"modified,omitempty"`
+	Custom   map[string]string `json:"custom,omitempty"`
+	XMP      string            `json:"xmp,omitempty"`
 }
 
-func viewMetadata(inputFile, password string, formatter *output.OutputFormatter) error {
+// newMetadataOutput converts metadata for structured output.
+func newMetadataOutput(file string, meta *pdf.Metadata) MetadataOutput {
+	return MetadataOutput{
+		File:     file,
+		Title:    meta.Title,
+		Author:   meta.Author,
+		Subject:  meta.Subject,
+		Keywords: meta.Keywords,
+		Creator:  meta.Creator,
+		Producer: meta.Producer,
+		Created:  meta.CreatedDate,
+		Modified: meta.ModDate,
+		Custom:   meta.Custom,
+	}
+}
+
+func viewMetadata(inputFile, password string, formatter *output.OutputFormatter, showXMP bool) error {
 	if err := fileio.ValidatePDFFile(inputFile); err != nil {
 		return err
 	}
@@ -109,18 +216,17 @@ func viewMetadata(inputFile, password string, formatter *output.OutputFormatter)
 	if err != nil {
 		return pdferrors.WrapError("reading metadata", inputFile, err)
 	}
+	var xmp string
+	if showXMP {
+		if xmp, err = pdf.GetXMP(inputFile, password); err != nil {
+			return pdferrors.WrapError("reading XMP metadata", inputFile, err)
+		}
+	}
 
 	// Structured output (JSON/CSV/TSV)
 	if formatter.IsStructured() {
-		output := MetadataOutput{
-			File:     inputFile,
-			Title:    meta.Title,
-			Author:   meta.Author,
-			Subject:  meta.Subject,
-			Keywords: meta.Keywords,
-			Creator:  meta.Creator,
-			Producer: meta.Producer,
-		}
+		output := newMetadataOutput(inputFile, meta)
+		output.XMP = xmp
 		return formatter.Print(output)
 	}
 
@@ -129,135 +235,144 @@ func viewMetadata(inputFile, password string, formatter *output.OutputFormatter)
 
 	if !hasMetadata(meta) {
 		fmt.Println("No metadata found")
-		return nil
+	} else {
+		printMetadataFields(meta)
+	}
+	if showXMP {
+		printXMP(xmp)
 	}
-
-	printMetadataFields(meta)
 	return nil
 }
 
-func viewBatchMetadata(files []string, password string, formatter *output.OutputFormatter) error {
+func viewBatchMetadata(files []string, password string, formatter *output.OutputFormatter, showXMP bool) error {
+	readMetadata := func(file string) (*pdf.Metadata, error) {
+		if err := fileio.ValidatePDFFile(file); err != nil {
+			return nil, err
+		}
+		meta, err := pdf.GetMetadata(file, password)
+		if err != nil {
+			return nil, pdferrors.WrapError("reading metadata", file, err)
+		}
+		return meta, nil
+	}
+
 	// Structured output (JSON/CSV/TSV)
 	if formatter.IsStructured() {
 		var outputs []MetadataOutput
-		for _, file := range files {
-			if err := fileio.ValidatePDFFile(file); err != nil {
-				continue
-			}
-			meta, err := pdf.GetMetadata(file, password)
+		batchErr := processBatchInOrder(files, func(file string) error {
+			meta, err := readMetadata(file)
 			if err != nil {
-				continue
+				return err
 			}
-			outputs = append(outputs, MetadataOutput{
-				File:     file,
-				Title:    meta.Title,
-				Author:   meta.Author,
-				Subject:  meta.Subject,
-				Keywords: meta.Keywords,
-				Creator:  meta.Creator,
-				Producer: meta.Producer,
-			})
-		}
+			output := newMetadataOutput(file, meta)
+			if showXMP {
+				output.XMP, _ = pdf.GetXMP(file, password)
+			}
+			outputs = append(outputs, output)
+			return nil
+		})
 
 		if formatter.Format == output.FormatJSON {
-			return formatter.Print(outputs)
+			if err := formatter.Print(outputs); err != nil {
+				return err
+			}
+			return batchErr
 		}
 
 		// CSV/TSV: use table format
-		headers := []string{"file", "title", "author", "subject", "keywords", "creator", "producer"}
+		headers := []string{"file", "title", "author", "subject", "keywords", "creator", "producer", "created", "modified"}
 		var rows [][]string
 		for _, o := range outputs {
 			rows = append(rows, []string{
-				o.File, o.Title, o.Author, o.Subject, o.Keywords, o.Creator, o.Producer,
+				o.File, o.Title, o.Author, o.Subject, o.Keywords, o.Creator, o.Producer, o.Created, o.Modified,
 			})
 		}
-		return formatter.PrintTable(headers, rows)
+		if err := formatter.PrintTable(headers, rows); err != nil {
+			return err
+		}
+		return batchErr
 	}
 
 	// Human-readable output
-	for i, file := range files {
-		if i > 0 {
+	first := true
+	return processBatchInOrder(files, func(file string) error {
+		if !first {
 			fmt.Println()
 		}
+		first = false
 
 		fmt.Printf("=== %s ===\n", filepath.Base(file))
 
-		if err := fileio.ValidatePDFFile(file); err != nil {
-			fmt.Printf("Error: %v\n", err)
-			continue
-		}
-
-		meta, err := pdf.GetMetadata(file, password)
+		meta, err := readMetadata(file)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			continue
+			return err
 		}
 
 		if !hasMetadata(meta) {
 			fmt.Println("No metadata found")
-			continue
+		} else {
+			printMetadataFields(meta)
 		}
-
-		printMetadataFields(meta)
-	}
-
-	return nil
+		if showXMP {
+			xmp, err := pdf.GetXMP(file, password)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return pdferrors.WrapError("reading XMP", file, err)
+			}
+			printXMP(xmp)
+		}
+		return nil
+	})
 }
 
-func setMetadata(inputFile, outputFile, password, title, author, subject, keywords, creator string) error {
-	if err := fileio.ValidatePDFFile(inputFile); err != nil {
-		return err
+func metaDryRun(args []string, explicitOutput string, update pdf.MetadataUpdate) error {
+	keys := make([]string, 0, len(update.Set))
+	for key := range update.Set {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
-	outputFile = outputOrDefault(outputFile, inputFile, "_updated")
-
-	// Handle dry-run mode
-	if cli.IsDryRun() {
+	for _, inputFile := range args {
 		cli.DryRunPrint("Would set metadata on: %s", inputFile)
-		if title != "" {
-			cli.DryRunPrint("  Title: \"%s\"", title)
+		for _, key := range keys {
+			cli.DryRunPrint("  %s: %q", key, update.Set[key])
 		}
-		if author != "" {
-			cli.DryRunPrint("  Author: \"%s\"", author)
+		for _, key := range update.Clear {
+			cli.DryRunPrint("  Clear: %s", key)
 		}
-		if subject != "" {
-			cli.DryRunPrint("  Subject: \"%s\"", subject)
+		if update.SyncXMP {
+			cli.DryRunPrint("  Rewrite XMP metadata")
 		}
-		if keywords != "" {
-			cli.DryRunPrint("  Keywords: \"%s\"", keywords)
-		}
-		if creator != "" {
-			cli.DryRunPrint("  Creator: \"%s\"", creator)
-		}
-		cli.DryRunPrint("  Output: %s", outputFile)
-		return nil
+		cli.DryRunPrint("  Output: %s", outputOrDefault(explicitOutput, inputFile, SuffixUpdated))
 	}
+	return nil
+}
 
-	if err := checkOutputFile(outputFile); err != nil {
+func setMetadata(w io.Writer, inputFile, outputFile, password string, update pdf.MetadataUpdate) error {
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
 		return err
 	}
 
-	meta := &pdf.Metadata{
-		Title:    title,
-		Author:   author,
-		Subject:  subject,
-		Keywords: keywords,
-		Creator:  creator,
+	outputFile = outputOrDefault(outputFile, inputFile, SuffixUpdated)
+	if err := checkOutputFile(outputFile); err != nil {
+		return err
 	}
 
 	cli.PrintVerbose("Setting metadata on %s", inputFile)
 
-	if err := pdf.SetMetadata(inputFile, outputFile, meta, password); err != nil {
+	if err := pdf.UpdateMetadata(inputFile, outputFile, update, password); err != nil {
 		return pdferrors.WrapError("setting metadata", inputFile, err)
 	}
 
-	fmt.Printf("Metadata updated in %s\n", outputFile)
+	fmt.Fprintf(w, "Metadata updated in %s\n", outputFile)
 	return nil
 }
 
 func hasMetadata(meta *pdf.Metadata) bool {
 	return meta.Title != "" || meta.Author != "" || meta.Subject != "" ||
-		meta.Keywords != "" || meta.Creator != "" || meta.Producer != ""
+		meta.Keywords != "" || meta.Creator != "" || meta.Producer != "" ||
+		meta.CreatedDate != "" || meta.ModDate != "" || len(meta.Custom) > 0
 }
 
 func printMetadataFields(meta *pdf.Metadata) {
@@ -267,4 +382,23 @@ func printMetadataFields(meta *pdf.Metadata) {
 	printIfSet("Keywords", meta.Keywords)
 	printIfSet("Creator", meta.Creator)
 	printIfSet("Producer", meta.Producer)
+	printIfSet("Created", meta.CreatedDate)
+	printIfSet("Modified", meta.ModDate)
+
+	keys := make([]string, 0, len(meta.Custom))
+	for key := range meta.Custom {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		printIfSet(key, meta.Custom[key])
+	}
+}
+
+func printXMP(xmp string) {
+	if xmp == "" {
+		fmt.Println("\nNo XMP metadata found")
+		return
+	}
+	fmt.Printf("\nXMP:\n%s\n", strings.TrimSpace(xmp))
 }
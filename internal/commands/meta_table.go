@@ -0,0 +1,489 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lgbarn/pdf-cli/internal/cli"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/output"
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	metaCmd.AddCommand(metaExportCmd)
+	metaCmd.AddCommand(metaImportCmd)
+
+	cli.AddPasswordFlag(metaExportCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(metaExportCmd, "")
+	cli.AddAllowInsecurePasswordFlag(metaExportCmd)
+	cli.AddFormatFlag(metaExportCmd)
+	cli.AddBatchInputFlags(metaExportCmd)
+
+	cli.AddPasswordFlag(metaImportCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(metaImportCmd, "")
+	cli.AddAllowInsecurePasswordFlag(metaImportCmd)
+	metaImportCmd.Flags().String("format", "", "Table format: csv, tsv, json, yaml (default: from file extension)")
+	metaImportCmd.Flags().Bool("xmp", false, "Rewrite the XMP metadata to match the imported fields")
+}
+
+var metaExportCmd = &cobra.Command{
+	Use:   "export <file.pdf> [file2.pdf...]",
+	Short: "Export metadata of PDFs as a table",
+	Long: `Export the metadata of one or more PDFs as a table with one row per file.
+
+The columns are file, title, author, subject, keywords, creator, producer,
+created and modified, followed by one column per custom field. The table
+is written to standard output as CSV unless --format selects tsv, json or
+yaml. Edit it and apply it again with 'pdf meta import'.
+
+Examples:
+  pdf meta export *.pdf > meta.csv
+  pdf meta export *.pdf --format yaml > meta.yaml`,
+	Args: cli.BatchArgs,
+	RunE: runMetaExport,
+}
+
+var metaImportCmd = &cobra.Command{
+	Use:   "import <table>",
+	Short: "Apply metadata from a CSV, TSV, JSON or YAML table",
+	Long: `Apply metadata from a table with one row per file.
+
+The table has the same columns as 'pdf meta export'. The file column is
+required; an optional output column names the file to write, which
+otherwise gets the '_updated' suffix. Columns that are missing leave the
+field unchanged, empty cells clear it. Files whose metadata already
+matches their row are skipped.
+
+The format is taken from the file extension (.csv, .tsv, .json, .yaml or
+.yml) unless --format is given. JSON and YAML tables may also hold custom
+fields in a nested "custom" object, as printed by 'pdf meta --format json',
+and may be a single record. The xmp field printed with --xmp is ignored;
+use --xmp to rewrite the XMP metadata from the imported fields.
+
+The producer and the dates of an encrypted PDF are written by pdfcpu, so
+their columns are ignored for encrypted files with a message.
+
+Use --dry-run to show the changes for each file without writing anything.
+
+Examples:
+  pdf meta import meta.csv
+  pdf meta import meta.yaml --dry-run
+  pdf meta import meta.json --xmp`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMetaImport,
+}
+
+// metaColumns are the fixed leading columns of an exported metadata table.
+var metaColumns = []string{"file", "title", "author", "subject", "keywords", "creator", "producer", "created", "modified"}
+
+// metaExportOnlyColumns are printed by 'pdf meta' but not imported.
+var metaExportOnlyColumns = []string{"xmp"}
+
+// metaEncryptedKeys are the document information keys that pdfcpu
+// overwrites when it writes an encrypted PDF.
+var metaEncryptedKeys = []string{"Producer", "CreationDate", "ModDate"}
+
+func runMetaExport(cmd *cobra.Command, args []string) error {
+	args, err := batchInputs(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+
+	formatter := output.NewOutputFormatter(cli.GetFormat(cmd))
+	if !formatter.IsStructured() {
+		formatter.Format = output.FormatCSV
+	}
+
+	outputs := make([]MetadataOutput, 0, len(args))
+	for _, file := range args {
+		if err := fileio.ValidatePDFFile(file); err != nil {
+			return err
+		}
+		cli.PrintVerbose("Reading metadata from %s", file)
+		meta, err := pdf.GetMetadata(file, password)
+		if err != nil {
+			return pdferrors.WrapError("reading metadata", file, err)
+		}
+		outputs = append(outputs, newMetadataOutput(file, meta))
+	}
+
+	headers, rows := metadataTable(outputs)
+	return formatter.PrintTable(headers, rows)
+}
+
+// metadataTable returns the columns and rows of an exported metadata table.
+func metadataTable(outputs []MetadataOutput) ([]string, [][]string) {
+	seen := map[string]bool{}
+	var custom []string
+	for _, o := range outputs {
+		for key := range o.Custom {
+			if !seen[key] {
+				seen[key] = true
+				custom = append(custom, key)
+			}
+		}
+	}
+	sort.Strings(custom)
+
+	headers := append(append([]string{}, metaColumns...), custom...)
+	rows := make([][]string, 0, len(outputs))
+	for _, o := range outputs {
+		row := []string{o.File, o.Title, o.Author, o.Subject, o.Keywords, o.Creator, o.Producer, o.Created, o.Modified}
+		for _, key := range custom {
+			row = append(row, o.Custom[key])
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows
+}
+
+// metaChange is a single field change of a metadata import.
+type metaChange struct {
+	key      string
+	old, new string
+}
+
+// metaImport is the metadata update for one row of an imported table.
+type metaImport struct {
+	file    string
+	output  string
+	changes []metaChange
+	update  pdf.MetadataUpdate
+}
+
+func runMetaImport(cmd *cobra.Command, args []string) error {
+	args, err := sanitizeInputArgs(args)
+	if err != nil {
+		return err
+	}
+
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	syncXMP, _ := cmd.Flags().GetBool("xmp")
+
+	rows, err := readMetadataTable(args[0], cli.GetFormat(cmd))
+	if err != nil {
+		return err
+	}
+
+	// Plan every row before writing anything so that a bad row stops the import.
+	imports := make(map[string]*metaImport, len(rows))
+	var files []string
+	for i, row := range rows {
+		imp, err := planMetaImport(row, password)
+		if err != nil {
+			return fmt.Errorf("%s: row %d: %w", args[0], i+1, err)
+		}
+		if imports[imp.file] != nil {
+			return fmt.Errorf("%s: row %d: duplicate file %s", args[0], i+1, imp.file)
+		}
+		imp.update.SyncXMP = syncXMP
+		imports[imp.file] = imp
+		files = append(files, imp.file)
+	}
+
+	if cli.IsDryRun() {
+		for _, file := range files {
+			printMetaImport(imports[file], "Would update", cli.DryRunPrint)
+		}
+		return nil
+	}
+
+	return processBatchInOrder(files, func(file string) error {
+		imp := imports[file]
+		if len(imp.changes) == 0 {
+			fmt.Printf("No metadata changes for %s\n", file)
+			return nil
+		}
+		printMetaImport(imp, "Updating", cli.PrintVerbose)
+		return setMetadata(os.Stdout, file, imp.output, password, imp.update)
+	})
+}
+
+// printMetaImport prints the changes planned for one file.
+func printMetaImport(imp *metaImport, verb string, printf func(format string, args ...interface{})) {
+	if len(imp.changes) == 0 {
+		printf("No metadata changes for %s", imp.file)
+		return
+	}
+	printf("%s metadata of: %s", verb, imp.file)
+	for _, c := range imp.changes {
+		switch {
+		case c.new == "":
+			printf("  - %s: %q", c.key, c.old)
+		case c.old == "":
+			printf("  + %s: %q", c.key, c.new)
+		default:
+			printf("  ~ %s: %q -> %q", c.key, c.old, c.new)
+		}
+	}
+	if imp.update.SyncXMP {
+		printf("  Rewrite XMP metadata")
+	}
+	printf("  Output: %s", outputOrDefault(imp.output, imp.file, SuffixUpdated))
+}
+
+// planMetaImport compares a table row with the current metadata of its file.
+func planMetaImport(row map[string]string, password string) (*metaImport, error) {
+	imp := &metaImport{
+		file:   strings.TrimSpace(row["file"]),
+		output: strings.TrimSpace(row["output"]),
+		update: pdf.MetadataUpdate{Set: map[string]string{}},
+	}
+	if imp.file == "" {
+		return nil, fmt.Errorf("missing file")
+	}
+	var err error
+	if imp.file, err = fileio.SanitizePath(imp.file); err != nil {
+		return nil, fmt.Errorf("invalid file path: %w", err)
+	}
+	if imp.output, err = sanitizeOutputPath(imp.output); err != nil {
+		return nil, err
+	}
+	if err := fileio.ValidatePDFFile(imp.file); err != nil {
+		return nil, err
+	}
+
+	meta, err := pdf.GetMetadata(imp.file, password)
+	if err != nil {
+		return nil, pdferrors.WrapError("reading metadata", imp.file, err)
+	}
+	current := map[string]string{
+		"Title":        meta.Title,
+		"Author":       meta.Author,
+		"Subject":      meta.Subject,
+		"Keywords":     meta.Keywords,
+		"Creator":      meta.Creator,
+		"Producer":     meta.Producer,
+		"CreationDate": meta.CreatedDate,
+		"ModDate":      meta.ModDate,
+	}
+	for key, value := range meta.Custom {
+		current[key] = value
+	}
+
+	// Standard fields come first, in table order, then custom fields by name.
+	var columns, custom []string
+	for _, column := range metaColumns[1:] {
+		if _, ok := row[column]; ok {
+			columns = append(columns, column)
+		}
+	}
+	for column := range row {
+		if column != "file" && column != "output" && !slices.Contains(metaColumns, column) && !slices.Contains(metaExportOnlyColumns, column) {
+			custom = append(custom, column)
+		}
+	}
+	sort.Strings(custom)
+
+	for _, column := range append(columns, custom...) {
+		key, err := metaColumnKey(column)
+		if err != nil {
+			return nil, err
+		}
+		value := row[column]
+		if isMetaDate(key) && value != "" {
+			if _, err := pdf.ParseDate(value); err != nil {
+				return nil, err
+			}
+		}
+		if sameMetaValue(key, current[key], value) {
+			continue
+		}
+		if meta.Encrypted && slices.Contains(metaEncryptedKeys, key) {
+			cli.PrintStatus("Ignoring %s of %s: it cannot be set in an encrypted PDF", column, imp.file)
+			continue
+		}
+		imp.changes = append(imp.changes, metaChange{key: key, old: current[key], new: value})
+		if value == "" {
+			imp.update.Clear = append(imp.update.Clear, key)
+		} else {
+			imp.update.Set[key] = value
+		}
+	}
+	return imp, nil
+}
+
+// metaColumnKey returns the document information key for a table column.
+func metaColumnKey(column string) (string, error) {
+	switch strings.ToLower(column) {
+	case "created":
+		return "CreationDate", nil
+	case "modified":
+		return "ModDate", nil
+	}
+	return pdf.InfoKey(column)
+}
+
+// isMetaDate reports whether the document information key holds a date.
+func isMetaDate(key string) bool {
+	return key == "CreationDate" || key == "ModDate"
+}
+
+// sameMetaValue reports whether value leaves the field key unchanged.
+// Dates are compared as points in time.
+func sameMetaValue(key, old, value string) bool {
+	if old == value {
+		return true
+	}
+	if !isMetaDate(key) || old == "" || value == "" {
+		return false
+	}
+	o, err := pdf.ParseDate(old)
+	if err != nil {
+		return false
+	}
+	v, err := pdf.ParseDate(value)
+	return err == nil && o.Equal(v)
+}
+
+// readMetadataTable reads the rows of a metadata table as column/value maps.
+// The format is taken from the file extension unless format is given.
+func readMetadataTable(path, format string) ([]map[string]string, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is sanitized
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata table: %w", err)
+	}
+
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	var rows []map[string]string
+	switch f := output.ParseOutputFormat(format); f {
+	case output.FormatCSV, output.FormatTSV:
+		rows, err = parseMetadataCSV(data, f == output.FormatTSV)
+	case output.FormatJSON:
+		var records []map[string]interface{}
+		if records, err = decodeMetadataRecords(data, json.Unmarshal); err == nil {
+			rows, err = flattenMetadataRecords(records)
+		}
+	case output.FormatYAML:
+		var records []map[string]interface{}
+		if records, err = decodeMetadataRecords(data, yaml.Unmarshal); err == nil {
+			rows, err = flattenMetadataRecords(records)
+		}
+	default:
+		return nil, fmt.Errorf("cannot tell the format of %s: use --format csv, tsv, json or yaml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metadata table %s: %w", path, err)
+	}
+	return rows, nil
+}
+
+// decodeMetadataRecords decodes a list of records, or a single record as
+// printed by 'pdf meta' for one file.
+func decodeMetadataRecords(data []byte, unmarshal func([]byte, interface{}) error) ([]map[string]interface{}, error) {
+	var records []map[string]interface{}
+	err := unmarshal(data, &records)
+	if err == nil {
+		return records, nil
+	}
+	var record map[string]interface{}
+	if unmarshal(data, &record) != nil {
+		return nil, err
+	}
+	return []map[string]interface{}{record}, nil
+}
+
+// parseMetadataCSV parses a CSV or TSV table whose first record holds the column names.
+func parseMetadataCSV(data []byte, tsv bool) ([]map[string]string, error) {
+	r := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(data), "\ufeff")))
+	if tsv {
+		r.Comma = '\t'
+		r.LazyQuotes = true
+	}
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := make([]string, len(records[0]))
+	for i, column := range records[0] {
+		header[i] = normalizeMetaColumn(column)
+	}
+
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			if column != "" && i < len(record) {
+				row[column] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// flattenMetadataRecords converts decoded JSON or YAML records to rows,
+// merging a nested "custom" object into the row.
+func flattenMetadataRecords(records []map[string]interface{}) ([]map[string]string, error) {
+	rows := make([]map[string]string, 0, len(records))
+	for i, record := range records {
+		row := map[string]string{}
+		for column, value := range record {
+			if custom, ok := value.(map[string]interface{}); ok && strings.EqualFold(column, "custom") {
+				for key, v := range custom {
+					row[key] = cellText(v)
+				}
+				continue
+			}
+			switch value.(type) {
+			case map[string]interface{}, []interface{}:
+				return nil, fmt.Errorf("record %d: field %q is not a single value", i+1, column)
+			}
+			row[normalizeMetaColumn(column)] = cellText(value)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// normalizeMetaColumn lowercases the fixed columns so that they match
+// regardless of case; custom columns keep their spelling.
+func normalizeMetaColumn(column string) string {
+	column = strings.TrimSpace(column)
+	lower := strings.ToLower(column)
+	if lower == "output" || slices.Contains(metaColumns, lower) || slices.Contains(metaExportOnlyColumns, lower) {
+		return lower
+	}
+	return column
+}
+
+// cellText formats a decoded JSON or YAML value as text.
+func cellText(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	switch v := v.(type) {
+	case string:
+		return v
+	case time.Time:
+		// YAML decodes unquoted timestamps.
+		return v.Format(time.RFC3339)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,285 @@
+package commands
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+)
+
+func TestMetaCommand_BatchSetAndClear(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	var inputs []string
+	for _, name := range []string{"a.pdf", "b.pdf"} {
+		data, err := os.ReadFile(samplePDF())
+		if err != nil {
+			t.Fatalf("Failed to read sample: %v", err)
+		}
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatalf("Failed to write input: %v", err)
+		}
+		inputs = append(inputs, path)
+	}
+
+	args := append([]string{"meta"}, inputs...)
+	args = append(args, "--author", "Jane", "--set", "Client=ACME", "--created", "2024-03-01")
+	if err := executeCommand(args...); err != nil {
+		t.Fatalf("meta batch set failed: %v", err)
+	}
+
+	updated := filepath.Join(tmpDir, "b_updated.pdf")
+	meta, err := pdf.GetMetadata(updated, "")
+	if err != nil {
+		t.Fatalf("GetMetadata() error = %v", err)
+	}
+	if meta.Author != "Jane" || meta.Custom["Client"] != "ACME" || meta.CreatedDate == "" {
+		t.Errorf("metadata = %+v", meta)
+	}
+
+	resetFlags(t)
+	cleared := filepath.Join(tmpDir, "cleared.pdf")
+	if err := executeCommand("meta", updated, "--clear", "author", "--clear", "Client", "-o", cleared); err != nil {
+		t.Fatalf("meta --clear failed: %v", err)
+	}
+	meta, err = pdf.GetMetadata(cleared, "")
+	if err != nil {
+		t.Fatalf("GetMetadata() error = %v", err)
+	}
+	if meta.Author != "" || len(meta.Custom) != 0 {
+		t.Errorf("fields not cleared: %+v", meta)
+	}
+}
+
+func TestMetaCommand_InvalidUpdates(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"bad date", []string{"--created", "someday"}},
+		{"set without value", []string{"--set", "Client"}},
+		{"bad key", []string{"--set", "a b=c"}},
+		{"set and clear", []string{"--title", "X", "--clear", "title"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags(t)
+			args := append([]string{"meta", samplePDF(), "-o", "unused.pdf"}, tt.args...)
+			if err := executeCommand(args...); err == nil {
+				t.Errorf("expected error for %v", tt.args)
+			}
+		})
+	}
+}
+
+func TestMetaCommand_ViewXMP(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	if err := executeCommand("meta", samplePDF(), "--xmp"); err != nil {
+		t.Fatalf("meta --xmp failed: %v", err)
+	}
+}
+
+func TestMetadataTable(t *testing.T) {
+	headers, rows := metadataTable([]MetadataOutput{
+		{File: "a.pdf", Title: "A", Custom: map[string]string{"Client": "ACME"}},
+		{File: "b.pdf", Author: "Bob", Custom: map[string]string{"Batch": "7"}},
+	})
+
+	wantHeaders := append(append([]string{}, metaColumns...), "Batch", "Client")
+	if strings.Join(headers, ",") != strings.Join(wantHeaders, ",") {
+		t.Errorf("headers = %v, want %v", headers, wantHeaders)
+	}
+	if len(rows) != 2 || rows[0][1] != "A" || rows[0][10] != "ACME" || rows[1][2] != "Bob" || rows[1][9] != "7" {
+		t.Errorf("rows = %q", rows)
+	}
+}
+
+func TestReadMetadataTable(t *testing.T) {
+	tmpDir := t.TempDir()
+	tests := []struct {
+		name    string
+		file    string
+		data    string
+		format  string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "csv",
+			file: "meta.csv",
+			data: "File,Title,Client\na.pdf,\"Report, 2024\",ACME\n",
+			want: map[string]string{"file": "a.pdf", "title": "Report, 2024", "Client": "ACME"},
+		},
+		{
+			name:   "tsv by flag",
+			file:   "meta.txt",
+			data:   "file\tauthor\na.pdf\tBob\n",
+			format: "tsv",
+			want:   map[string]string{"file": "a.pdf", "author": "Bob"},
+		},
+		{
+			name: "json with custom object",
+			file: "meta.json",
+			data: `[{"file": "a.pdf", "title": "A", "custom": {"Client": "ACME"}, "Batch": 7}]`,
+			want: map[string]string{"file": "a.pdf", "title": "A", "Client": "ACME", "Batch": "7"},
+		},
+		{
+			name: "yaml with timestamp",
+			file: "meta.yaml",
+			data: "- file: a.pdf\n  created: 2024-03-01T10:00:00Z\n  author: null\n",
+			want: map[string]string{"file": "a.pdf", "created": "2024-03-01T10:00:00Z", "author": ""},
+		},
+		{
+			name:    "nested value",
+			file:    "bad.json",
+			data:    `[{"file": "a.pdf", "title": ["A"]}]`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown extension",
+			file:    "meta.dat",
+			data:    "file\na.pdf\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, tt.file)
+			if err := os.WriteFile(path, []byte(tt.data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			rows, err := readMetadataTable(path, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readMetadataTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(rows) != 1 || !maps.Equal(rows[0], tt.want) {
+				t.Errorf("rows = %v, want [%v]", rows, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetaImportCommand(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir := t.TempDir()
+	data, err := os.ReadFile(samplePDF())
+	if err != nil {
+		t.Fatalf("Failed to read sample: %v", err)
+	}
+	input := filepath.Join(tmpDir, "a.pdf")
+	if err := os.WriteFile(input, data, 0o600); err != nil {
+		t.Fatalf("Failed to write input: %v", err)
+	}
+	output := filepath.Join(tmpDir, "out.pdf")
+	table := filepath.Join(tmpDir, "meta.csv")
+	csv := "file,output,title,created,Client\n" + input + "," + output + ",Imported,2024-03-01,ACME\n"
+	if err := os.WriteFile(table, []byte(csv), 0o600); err != nil {
+		t.Fatalf("Failed to write table: %v", err)
+	}
+
+	if err := executeCommand("meta", "import", table, "--dry-run"); err != nil {
+		t.Fatalf("meta import --dry-run failed: %v", err)
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Fatal("dry run wrote the output file")
+	}
+
+	resetFlags(t)
+	if err := executeCommand("meta", "import", table); err != nil {
+		t.Fatalf("meta import failed: %v", err)
+	}
+	meta, err := pdf.GetMetadata(output, "")
+	if err != nil {
+		t.Fatalf("GetMetadata() error = %v", err)
+	}
+	if meta.Title != "Imported" || meta.Custom["Client"] != "ACME" || !strings.HasPrefix(meta.CreatedDate, "2024-03-01") {
+		t.Errorf("metadata = %+v", meta)
+	}
+
+	bad := filepath.Join(tmpDir, "bad.csv")
+	if err := os.WriteFile(bad, []byte("file,created\n"+input+",someday\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write table: %v", err)
+	}
+	if err := executeCommand("meta", "import", bad); err == nil {
+		t.Error("expected error for invalid date")
+	}
+}
+
+func TestMetaImportRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "a.pdf")
+	update := pdf.MetadataUpdate{Set: map[string]string{"Title": "Report", "Producer": "Scanner 3000", "Client": "ACME"}, SyncXMP: true}
+	if err := pdf.UpdateMetadata(samplePDF(), input, update, ""); err != nil {
+		t.Fatalf("UpdateMetadata() error = %v", err)
+	}
+
+	// The JSON printed by 'pdf meta --format json --xmp' for one file
+	meta, err := pdf.GetMetadata(input, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported := newMetadataOutput(input, meta)
+	if exported.XMP, err = pdf.GetXMP(input, ""); err != nil || exported.XMP == "" {
+		t.Fatalf("GetXMP() = %q, %v", exported.XMP, err)
+	}
+	data, err := json.Marshal(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := filepath.Join(dir, "meta.json")
+	if err := os.WriteFile(table, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := readMetadataTable(table, "")
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("readMetadataTable() = %v, %v", rows, err)
+	}
+	imp, err := planMetaImport(rows[0], "")
+	if err != nil {
+		t.Fatalf("planMetaImport() error = %v", err)
+	}
+	if len(imp.changes) != 0 {
+		t.Errorf("importing the export changes %+v", imp.changes)
+	}
+
+	// The producer of an encrypted file cannot be set, so it is ignored
+	encrypted := filepath.Join(dir, "locked.pdf")
+	if err := pdf.Encrypt(input, encrypted, "tiger7", ""); err != nil {
+		t.Fatal(err)
+	}
+	imp, err = planMetaImport(map[string]string{"file": encrypted, "producer": "Other", "title": "New"}, "tiger7")
+	if err != nil {
+		t.Fatalf("planMetaImport() error = %v", err)
+	}
+	if len(imp.changes) != 1 || imp.changes[0].key != "Title" {
+		t.Errorf("changes of an encrypted file = %+v, want only the title", imp.changes)
+	}
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+)
+
+func TestRedactCommand(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	patternFile := filepath.Join(tmpDir, "pii.txt")
+	if err := os.WriteFile(patternFile, []byte("# page labels\n\nPage 3\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write pattern file: %v", err)
+	}
+
+	output := filepath.Join(tmpDir, "redacted.pdf")
+	if err := executeCommand("redact", samplePDF(), "--pattern", `Page [1]`, "--pattern-file", patternFile, "-o", output); err != nil {
+		t.Fatalf("redact failed: %v", err)
+	}
+
+	text, err := pdf.ExtractText(context.Background(), output, nil, "")
+	if err != nil {
+		t.Fatalf("ExtractText() error = %v", err)
+	}
+	if strings.Contains(text, "Page 1") || strings.Contains(text, "Page 3") {
+		t.Errorf("redacted text still present: %q", text)
+	}
+	if !strings.Contains(text, "Page 2") {
+		t.Errorf("unmatched text was removed: %q", text)
+	}
+}
+
+func TestRedactCommand_DryRun(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	if err := executeCommand("redact", samplePDF(), "--pattern", `Page \d`, "--dry-run"); err != nil {
+		t.Fatalf("redact --dry-run failed: %v", err)
+	}
+}
+
+func TestRedactCommand_InvalidPatterns(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no pattern", []string{}},
+		{"bad regexp", []string{"--pattern", "("}},
+		{"missing pattern file", []string{"--pattern-file", "does-not-exist.txt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags(t)
+			args := append([]string{"redact", samplePDF(), "-o", "unused.pdf"}, tt.args...)
+			if err := executeCommand(args...); err == nil {
+				t.Errorf("expected error for %v", tt.args)
+			}
+		})
+	}
+}
+
+func TestRedactFile_WarnsAboutForms(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	dir := t.TempDir()
+	stamped := filepath.Join(dir, "stamped.pdf")
+	if err := pdf.AddWatermark(samplePDF(), stamped, "SSN 123-45-6789", []int{2}, ""); err != nil {
+		t.Fatalf("AddWatermark() error = %v", err)
+	}
+
+	opts := pdf.RedactOptions{Patterns: []*regexp.Regexp{regexp.MustCompile(`\d{3}-\d{2}-\d{4}`)}}
+	var out jobOutput
+	if err := redactFile(&out, stamped, filepath.Join(dir, "out.pdf"), "", "", opts); err != nil {
+		t.Fatalf("redactFile() error = %v", err)
+	}
+	want := []string{"text in form XObjects or annotations on page 2 was not searched"}
+	if !slices.Equal(out.warnings, want) {
+		t.Errorf("warnings = %q, want %q", out.warnings, want)
+	}
+
+	out = jobOutput{}
+	if err := redactFile(&out, samplePDF(), filepath.Join(dir, "plain.pdf"), "", "", opts); err != nil {
+		t.Fatalf("redactFile() error = %v", err)
+	}
+	if len(out.warnings) != 0 {
+		t.Errorf("warnings for a file without forms = %q", out.warnings)
+	}
+}
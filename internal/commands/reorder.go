@@ -101,7 +101,7 @@ func runReorder(cmd *cobra.Command, args []string) error {
 
 	pageList, err := pages.ParseReorderSequence(sequence, pageCount)
 	if err != nil {
-		return fmt.Errorf("invalid sequence: %w", err)
+		return fmt.Errorf("%w in the sequence: %w", pdferrors.ErrInvalidPages, err)
 	}
 
 	if !toStdout {
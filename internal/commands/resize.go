@@ -0,0 +1,220 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/lgbarn/pdf-cli/internal/cli"
+	"github.com/lgbarn/pdf-cli/internal/commands/patterns"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cli.AddCommand(resizeCmd)
+	cli.AddOutputFlag(resizeCmd, "Output file path (only with single file)")
+	cli.AddOutputDirFlag(resizeCmd)
+	cli.AddBatchInputFlags(resizeCmd)
+	cli.AddPagesFlag(resizeCmd, "Pages to resize (default: all pages)")
+	cli.AddPasswordFlag(resizeCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(resizeCmd, "")
+	cli.AddAllowInsecurePasswordFlag(resizeCmd)
+	cli.AddStdoutFlag(resizeCmd)
+	resizeCmd.Flags().String("size", "", "Target page size: A4, Letter, A4L (landscape), or WxH in points")
+	resizeCmd.Flags().Float64("scale", 0, "Scale factor (e.g., 0.5 halves, 2 doubles the page size)")
+	resizeCmd.Flags().Bool("fit", false, "Keep each page's orientation when fitting content to --size")
+}
+
+var resizeCmd = &cobra.Command{
+	Use:   "resize <file.pdf> [file2.pdf...]",
+	Short: "Resize pages in PDF(s)",
+	Long: `Resize pages in PDF file(s) to a paper size or by a scale factor.
+
+Page content is scaled proportionally and centered on the new page.
+Use --size for a named paper size (A4, Letter, Legal, A3, ...) or
+explicit dimensions in points (612x792). Append L to a paper size
+for landscape (A4L). Use --scale to grow or shrink pages instead.
+
+By default, every page gets exactly the orientation of --size.
+With --fit, landscape pages become landscape pages of the target
+size, and portrait pages become portrait pages.
+
+Supports batch processing of multiple files. When processing
+multiple files, output files are named with '_resized' suffix.
+Use "-" to read from stdin. Use --stdout for binary output.
+
+Examples:
+  pdf resize document.pdf --size A4 --fit -o a4.pdf
+  pdf resize document.pdf --size Letter -p 1-5 -o letter.pdf
+  pdf resize document.pdf --scale 0.5 -o half.pdf
+  pdf resize *.pdf --size A4 --fit          # Batch resize`,
+	Args: cli.BatchArgs,
+	RunE: runResize,
+}
+
+func runResize(cmd *cobra.Command, args []string) error {
+	args, err := batchInputs(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	pagesStr := cli.GetPages(cmd)
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	output := cli.GetOutput(cmd)
+	toStdout := cli.GetStdout(cmd)
+
+	output, err = sanitizeOutputPath(output)
+	if err != nil {
+		return err
+	}
+
+	size, _ := cmd.Flags().GetString("size")
+	scale, _ := cmd.Flags().GetFloat64("scale")
+	fit, _ := cmd.Flags().GetBool("fit")
+
+	if size == "" && scale == 0 {
+		return fmt.Errorf("must specify either --size or --scale")
+	}
+	if size != "" && scale != 0 {
+		return fmt.Errorf("cannot specify both --size and --scale")
+	}
+	if scale < 0 || scale == 1 {
+		return fmt.Errorf("invalid scale factor: %g (must be positive and not 1)", scale)
+	}
+	if size != "" {
+		if _, _, err := pdf.ParsePageDim(size); err != nil {
+			return err
+		}
+	}
+
+	opts := pdf.ResizeOptions{Size: size, Scale: scale, Fit: fit}
+
+	// Handle dry-run mode
+	if cli.IsDryRun() {
+		return resizeDryRun(args, output, pagesStr, password, opts)
+	}
+
+	// Handle stdin/stdout for single file
+	if len(args) == 1 && (fileio.IsStdinInput(args[0]) || toStdout) {
+		return resizeWithStdio(args[0], output, pagesStr, password, opts, toStdout)
+	}
+
+	if err := validateBatchOutput(args, output, SuffixResized); err != nil {
+		return err
+	}
+
+	return processBatch(args, output, SuffixResized, func(w io.Writer, inputFile, out string) error {
+		return resizeFile(w, inputFile, out, pagesStr, password, opts)
+	})
+}
+
+// describeResize returns a short human-readable description of resize options.
+func describeResize(opts pdf.ResizeOptions) string {
+	if opts.Scale > 0 {
+		return fmt.Sprintf("scale %g", opts.Scale)
+	}
+	if opts.Fit {
+		return opts.Size + " (fit)"
+	}
+	return opts.Size
+}
+
+func resizeDryRun(args []string, explicitOutput, pagesStr, password string, opts pdf.ResizeOptions) error {
+	for _, inputFile := range args {
+		if fileio.IsStdinInput(inputFile) {
+			cli.DryRunPrint("Would resize: stdin to %s", describeResize(opts))
+			continue
+		}
+
+		info, err := pdf.GetInfo(inputFile, password)
+		if err != nil {
+			cli.DryRunPrint("Would resize: %s (unable to read info)", inputFile)
+			continue
+		}
+
+		output := outputOrDefault(explicitOutput, inputFile, SuffixResized)
+		pageDesc := describePages(pagesStr)
+
+		cli.DryRunPrint("Would resize: %s (%d pages)", inputFile, info.Pages)
+		cli.DryRunPrint("  Target: %s", describeResize(opts))
+		cli.DryRunPrint("  Pages: %s", pageDesc)
+		cli.DryRunPrint("  Output: %s", output)
+	}
+	return nil
+}
+
+func resizeWithStdio(inputArg, explicitOutput, pagesStr, password string, opts pdf.ResizeOptions, toStdout bool) error {
+	handler := &patterns.StdioHandler{
+		InputArg:       inputArg,
+		ExplicitOutput: explicitOutput,
+		ToStdout:       toStdout,
+		DefaultSuffix:  SuffixResized,
+		Operation:      "resize",
+	}
+	defer handler.Cleanup()
+
+	input, output, err := handler.Setup()
+	if err != nil {
+		return err
+	}
+
+	pages, err := parseAndValidatePages(pagesStr, input, password)
+	if err != nil {
+		return err
+	}
+
+	if !toStdout {
+		if err := checkOutputFile(output); err != nil {
+			return err
+		}
+	}
+
+	if err := pdf.Resize(input, output, opts, pages, password); err != nil {
+		return pdferrors.WrapError("resizing pages", inputArg, err)
+	}
+
+	if err := handler.Finalize(); err != nil {
+		return err
+	}
+
+	if !toStdout {
+		fmt.Fprintf(os.Stderr, "Resized to %s (%s)\n", output, describeResize(opts))
+	}
+	return nil
+}
+
+func resizeFile(w io.Writer, inputFile, explicitOutput, pagesStr, password string, opts pdf.ResizeOptions) error {
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return err
+	}
+
+	pages, err := parseAndValidatePages(pagesStr, inputFile, password)
+	if err != nil {
+		return err
+	}
+
+	output := outputOrDefault(explicitOutput, inputFile, SuffixResized)
+
+	if err := checkOutputFile(output); err != nil {
+		return err
+	}
+
+	pageDesc := "all pages"
+	if len(pages) > 0 {
+		pageDesc = fmt.Sprintf("%d pages", len(pages))
+	}
+	cli.PrintVerbose("Resizing %s of %s to %s", pageDesc, inputFile, describeResize(opts))
+
+	if err := pdf.Resize(inputFile, output, opts, pages, password); err != nil {
+		return pdferrors.WrapError("resizing pages", inputFile, err)
+	}
+
+	fmt.Fprintf(w, "Resized %s to %s (%s)\n", pageDesc, output, describeResize(opts))
+	return nil
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/lgbarn/pdf-cli/internal/cli"
@@ -15,6 +16,8 @@ import (
 func init() {
 	cli.AddCommand(rotateCmd)
 	cli.AddOutputFlag(rotateCmd, "Output file path (only with single file)")
+	cli.AddOutputDirFlag(rotateCmd)
+	cli.AddBatchInputFlags(rotateCmd)
 	cli.AddPagesFlag(rotateCmd, "Pages to rotate (default: all pages)")
 	cli.AddPasswordFlag(rotateCmd, "Password for encrypted PDFs")
 	cli.AddPasswordFileFlag(rotateCmd, "")
@@ -39,12 +42,12 @@ Examples:
   pdf rotate document.pdf -a 90 -o rotated.pdf
   pdf rotate document.pdf -a 180 -p 1-5 -o rotated.pdf
   cat input.pdf | pdf rotate - -a 90 --stdout > rotated.pdf`,
-	Args: cobra.MinimumNArgs(1),
+	Args: cli.BatchArgs,
 	RunE: runRotate,
 }
 
 func runRotate(cmd *cobra.Command, args []string) error {
-	args, err := sanitizeInputArgs(args)
+	args, err := batchInputs(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -82,8 +85,8 @@ func runRotate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	return processBatch(args, func(inputFile string) error {
-		return rotateFile(inputFile, output, pagesStr, password, angle)
+	return processBatch(args, output, SuffixRotated, func(w io.Writer, inputFile, out string) error {
+		return rotateFile(w, inputFile, out, pagesStr, password, angle)
 	})
 }
 
@@ -101,10 +104,7 @@ func rotateDryRun(args []string, explicitOutput, pagesStr, password string, angl
 		}
 
 		output := outputOrDefault(explicitOutput, inputFile, SuffixRotated)
-		pageDesc := "all pages"
-		if pagesStr != "" {
-			pageDesc = "pages " + pagesStr
-		}
+		pageDesc := describePages(pagesStr)
 
 		cli.DryRunPrint("Would rotate: %s (%d pages)", inputFile, info.Pages)
 		cli.DryRunPrint("  Angle: %d degrees", angle)
@@ -154,7 +154,7 @@ func rotateWithStdio(inputArg, explicitOutput, pagesStr, password string, angle
 	return nil
 }
 
-func rotateFile(inputFile, explicitOutput, pagesStr, password string, angle int) error {
+func rotateFile(w io.Writer, inputFile, explicitOutput, pagesStr, password string, angle int) error {
 	if err := fileio.ValidatePDFFile(inputFile); err != nil {
 		return err
 	}
@@ -180,6 +180,6 @@ func rotateFile(inputFile, explicitOutput, pagesStr, password string, angle int)
 		return pdferrors.WrapError("rotating pages", inputFile, err)
 	}
 
-	fmt.Printf("Rotated %s by %d degrees to %s\n", pageDesc, angle, output)
+	fmt.Fprintf(w, "Rotated %s by %d degrees to %s\n", pageDesc, angle, output)
 	return nil
 }
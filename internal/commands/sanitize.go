@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/lgbarn/pdf-cli/internal/cli"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cli.AddCommand(sanitizeCmd)
+	cli.AddOutputFlag(sanitizeCmd, "Output file path (only with single file)")
+	cli.AddOutputDirFlag(sanitizeCmd)
+	cli.AddBatchInputFlags(sanitizeCmd)
+	cli.AddPasswordFlag(sanitizeCmd, "Password for encrypted PDFs")
+	cli.AddPasswordFileFlag(sanitizeCmd, "")
+	cli.AddAllowInsecurePasswordFlag(sanitizeCmd)
+	sanitizeCmd.Flags().Bool("annotations", false, "Also remove all annotations, links and form fields")
+}
+
+var sanitizeCmd = &cobra.Command{
+	Use:   "sanitize <file.pdf> [file2.pdf...]",
+	Short: "Remove hidden data and active content",
+	Long: `Remove hidden data and active content from PDF file(s) before
+publishing them.
+
+Removes:
+  - JavaScript (document scripts, actions and XFA forms)
+  - Launch, form submission and other active actions
+  - The document open action
+  - Embedded files and file attachment annotations
+  - XMP metadata and document information (title, author, ...)
+  - Private application data (PieceInfo)
+  - Content on layers that are hidden by default; the remaining
+    layers are flattened into the page
+  - Objects that are no longer used
+
+Links and form fields are kept unless --annotations is given, which
+removes every annotation and the interactive form.
+
+A report of what was removed is printed for each file.
+
+Supports batch processing of multiple files. When processing
+multiple files, output files are named with '_sanitized' suffix.
+
+Examples:
+  pdf sanitize report.pdf -o public.pdf
+  pdf sanitize report.pdf --annotations -o public.pdf
+  pdf sanitize *.pdf`,
+	Args: cli.BatchArgs,
+	RunE: runSanitize,
+}
+
+func runSanitize(cmd *cobra.Command, args []string) error {
+	args, err := batchInputs(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	password, err := cli.GetPasswordSecure(cmd, "Enter PDF password: ")
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	output, err := sanitizeOutputPath(cli.GetOutput(cmd))
+	if err != nil {
+		return err
+	}
+
+	annotations, _ := cmd.Flags().GetBool("annotations")
+	opts := pdf.SanitizeOptions{Annotations: annotations}
+
+	// Handle dry-run mode
+	if cli.IsDryRun() {
+		return sanitizeDryRun(args, output, opts)
+	}
+
+	if err := validateBatchOutput(args, output, SuffixSanitized); err != nil {
+		return err
+	}
+
+	return processBatch(args, output, SuffixSanitized, func(w io.Writer, inputFile, out string) error {
+		return sanitizeFile(w, inputFile, out, password, opts)
+	})
+}
+
+func sanitizeDryRun(args []string, explicitOutput string, opts pdf.SanitizeOptions) error {
+	for _, inputFile := range args {
+		output := outputOrDefault(explicitOutput, inputFile, SuffixSanitized)
+		cli.DryRunPrint("Would sanitize: %s", inputFile)
+		if opts.Annotations {
+			cli.DryRunPrint("  Including annotations and form fields")
+		}
+		cli.DryRunPrint("  Output: %s", output)
+	}
+	return nil
+}
+
+func sanitizeFile(w io.Writer, inputFile, explicitOutput, password string, opts pdf.SanitizeOptions) error {
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return err
+	}
+
+	output := outputOrDefault(explicitOutput, inputFile, SuffixSanitized)
+	if err := checkOutputFile(output); err != nil {
+		return err
+	}
+
+	cli.PrintVerbose("Sanitizing %s", inputFile)
+
+	report, err := pdf.Sanitize(inputFile, output, opts, password)
+	if err != nil {
+		return pdferrors.WrapError("sanitizing", inputFile, err)
+	}
+
+	fmt.Fprintf(w, "Sanitized %s -> %s\n", inputFile, output)
+	printSanitizeReport(w, report)
+	return nil
+}
+
+// printSanitizeReport prints one line for each kind of data that was removed.
+func printSanitizeReport(w io.Writer, r *pdf.SanitizeReport) {
+	openAction := 0
+	if r.OpenAction {
+		openAction = 1
+	}
+
+	removed := false
+	for _, item := range []struct {
+		label string
+		count int
+	}{
+		{"JavaScript", r.JavaScript},
+		{"Active actions", r.Actions},
+		{"Open action", openAction},
+		{"Embedded files", r.EmbeddedFiles},
+		{"XMP metadata", r.Metadata},
+		{"Info entries", r.InfoEntries},
+		{"Private data", r.PrivateData},
+		{"Hidden layers", r.HiddenLayers},
+		{"Hidden content", r.HiddenContent},
+		{"Annotations", r.Annotations},
+		{"Unused objects", r.UnusedObjects},
+	} {
+		if item.count == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "  %-16s %d\n", item.label+":", item.count)
+		removed = true
+	}
+	if !removed {
+		fmt.Fprintln(w, "  Nothing to remove")
+	}
+}
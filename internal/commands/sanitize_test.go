@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSanitizeCommand(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output := filepath.Join(tmpDir, "clean.pdf")
+	if err := executeCommand("sanitize", samplePDF(), "--annotations", "-o", output); err != nil {
+		t.Fatalf("sanitize failed: %v", err)
+	}
+	if _, err := os.Stat(output); err != nil {
+		t.Errorf("expected output file: %v", err)
+	}
+}
+
+func TestSanitizeCommand_DryRun(t *testing.T) {
+	resetFlags(t)
+	if err := executeCommand("sanitize", samplePDF(), "--dry-run"); err != nil {
+		t.Fatalf("sanitize --dry-run failed: %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/lgbarn/pdf-cli/internal/cli"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/spf13/cobra"
+)
+
+// defaultSignatureRect is the position of a visible signature without --rect:
+// 200x60 points in the lower left corner, half an inch from the edges.
+const defaultSignatureRect = "36 36 236 96"
+
+func init() {
+	cli.AddCommand(signCmd)
+	cli.AddOutputFlag(signCmd, "Output file path")
+	cli.AddPasswordFlag(signCmd, "Password of the PKCS#12 file")
+	cli.AddPasswordFileFlag(signCmd, "Read the PKCS#12 password from file")
+	cli.AddAllowInsecurePasswordFlag(signCmd)
+	cli.MarkCertificatePassword(signCmd)
+	signCmd.Flags().String("cert", "", "Signer certificate: PKCS#12 file (.p12, .pfx) or PEM file (required)")
+	signCmd.Flags().String("key", "", "PEM private key (default: read from the --cert file)")
+	signCmd.Flags().String("type", pdf.SignFormatPAdES, "Signature type: pades (PAdES-B-B) or cms")
+	signCmd.Flags().String("field", "", "Signature field name (default: Signature1, Signature2, ...)")
+	signCmd.Flags().String("name", "", "Signer name (default: common name of the certificate)")
+	signCmd.Flags().String("reason", "", "Reason for signing")
+	signCmd.Flags().String("location", "", "Location of signing")
+	signCmd.Flags().String("contact", "", "Contact information of the signer")
+	signCmd.Flags().Bool("visible", false, "Show the signature on the page")
+	signCmd.Flags().Int("page", 1, "Page of the signature field")
+	signCmd.Flags().String("rect", "", "Position of a visible signature in points: \"llx lly urx ury\" (default \""+defaultSignatureRect+"\")")
+	_ = signCmd.MarkFlagRequired("cert")
+}
+
+var signCmd = &cobra.Command{
+	Use:   "sign <file.pdf>",
+	Short: "Digitally sign a PDF",
+	Long: `Add a digital signature to a PDF.
+
+The signer certificate and private key are read from a PKCS#12 file
+(.p12 or .pfx) or from PEM files. The password of a PKCS#12 file is read
+from --password-file, PDF_CLI_PASSWORD or an interactive prompt; encrypted
+PEM keys are not supported.
+
+Signature types:
+  pades - PAdES baseline B-B (ETSI.CAdES.detached), the default
+  cms   - CMS/PKCS#7 detached (adbe.pkcs7.detached) for older readers
+
+The signature is appended as an incremental update, so earlier
+signatures stay valid. By default the signature field is invisible; with
+--visible a box with the signer, date, reason and location is drawn on
+--page at --rect. No timestamp is requested from a time-stamping
+authority; the signing time is the local clock. Encrypted PDFs cannot
+be signed.
+
+The output defaults to the input name with the '_signed' suffix. Use
+'pdf signatures' to verify the result.
+
+Examples:
+  pdf sign contract.pdf --cert signer.p12 --password-file pw.txt --reason "Approved"
+  pdf sign contract.pdf --cert signer.p12 --password-file pw.txt --visible --page 3 --rect "350 50 550 110"
+  pdf sign contract.pdf --cert signer.pem --key signer.key --type cms -o signed.pdf`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSign,
+}
+
+func runSign(cmd *cobra.Command, args []string) error {
+	args, err := sanitizeInputArgs(args)
+	if err != nil {
+		return err
+	}
+
+	outputFile, err := sanitizeOutputPath(cli.GetOutput(cmd))
+	if err != nil {
+		return err
+	}
+
+	certFile, _ := cmd.Flags().GetString("cert")
+	keyFile, _ := cmd.Flags().GetString("key")
+	if certFile, err = fileio.SanitizePath(certFile); err != nil {
+		return fmt.Errorf("invalid certificate path: %w", err)
+	}
+	if keyFile != "" {
+		if keyFile, err = fileio.SanitizePath(keyFile); err != nil {
+			return fmt.Errorf("invalid key path: %w", err)
+		}
+	}
+
+	opts := pdf.SignOptions{}
+	opts.Format, _ = cmd.Flags().GetString("type")
+	opts.Field, _ = cmd.Flags().GetString("field")
+	opts.Name, _ = cmd.Flags().GetString("name")
+	opts.Reason, _ = cmd.Flags().GetString("reason")
+	opts.Location, _ = cmd.Flags().GetString("location")
+	opts.Contact, _ = cmd.Flags().GetString("contact")
+	opts.Visible, _ = cmd.Flags().GetBool("visible")
+	opts.Page, _ = cmd.Flags().GetInt("page")
+	rect, _ := cmd.Flags().GetString("rect")
+	if rect != "" && !opts.Visible {
+		return fmt.Errorf("--rect requires --visible")
+	}
+	if opts.Visible {
+		if rect == "" {
+			rect = defaultSignatureRect
+		}
+		if opts.Rect, err = pdf.ParseRect(rect); err != nil {
+			return err
+		}
+	}
+
+	inputFile := args[0]
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return err
+	}
+	output := outputOrDefault(outputFile, inputFile, SuffixSigned)
+
+	// Only PKCS#12 files are protected by a password.
+	password := ""
+	if pdf.IsPKCS12File(certFile) {
+		if password, err = cli.ReadPassword(cmd, "Enter certificate password: "); err != nil {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+	}
+	signer, err := pdf.LoadSigner(certFile, keyFile, password)
+	if err != nil {
+		return err
+	}
+
+	if cli.IsDryRun() {
+		cli.DryRunPrint("Would sign: %s", inputFile)
+		cli.DryRunPrint("  Signer: %s", signer.Certificate.Subject)
+		cli.DryRunPrint("  Type: %s", opts.Format)
+		if opts.Visible {
+			cli.DryRunPrint("  Visible on page %d at %s", opts.Page, opts.Rect)
+		}
+		cli.DryRunPrint("  Output: %s", output)
+		return nil
+	}
+
+	if err := checkOutputFile(output); err != nil {
+		return err
+	}
+
+	cli.PrintVerbose("Signing %s as %s", inputFile, signer.Certificate.Subject)
+
+	if err := pdf.Sign(inputFile, output, signer, opts); err != nil {
+		return pdferrors.WrapError("signing", inputFile, err)
+	}
+
+	fmt.Printf("Signed %s as %s: %s\n", inputFile, signer.Certificate.Subject.CommonName, output)
+	return nil
+}
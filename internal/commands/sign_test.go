@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// testSigner writes a signer certificate issued by a new CA to a PKCS#12
+// file protected by password, and the CA to a trust store directory.
+func testSigner(t *testing.T, password string) (p12, trustStore string) {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Sign Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _ := x509.ParseCertificate(caDER)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "Jane Signer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+
+	data, err := pkcs12.Modern.Encode(key, cert, []*x509.Certificate{ca}, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	p12 = filepath.Join(dir, "signer.p12")
+	if err := os.WriteFile(p12, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	trustStore = filepath.Join(dir, "trust")
+	if err := os.Mkdir(trustStore, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	if err := os.WriteFile(filepath.Join(trustStore, "ca.pem"), caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return p12, trustStore
+}
+
+func TestSignCommand(t *testing.T) {
+	p12, trustStore := testSigner(t, "secret")
+	pwFile := filepath.Join(t.TempDir(), "pw.txt")
+	if err := os.WriteFile(pwFile, []byte("secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(t.TempDir(), "signed.pdf")
+
+	resetFlags(t)
+	if err := executeCommand("sign", samplePDF(), "--cert", p12, "--password-file", pwFile,
+		"--reason", "Approved", "--visible", "--page", "1", "--rect", "36 36 236 96", "-o", output); err != nil {
+		t.Fatalf("sign failed: %v", err)
+	}
+
+	resetFlags(t)
+	if err := executeCommand("signatures", output, "--trust-store", trustStore); err != nil {
+		t.Errorf("signatures of the signed PDF failed: %v", err)
+	}
+}
+
+func TestSignCommandErrors(t *testing.T) {
+	p12, _ := testSigner(t, "secret")
+	wrongPW := filepath.Join(t.TempDir(), "pw.txt")
+	if err := os.WriteFile(wrongPW, []byte("wrong"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(t.TempDir(), "signed.pdf")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"wrong password", []string{"--password-file", wrongPW}, "decode"},
+		{"rect without visible", []string{"--rect", "0 0 10 10"}, "--visible"},
+		{"invalid rect", []string{"--visible", "--rect", "10 10 0 0"}, "invalid rectangle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags(t)
+			args := append([]string{"sign", samplePDF(), "--cert", p12, "-o", output}, tt.args...)
+			if err := executeCommand(args...); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func signedPDF() string {
+	return filepath.Join(testdataDir(), "signed.pdf")
+}
+
+func TestSignaturesCommand(t *testing.T) {
+	resetFlags(t)
+	trustStore := filepath.Join(testdataDir(), "truststore")
+	if err := executeCommand("signatures", signedPDF(), "--trust-store", trustStore, "--format", "json"); err != nil {
+		t.Fatalf("signatures failed: %v", err)
+	}
+
+	resetFlags(t)
+	if err := executeCommand("signatures", samplePDF()); err != nil {
+		t.Errorf("signatures of an unsigned PDF failed: %v", err)
+	}
+}
+
+func TestSignaturesCommandUntrusted(t *testing.T) {
+	// A trust store without the test CA leaves the signature unverified.
+	dir := t.TempDir()
+	data, err := os.ReadFile(filepath.Join(testdataDir(), "truststore", "ca.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ca.txt"), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resetFlags(t)
+	if err := executeCommand("signatures", signedPDF(), "--trust-store", dir); err == nil || !strings.Contains(err.Error(), "no certificates") {
+		t.Errorf("expected empty trust store error, got %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "other.pem"), otherCertPEM(t), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	resetFlags(t)
+	if err := executeCommand("signatures", signedPDF(), "--trust-store", dir); err == nil || !strings.Contains(err.Error(), "1 of 1 signatures") {
+		t.Errorf("expected verification failure, got %v", err)
+	}
+}
+
+// otherCertPEM returns a self-signed CA certificate unrelated to the test CA.
+func otherCertPEM(t *testing.T) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Other CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
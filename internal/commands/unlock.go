@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/lgbarn/pdf-cli/internal/cli"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+	"github.com/lgbarn/pdf-cli/internal/progress"
+	"github.com/schollz/progressbar/v3"
+	"github.com/spf13/cobra"
+)
+
+// errPasswordNotFound is returned when no candidate of the wordlist opens the PDF.
+var errPasswordNotFound = errors.New("password not found in wordlist")
+
+func init() {
+	cli.AddCommand(unlockCmd)
+	cli.AddOutputFlag(unlockCmd, "Output file path for the decrypted PDF")
+	unlockCmd.Flags().String("wordlist", "", "File with one candidate password per line (required)")
+	_ = unlockCmd.MarkFlagRequired("wordlist")
+}
+
+var unlockCmd = &cobra.Command{
+	Use:   "unlock <file.pdf>",
+	Short: "Recover the password of a PDF from a wordlist",
+	Long: `Recover the lost password of one of your own encrypted PDFs by trying
+the candidates of a wordlist.
+
+The wordlist holds one candidate per line; line endings are removed and
+empty lines are skipped, all other characters including spaces are part
+of the password. Candidates are tried concurrently with --jobs workers
+(default performance.max_workers) and the search stops at the first
+password that opens the file, as user or owner password.
+
+The password found is printed and a decrypted copy is written to the
+output, which defaults to the input name with the '_unlocked' suffix.
+Press Ctrl+C to stop the search.
+
+Examples:
+  pdf unlock archive.pdf --wordlist words.txt
+  pdf unlock archive.pdf --wordlist words.txt -o archive_open.pdf --progress`,
+	Args: cobra.ExactArgs(1),
+	RunE: runUnlock,
+}
+
+func runUnlock(cmd *cobra.Command, args []string) error {
+	args, err := sanitizeInputArgs(args)
+	if err != nil {
+		return err
+	}
+
+	outputFile, err := sanitizeOutputPath(cli.GetOutput(cmd))
+	if err != nil {
+		return err
+	}
+
+	wordlist, _ := cmd.Flags().GetString("wordlist")
+	candidates, err := readWordlist(wordlist)
+	if err != nil {
+		return err
+	}
+
+	inputFile := args[0]
+	if err := fileio.ValidatePDFFile(inputFile); err != nil {
+		return err
+	}
+	output := outputOrDefault(outputFile, inputFile, SuffixUnlocked)
+
+	data, err := os.ReadFile(inputFile) // #nosec G304 -- path is sanitized
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	// A file that opens without a password may still have an owner password.
+	if info, err := pdf.GetInfo(inputFile, ""); err == nil && !info.Encrypted {
+		return fmt.Errorf("%s is not encrypted", inputFile)
+	} else if err != nil && !pdferrors.IsWrongPassword(pdferrors.WrapError("reading", inputFile, err)) {
+		return pdferrors.WrapError("reading", inputFile, err)
+	}
+
+	workers := max(1, min(cli.Jobs(), len(candidates)))
+
+	if cli.IsDryRun() {
+		cli.DryRunPrint("Would unlock: %s", inputFile)
+		cli.DryRunPrint("  Candidates: %d", len(candidates))
+		cli.DryRunPrint("  Workers: %d", workers)
+		cli.DryRunPrint("  Output: %s", output)
+		return nil
+	}
+
+	if err := checkOutputFile(output); err != nil {
+		return err
+	}
+
+	cli.PrintVerbose("Trying %d passwords on %s with %d workers", len(candidates), inputFile, workers)
+
+	var bar *progressbar.ProgressBar
+	if cli.Progress() {
+		bar = progress.NewProgressBar("Trying passwords", len(candidates), 0)
+	}
+	password, err := findPassword(cmd.Context(), inputFile, data, candidates, workers, bar)
+	progress.FinishProgressBar(bar)
+	if err != nil {
+		return err
+	}
+
+	if err := pdf.Decrypt(inputFile, output, password); err != nil {
+		return pdferrors.WrapError("decrypting file", inputFile, err)
+	}
+
+	fmt.Printf("Password of %s: %s\n", inputFile, password)
+	fmt.Printf("Decrypted %s to %s\n", inputFile, output)
+	return nil
+}
+
+// readWordlist returns the non-empty lines of a wordlist file.
+func readWordlist(path string) ([]string, error) {
+	path, err := fileio.SanitizePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wordlist path: %w", err)
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- path is sanitized
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wordlist: %w", err)
+	}
+	var candidates []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			candidates = append(candidates, line)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("wordlist %s is empty", path)
+	}
+	return candidates, nil
+}
+
+// findPassword tries candidates on the PDF data with workers concurrent
+// workers and returns the first password that opens it. The search stops
+// early on a hit, on an error other than a wrong password, or when ctx is
+// canceled.
+func findPassword(ctx context.Context, inputFile string, data []byte, candidates []string, workers int, bar *progressbar.ProgressBar) (string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		found    string
+		firstErr error
+	)
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for password := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				err := pdf.CheckPassword(data, password)
+				if bar != nil {
+					_ = bar.Add(1)
+				}
+				if err != nil && pdferrors.IsWrongPassword(pdferrors.WrapError("unlocking", inputFile, err)) {
+					continue
+				}
+				mu.Lock()
+				if err == nil && found == "" {
+					found = password
+				} else if err != nil && firstErr == nil {
+					firstErr = pdferrors.WrapError("unlocking", inputFile, err)
+				}
+				mu.Unlock()
+				cancel()
+			}
+		}()
+	}
+
+feed:
+	for _, password := range candidates {
+		select {
+		case jobs <- password:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	switch {
+	case found != "":
+		return found, nil
+	case firstErr != nil:
+		return "", firstErr
+	case ctx.Err() != nil:
+		// Canceled by the caller, e.g. on Ctrl+C
+		return "", ctx.Err()
+	}
+	return "", errPasswordNotFound
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lgbarn/pdf-cli/internal/pdf"
+)
+
+// encryptedSample encrypts the sample PDF with password into a temp directory.
+func encryptedSample(t *testing.T, password string) string {
+	t.Helper()
+	encrypted := filepath.Join(t.TempDir(), "archive.pdf")
+	if err := pdf.Encrypt(samplePDF(), encrypted, password, ""); err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	return encrypted
+}
+
+func writeWordlist(t *testing.T, words ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(words, "\r\n")+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestUnlockCommand(t *testing.T) {
+	encrypted := encryptedSample(t, "tiger7")
+	wordlist := writeWordlist(t, "alpha", "", "bra vo", "tiger7", "charlie", "delta")
+
+	resetFlags(t)
+	if err := executeCommand("unlock", encrypted, "--wordlist", wordlist); err != nil {
+		t.Fatalf("unlock failed: %v", err)
+	}
+
+	output := strings.TrimSuffix(encrypted, ".pdf") + SuffixUnlocked + ".pdf"
+	info, err := pdf.GetInfo(output, "")
+	if err != nil {
+		t.Fatalf("GetInfo() of the unlocked PDF error = %v", err)
+	}
+	if info.Encrypted {
+		t.Error("unlocked PDF should not be encrypted")
+	}
+}
+
+func TestUnlockCommandErrors(t *testing.T) {
+	encrypted := encryptedSample(t, "secret")
+	output := filepath.Join(t.TempDir(), "out.pdf")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"not found", []string{encrypted, "--wordlist", writeWordlist(t, "one", "two", "three")}, "not found"},
+		{"empty wordlist", []string{encrypted, "--wordlist", writeWordlist(t, "")}, "is empty"},
+		{"not encrypted", []string{samplePDF(), "--wordlist", writeWordlist(t, "one")}, "not encrypted"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags(t)
+			args := append([]string{"unlock", "-o", output}, tt.args...)
+			if err := executeCommand(args...); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFindPasswordCanceled(t *testing.T) {
+	encrypted := encryptedSample(t, "secret")
+	data, err := os.ReadFile(encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := findPassword(ctx, encrypted, data, []string{"one", "secret"}, 2, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("findPassword() with a canceled context error = %v", err)
+	}
+
+	password, err := findPassword(context.Background(), encrypted, data, []string{"one", "two", "secret", "four"}, 3, nil)
+	if err != nil || password != "secret" {
+		t.Errorf("findPassword() = %q, %v", password, err)
+	}
+}
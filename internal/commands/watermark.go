@@ -2,9 +2,13 @@ package commands
 
 import (
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 
 	"github.com/lgbarn/pdf-cli/internal/cli"
 	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/lgbarn/pdf-cli/internal/output"
 	"github.com/lgbarn/pdf-cli/internal/pdf"
 	"github.com/lgbarn/pdf-cli/internal/pdferrors"
 	"github.com/spf13/cobra"
@@ -13,12 +17,26 @@ import (
 func init() {
 	cli.AddCommand(watermarkCmd)
 	cli.AddOutputFlag(watermarkCmd, "Output file path (only with single file)")
+	cli.AddOutputDirFlag(watermarkCmd)
+	cli.AddBatchInputFlags(watermarkCmd)
 	cli.AddPagesFlag(watermarkCmd, "Pages to watermark (default: all)")
 	cli.AddPasswordFlag(watermarkCmd, "Password for encrypted PDFs")
 	cli.AddPasswordFileFlag(watermarkCmd, "")
 	cli.AddAllowInsecurePasswordFlag(watermarkCmd)
 	watermarkCmd.Flags().StringP("text", "t", "", "Text watermark content")
 	watermarkCmd.Flags().StringP("image", "i", "", "Image file for image watermark")
+	watermarkCmd.Flags().String("font", "Helvetica", "Font name for text watermarks (e.g., Helvetica, Times-Roman, Courier-Bold)")
+	watermarkCmd.Flags().Int("font-size", 0, "Font size in points (default: scale text to the page)")
+	watermarkCmd.Flags().String("color", "0.5 0.5 0.5", "Text color: name (red), #RRGGBB or \"r g b\" (0-1)")
+	watermarkCmd.Flags().Float64("opacity", 0.3, "Opacity from 0 (invisible) to 1 (opaque)")
+	watermarkCmd.Flags().Float64("rotation", 45, "Rotation in degrees, -180 to 180 (images follow the page diagonal unless set)")
+	watermarkCmd.Flags().Float64("scale", 0, "Size relative to the page, 0 < scale <= 1 (default: 1 for text, 0.5 for images)")
+	watermarkCmd.Flags().String("position", "center", "Position: center, top-left, top, top-right, left, right, bottom-left, bottom, bottom-right")
+	watermarkCmd.Flags().String("offset", "", "Offset from position in points as \"dx,dy\" (e.g., \"-20,20\")")
+	watermarkCmd.Flags().Bool("background", false, "Place the watermark behind page content instead of on top")
+	watermarkCmd.Flags().Bool("remove", false, "Remove watermarks and stamps instead of adding one")
+	watermarkCmd.Flags().Bool("list", false, "List pages that have watermarks or stamps")
+	cli.AddFormatFlag(watermarkCmd)
 }
 
 var watermarkCmd = &cobra.Command{
@@ -29,23 +47,47 @@ var watermarkCmd = &cobra.Command{
 Text watermarks are rendered diagonally across each page.
 Image watermarks are centered on each page.
 
-Either --text or --image must be specified.
+Either --text or --image must be specified, unless --remove or
+--list is used.
+
+Style the watermark with --font, --font-size, --color, --opacity,
+--rotation and --scale. Use --position to anchor it to a corner or
+edge of the page and --offset to move it from there. By default the
+watermark is stamped on top of the page; use --background to place
+it behind the page content.
+
+Text watermarks may contain placeholders:
+  {page}      current page number
+  {pages}     total number of pages
+  {date}      current date (YYYY-MM-DD)
+  {filename}  name of the input file
+
+Use --list to show which pages carry watermarks or stamps, and
+--remove to strip them again (e.g., to re-issue a draft as final).
+Both detect everything added by pdf-cli or pdfcpu, including
+headers, footers and Bates numbers; -p limits removal to some pages.
 
 Supports batch processing of multiple files. When processing
-multiple files, output files are named with '_watermarked' suffix.
+multiple files, output files are named with '_watermarked' suffix,
+or '_unwatermarked' with --remove.
 
 Examples:
   pdf watermark document.pdf -t "CONFIDENTIAL" -o marked.pdf
   pdf watermark document.pdf -t "DRAFT" -p 1-5 -o draft.pdf
   pdf watermark document.pdf -i logo.png -o branded.pdf
+  pdf watermark document.pdf -t "CONFIDENTIAL - Page {page} of {pages}" \
+      --position bottom --rotation 0 --font-size 10 --color red --opacity 1
+  pdf watermark document.pdf -i logo.png --position top-right --scale 0.1 --offset "-20,-20"
   pdf watermark *.pdf -t "CONFIDENTIAL"       # Batch watermark
-  pdf watermark doc1.pdf doc2.pdf -t "DRAFT"  # Multiple files`,
-	Args: cobra.MinimumNArgs(1),
+  pdf watermark doc1.pdf doc2.pdf -t "DRAFT"  # Multiple files
+  pdf watermark draft.pdf --list
+  pdf watermark draft.pdf --remove -o final.pdf`,
+	Args: cli.BatchArgs,
 	RunE: runWatermark,
 }
 
 func runWatermark(cmd *cobra.Command, args []string) error {
-	args, err := sanitizeInputArgs(args)
+	args, err := batchInputs(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -72,6 +114,21 @@ func runWatermark(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	remove, _ := cmd.Flags().GetBool("remove")
+	list, _ := cmd.Flags().GetBool("list")
+	if remove || list {
+		if remove && list {
+			return fmt.Errorf("cannot specify both --remove and --list")
+		}
+		if text != "" || image != "" {
+			return fmt.Errorf("--text and --image cannot be used with --remove or --list")
+		}
+		if list {
+			return listWatermarks(cmd, args, password)
+		}
+		return removeWatermarks(args, output, pagesStr, password)
+	}
+
 	if text == "" && image == "" {
 		return fmt.Errorf("must specify either --text or --image for watermark")
 	}
@@ -83,21 +140,93 @@ func runWatermark(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("image file not found: %s", image)
 	}
 
+	opts, err := watermarkOptions(cmd, text != "")
+	if err != nil {
+		return err
+	}
+
 	// Handle dry-run mode
 	if cli.IsDryRun() {
-		return watermarkDryRun(args, output, pagesStr, password, text, image)
+		return watermarkDryRun(args, output, pagesStr, password, text, image, opts)
 	}
 
 	if err := validateBatchOutput(args, output, SuffixWatermarked); err != nil {
 		return err
 	}
 
-	return processBatch(args, func(inputFile string) error {
-		return watermarkFile(inputFile, output, pagesStr, password, text, image)
+	return processBatch(args, output, SuffixWatermarked, func(w io.Writer, inputFile, out string) error {
+		return watermarkFile(w, inputFile, out, pagesStr, password, text, image, opts)
 	})
 }
 
-func watermarkDryRun(args []string, explicitOutput, pagesStr, password, text, image string) error {
+// watermarkOptions builds watermark options from the styling flags.
+// Flags that were not set keep the defaults of the watermark type.
+func watermarkOptions(cmd *cobra.Command, isText bool) (pdf.WatermarkOptions, error) {
+	opts := pdf.DefaultImageWatermarkOptions()
+	if isText {
+		opts = pdf.DefaultTextWatermarkOptions()
+	}
+
+	flags := cmd.Flags()
+	if isText {
+		opts.FontName, _ = flags.GetString("font")
+		opts.FontSize, _ = flags.GetInt("font-size")
+		opts.Color, _ = flags.GetString("color")
+	} else {
+		for _, name := range []string{"font", "font-size", "color"} {
+			if flags.Changed(name) {
+				return opts, fmt.Errorf("--%s can only be used with --text", name)
+			}
+		}
+	}
+
+	scale, _ := flags.GetFloat64("scale")
+	if scale != 0 {
+		if opts.FontSize > 0 {
+			return opts, fmt.Errorf("cannot specify both --font-size and --scale")
+		}
+		if scale < 0 {
+			return opts, fmt.Errorf("invalid scale %g: must be greater than 0 and at most 1", scale)
+		}
+		opts.Scale = scale
+	}
+	if flags.Changed("rotation") {
+		opts.Rotation, _ = flags.GetFloat64("rotation")
+		opts.Diagonal = false
+	}
+	opts.Opacity, _ = flags.GetFloat64("opacity")
+	opts.Position, _ = flags.GetString("position")
+	opts.Background, _ = flags.GetBool("background")
+
+	if offset, _ := flags.GetString("offset"); offset != "" {
+		dx, dy, err := parseOffset(offset)
+		if err != nil {
+			return opts, err
+		}
+		opts.OffsetX, opts.OffsetY = dx, dy
+	}
+
+	if err := pdf.ValidateWatermarkOptions(opts, isText); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// parseOffset parses an offset given as "dx,dy" or "dx dy" in points.
+func parseOffset(s string) (float64, float64, error) {
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid offset %q: use \"dx,dy\" in points (e.g., \"-20,20\")", s)
+	}
+	dx, errX := strconv.ParseFloat(parts[0], 64)
+	dy, errY := strconv.ParseFloat(parts[1], 64)
+	if errX != nil || errY != nil {
+		return 0, 0, fmt.Errorf("invalid offset %q: use \"dx,dy\" in points (e.g., \"-20,20\")", s)
+	}
+	return dx, dy, nil
+}
+
+func watermarkDryRun(args []string, explicitOutput, pagesStr, password, text, image string, opts pdf.WatermarkOptions) error {
 	for _, inputFile := range args {
 		info, err := pdf.GetInfo(inputFile, password)
 		if err != nil {
@@ -106,10 +235,7 @@ func watermarkDryRun(args []string, explicitOutput, pagesStr, password, text, im
 		}
 
 		output := outputOrDefault(explicitOutput, inputFile, SuffixWatermarked)
-		pageDesc := "all pages"
-		if pagesStr != "" {
-			pageDesc = "pages " + pagesStr
-		}
+		pageDesc := describePages(pagesStr)
 
 		cli.DryRunPrint("Would watermark: %s (%d pages)", inputFile, info.Pages)
 		if text != "" {
@@ -117,13 +243,14 @@ func watermarkDryRun(args []string, explicitOutput, pagesStr, password, text, im
 		} else {
 			cli.DryRunPrint("  Image: %s", image)
 		}
+		cli.DryRunPrint("  Position: %s, opacity %g", opts.Position, opts.Opacity)
 		cli.DryRunPrint("  Pages: %s", pageDesc)
 		cli.DryRunPrint("  Output: %s", output)
 	}
 	return nil
 }
 
-func watermarkFile(inputFile, explicitOutput, pagesStr, password, text, image string) error {
+func watermarkFile(w io.Writer, inputFile, explicitOutput, pagesStr, password, text, image string, opts pdf.WatermarkOptions) error {
 	if err := fileio.ValidatePDFFile(inputFile); err != nil {
 		return err
 	}
@@ -141,16 +268,113 @@ func watermarkFile(inputFile, explicitOutput, pagesStr, password, text, image st
 
 	if text != "" {
 		cli.PrintVerbose("Adding text watermark '%s' to %s", text, inputFile)
-		if err := pdf.AddWatermark(inputFile, output, text, pages, password); err != nil {
+		if err := pdf.AddWatermarkWithOptions(inputFile, output, text, opts, pages, password); err != nil {
 			return pdferrors.WrapError("adding watermark", inputFile, err)
 		}
 	} else {
 		cli.PrintVerbose("Adding image watermark '%s' to %s", image, inputFile)
-		if err := pdf.AddImageWatermark(inputFile, output, image, pages, password); err != nil {
+		if err := pdf.AddImageWatermarkWithOptions(inputFile, output, image, opts, pages, password); err != nil {
 			return pdferrors.WrapError("adding watermark", inputFile, err)
 		}
 	}
 
-	fmt.Printf("Watermark added to %s\n", output)
+	fmt.Fprintf(w, "Watermark added to %s\n", output)
 	return nil
 }
+
+// WatermarkListOutput represents the watermarks found on one page for structured output.
+type WatermarkListOutput struct {
+	File       string `json:"file"`
+	Page       int    `json:"page"`
+	Watermarks int    `json:"watermarks"`
+}
+
+// listWatermarks prints the pages of each file that carry watermarks or stamps.
+func listWatermarks(cmd *cobra.Command, args []string, password string) error {
+	formatter := output.NewOutputFormatter(cli.GetFormat(cmd))
+
+	var found []WatermarkListOutput
+	err := processBatchInOrder(args, func(inputFile string) error {
+		if err := fileio.ValidatePDFFile(inputFile); err != nil {
+			return err
+		}
+		cli.PrintVerbose("Looking for watermarks in %s", inputFile)
+
+		pages, err := pdf.ListWatermarks(inputFile, password)
+		if err != nil {
+			return pdferrors.WrapError("listing watermarks", inputFile, err)
+		}
+		for _, p := range pages {
+			found = append(found, WatermarkListOutput{File: inputFile, Page: p.Page, Watermarks: p.Count})
+		}
+		return nil
+	})
+
+	switch {
+	case formatter.Format == output.FormatJSON:
+		if found == nil {
+			found = []WatermarkListOutput{}
+		}
+		if printErr := formatter.Print(found); printErr != nil {
+			return printErr
+		}
+	case len(found) == 0 && !formatter.IsStructured():
+		fmt.Println("No watermarks found")
+	default:
+		headers := []string{"file", "page", "watermarks"}
+		rows := make([][]string, 0, len(found))
+		for _, f := range found {
+			rows = append(rows, []string{f.File, strconv.Itoa(f.Page), strconv.Itoa(f.Watermarks)})
+		}
+		if printErr := formatter.PrintTable(headers, rows); printErr != nil {
+			return printErr
+		}
+	}
+	return err
+}
+
+// removeWatermarks strips watermarks and stamps from each file.
+func removeWatermarks(args []string, explicitOutput, pagesStr, password string) error {
+	if cli.IsDryRun() {
+		for _, inputFile := range args {
+			pages, err := pdf.ListWatermarks(inputFile, password)
+			if err != nil {
+				cli.DryRunPrint("Would remove watermarks: %s (unable to read watermarks)", inputFile)
+				continue
+			}
+			cli.DryRunPrint("Would remove watermarks: %s (%d pages watermarked)", inputFile, len(pages))
+			if pagesStr != "" || cli.InvertPages() {
+				cli.DryRunPrint("  Pages: %s", describePages(pagesStr))
+			}
+			cli.DryRunPrint("  Output: %s", outputOrDefault(explicitOutput, inputFile, SuffixUnwatermarked))
+		}
+		return nil
+	}
+
+	if err := validateBatchOutput(args, explicitOutput, SuffixUnwatermarked); err != nil {
+		return err
+	}
+
+	return processBatch(args, explicitOutput, SuffixUnwatermarked, func(w io.Writer, inputFile, output string) error {
+		if err := fileio.ValidatePDFFile(inputFile); err != nil {
+			return err
+		}
+
+		pages, err := parseAndValidatePages(pagesStr, inputFile, password)
+		if err != nil {
+			return err
+		}
+
+		if err := checkOutputFile(output); err != nil {
+			return err
+		}
+
+		cli.PrintVerbose("Removing watermarks from %s", inputFile)
+		if err := pdf.RemoveWatermarks(inputFile, output, pages, password); err != nil {
+			return pdferrors.WrapError("removing watermarks", inputFile, err)
+		}
+
+		fmt.Fprintf(w, "Watermarks removed: %s\n", output)
+		return nil
+	})
+}
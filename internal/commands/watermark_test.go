@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWatermarkCommand_Styled(t *testing.T) {
+	testImage := filepath.Join(testdataDir(), "test_image.png")
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"page stamp", []string{"-t", "CONFIDENTIAL - Page {page} of {pages}", "--position", "bottom", "--rotation", "0", "--font-size", "10", "--color", "red", "--opacity", "1"}},
+		{"background", []string{"-t", "DRAFT {date}", "--background", "--font", "Times-Bold", "--color", "#336699"}},
+		{"corner image", []string{"-i", testImage, "--position", "top-right", "--scale", "0.1", "--offset", "-20,-20"}},
+		{"rotated image", []string{"-i", testImage, "--rotation", "90"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags(t)
+			if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+				t.Skip("sample.pdf not found in testdata")
+			}
+
+			tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tmpDir)
+
+			output := filepath.Join(tmpDir, "watermarked.pdf")
+			args := append([]string{"watermark", samplePDF(), "-o", output}, tt.args...)
+			if err := executeCommand(args...); err != nil {
+				t.Fatalf("watermark %v failed: %v", tt.args, err)
+			}
+			if _, err := os.Stat(output); os.IsNotExist(err) {
+				t.Error("watermark did not create output file")
+			}
+		})
+	}
+}
+
+func TestWatermarkCommand_InvalidStyle(t *testing.T) {
+	testImage := filepath.Join(testdataDir(), "test_image.png")
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"font size and scale", []string{"-t", "X", "--font-size", "12", "--scale", "0.5"}},
+		{"font with image", []string{"-i", testImage, "--font", "Courier"}},
+		{"unknown font", []string{"-t", "X", "--font", "NoSuchFont"}},
+		{"bad color", []string{"-t", "X", "--color", "ultraviolet"}},
+		{"bad opacity", []string{"-t", "X", "--opacity", "2"}},
+		{"bad rotation", []string{"-t", "X", "--rotation", "270"}},
+		{"bad position", []string{"-t", "X", "--position", "middle"}},
+		{"bad offset", []string{"-t", "X", "--offset", "10"}},
+		{"negative scale", []string{"-t", "X", "--scale", "-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags(t)
+			tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tmpDir)
+
+			args := append([]string{"watermark", samplePDF(), "-o", filepath.Join(tmpDir, "out.pdf")}, tt.args...)
+			if err := executeCommand(args...); err == nil {
+				t.Errorf("watermark %v should fail", tt.args)
+			}
+		})
+	}
+}
+
+func TestParseOffset(t *testing.T) {
+	tests := []struct {
+		input   string
+		dx, dy  float64
+		wantErr bool
+	}{
+		{"10,20", 10, 20, false},
+		{"-20 15.5", -20, 15.5, false},
+		{"-20, -20", -20, -20, false},
+		{"10", 0, 0, true},
+		{"a,b", 0, 0, true},
+		{"1,2,3", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		dx, dy, err := parseOffset(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseOffset(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if dx != tt.dx || dy != tt.dy {
+			t.Errorf("parseOffset(%q) = (%g, %g), want (%g, %g)", tt.input, dx, dy, tt.dx, tt.dy)
+		}
+	}
+}
+
+func TestWatermarkCommand_ListAndRemove(t *testing.T) {
+	resetFlags(t)
+	if _, err := os.Stat(samplePDF()); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	marked := filepath.Join(tmpDir, "marked.pdf")
+	if err = executeCommand("watermark", samplePDF(), "-t", "DRAFT", "-o", marked); err != nil {
+		t.Fatalf("watermark failed: %v", err)
+	}
+
+	resetFlags(t)
+	if err = executeCommand("watermark", marked, "--list", "--format", "json"); err != nil {
+		t.Fatalf("watermark --list failed: %v", err)
+	}
+
+	resetFlags(t)
+	clean := filepath.Join(tmpDir, "clean.pdf")
+	if err = executeCommand("watermark", marked, "--remove", "-o", clean); err != nil {
+		t.Fatalf("watermark --remove failed: %v", err)
+	}
+	if _, err = os.Stat(clean); os.IsNotExist(err) {
+		t.Error("watermark --remove did not create output file")
+	}
+
+	resetFlags(t)
+	if err = executeCommand("watermark", clean, "--remove", "-o", filepath.Join(tmpDir, "again.pdf")); err == nil {
+		t.Error("removing watermarks from a clean file should fail")
+	}
+}
+
+func TestWatermarkCommand_RemoveConflicts(t *testing.T) {
+	tests := [][]string{
+		{"--remove", "--list"},
+		{"--remove", "-t", "DRAFT"},
+		{"--list", "-i", "logo.png"},
+	}
+	for _, args := range tests {
+		resetFlags(t)
+		if err := executeCommand(append([]string{"watermark", samplePDF()}, args...)...); err == nil {
+			t.Errorf("watermark %v should fail", args)
+		}
+	}
+}
@@ -17,14 +17,16 @@ type Config struct {
 	Compress    CompressConfig    `yaml:"compress"`
 	Encrypt     EncryptConfig     `yaml:"encrypt"`
 	OCR         OCRConfig         `yaml:"ocr"`
+	Signatures  SignaturesConfig  `yaml:"signatures"`
 	Performance PerformanceConfig `yaml:"performance"`
 }
 
 // DefaultsConfig holds default settings.
 type DefaultsConfig struct {
-	OutputFormat string `yaml:"output_format"` // json, csv, tsv, human
+	OutputFormat string `yaml:"output_format"` // json, csv, tsv, yaml, human
 	Verbose      bool   `yaml:"verbose"`
 	ShowProgress bool   `yaml:"show_progress"`
+	FailFast     bool   `yaml:"fail_fast"` // stop batches at the first failed file
 }
 
 // CompressConfig holds compression settings.
@@ -43,6 +45,11 @@ type OCRConfig struct {
 	Backend  string `yaml:"backend"`  // auto, native, wasm
 }
 
+// SignaturesConfig holds signature verification settings.
+type SignaturesConfig struct {
+	TrustStore string `yaml:"trust_store"` // directory of trusted certificates (default: system)
+}
+
 // PerformanceConfig holds performance-related settings.
 type PerformanceConfig struct {
 	OCRParallelThreshold  int `yaml:"ocr_parallel_threshold"`
@@ -140,12 +147,18 @@ func applyEnvOverrides(cfg *Config) {
 	if env := os.Getenv("PDF_CLI_VERBOSE"); env == "true" || env == "1" {
 		cfg.Defaults.Verbose = true
 	}
+	if env := os.Getenv("PDF_CLI_FAIL_FAST"); env == "true" || env == "1" {
+		cfg.Defaults.FailFast = true
+	}
 	if env := os.Getenv("PDF_CLI_OCR_LANGUAGE"); env != "" {
 		cfg.OCR.Language = env
 	}
 	if env := os.Getenv("PDF_CLI_OCR_BACKEND"); env != "" {
 		cfg.OCR.Backend = env
 	}
+	if env := os.Getenv("PDF_CLI_TRUST_STORE"); env != "" {
+		cfg.Signatures.TrustStore = env
+	}
 	if env := os.Getenv("PDF_CLI_PERF_OCR_THRESHOLD"); env != "" {
 		if v, err := strconv.Atoi(env); err == nil && v > 0 {
 			cfg.Performance.OCRParallelThreshold = v
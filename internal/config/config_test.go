@@ -88,6 +88,21 @@ func TestLoadWithEnvOverrideNumericVerbose(t *testing.T) {
 	}
 }
 
+func TestLoadWithEnvOverrideFailFast(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/nonexistent/path")
+	t.Setenv("PDF_CLI_FAIL_FAST", "true")
+	Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if !cfg.Defaults.FailFast {
+		t.Error("Expected fail_fast to be true with PDF_CLI_FAIL_FAST=true")
+	}
+}
+
 func TestSaveAndLoad(t *testing.T) {
 	// Create temp directory for config
 	tmpDir := t.TempDir()
@@ -256,3 +271,18 @@ func TestReset(t *testing.T) {
 		t.Error("After Reset, Get should return a new config instance")
 	}
 }
+
+func TestLoadWithEnvTrustStoreOverride(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/nonexistent/path")
+	t.Setenv("PDF_CLI_TRUST_STORE", "/etc/pdf-cli/certs")
+	Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Signatures.TrustStore != "/etc/pdf-cli/certs" {
+		t.Errorf("Expected trust store '/etc/pdf-cli/certs', got %s", cfg.Signatures.TrustStore)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/lgbarn/pdf-cli/internal/cleanup"
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
 )
 
 const (
@@ -138,12 +139,12 @@ func CopyFile(src, dst string) (err error) {
 // ValidatePDFFile checks if a file exists and has a .pdf extension
 func ValidatePDFFile(path string) error {
 	if !FileExists(path) {
-		return fmt.Errorf("file not found: %s", path)
+		return fmt.Errorf("%w: %s", pdferrors.ErrFileNotFound, path)
 	}
 
 	ext := filepath.Ext(path)
 	if ext != ".pdf" && ext != ".PDF" {
-		return fmt.Errorf("not a PDF file: %s", path)
+		return fmt.Errorf("%w: %s", pdferrors.ErrNotPDF, path)
 	}
 
 	return nil
@@ -0,0 +1,208 @@
+package fileio
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/lgbarn/pdf-cli/internal/pdferrors"
+)
+
+// InputOptions controls how ExpandInputs finds the files of a batch.
+type InputOptions struct {
+	// Recursive processes the files below directory arguments; without it
+	// directories are an error.
+	Recursive bool
+	// Include keeps only the found files that match one of the patterns.
+	// Without patterns, files with the .pdf extension are kept.
+	Include []string
+	// Exclude drops the found files and skips the directories that match
+	// one of the patterns.
+	Exclude []string
+}
+
+// ExpandInputs returns the files named by args, in order. Directories are
+// walked with opts.Recursive, and arguments with glob characters that name no
+// existing file are expanded, which helps shells without globbing. Include
+// and exclude patterns filter the files found in directories and by glob
+// patterns, and each found file is added once; files named explicitly are
+// always kept as given.
+//
+// A pattern without a slash is matched against the file name, a pattern with
+// one against the path below the directory argument, or the path of a glob
+// match.
+func ExpandInputs(args []string, opts InputOptions) ([]string, error) {
+	for _, pattern := range slices.Concat(opts.Include, opts.Exclude) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	var files []string
+	seen := make(map[string]bool)
+	add := func(file string) {
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+
+	for _, arg := range args {
+		switch {
+		case IsDir(arg):
+			if !opts.Recursive {
+				return nil, fmt.Errorf("%s is a directory (use --recursive to process the files in it)", arg)
+			}
+			found, err := walkInputs(arg, opts)
+			if err != nil {
+				return nil, err
+			}
+			for _, file := range found {
+				add(file)
+			}
+		case !FileExists(arg) && hasGlobMeta(arg):
+			matches, err := filepath.Glob(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", arg, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("%w: no files match %s", pdferrors.ErrFileNotFound, arg)
+			}
+			for _, match := range matches {
+				if IsDir(match) {
+					if !opts.Recursive {
+						continue
+					}
+					found, err := walkInputs(match, opts)
+					if err != nil {
+						return nil, err
+					}
+					for _, file := range found {
+						add(file)
+					}
+				} else if opts.keeps(match, filepath.Base(match)) {
+					add(match)
+				}
+			}
+		default:
+			seen[arg] = true
+			files = append(files, arg)
+		}
+	}
+	return files, nil
+}
+
+// walkInputs returns the regular files below root kept by opts, in lexical
+// order.
+func walkInputs(root string, opts InputOptions) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if file == root {
+			return nil
+		}
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		if matchesAny(opts.Exclude, rel, d.Name()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() && opts.keeps(rel, d.Name()) {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", root, err)
+	}
+	return files, nil
+}
+
+// keeps reports whether a found file with the given path and name passes the
+// include and exclude patterns.
+func (opts InputOptions) keeps(file, name string) bool {
+	if matchesAny(opts.Exclude, file, name) {
+		return false
+	}
+	if len(opts.Include) == 0 {
+		return strings.EqualFold(filepath.Ext(name), ".pdf")
+	}
+	return matchesAny(opts.Include, file, name)
+}
+
+// matchesAny reports whether one of the patterns matches the file: patterns
+// with a slash match its path, others its name.
+func matchesAny(patterns []string, file, name string) bool {
+	for _, pattern := range patterns {
+		target := name
+		if strings.Contains(pattern, "/") {
+			target = filepath.ToSlash(file)
+		}
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hasGlobMeta reports whether path contains glob characters.
+func hasGlobMeta(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// ReadFileList reads input paths, one per line, from the file at path or
+// from stdin if path is "-". Empty lines and lines starting with # are
+// skipped, and surrounding whitespace is removed.
+func ReadFileList(path string) ([]string, error) {
+	f := os.Stdin
+	if !IsStdinInput(path) {
+		var err error
+		if f, err = os.Open(path); err != nil { // #nosec G304 -- path is a user-provided file list
+			return nil, fmt.Errorf("failed to open file list: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+	}
+
+	var files []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		files = append(files, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read file list: %w", err)
+	}
+	return files, nil
+}
+
+// MirrorPath returns the path of input below dir, so that the outputs of a
+// batch keep the directory structure of its inputs. Relative inputs keep
+// their path, absolute inputs below the working directory their path
+// relative to it, and other absolute inputs their full path without the
+// volume name.
+func MirrorPath(dir, input string) string {
+	rel := input
+	if filepath.IsAbs(input) {
+		rel = strings.TrimLeft(input[len(filepath.VolumeName(input)):], `/\`)
+		if wd, err := os.Getwd(); err == nil {
+			if r, err := filepath.Rel(wd, input); err == nil && filepath.IsLocal(r) {
+				rel = r
+			}
+		}
+	}
+	return filepath.Join(dir, rel)
+}
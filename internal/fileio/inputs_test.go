@@ -0,0 +1,120 @@
+package fileio
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// inputTree creates files below a temporary directory and changes into it.
+func inputTree(t *testing.T, files ...string) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	for _, f := range files {
+		if err := os.MkdirAll(filepath.Dir(f), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(f, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestExpandInputs(t *testing.T) {
+	inputTree(t,
+		"docs/a.pdf", "docs/B.PDF", "docs/notes.txt",
+		"docs/sub/c.pdf", "docs/sub/d.txt", "docs/archive/old.pdf",
+		"top.pdf",
+	)
+
+	tests := []struct {
+		name string
+		args []string
+		opts InputOptions
+		want []string
+	}{
+		{"files as given", []string{"top.pdf", "missing.pdf", "top.pdf"}, InputOptions{}, []string{"top.pdf", "missing.pdf", "top.pdf"}},
+		{"recursive", []string{"docs"}, InputOptions{Recursive: true},
+			[]string{"docs/B.PDF", "docs/a.pdf", "docs/archive/old.pdf", "docs/sub/c.pdf"}},
+		{"exclude directory", []string{"docs"}, InputOptions{Recursive: true, Exclude: []string{"archive"}},
+			[]string{"docs/B.PDF", "docs/a.pdf", "docs/sub/c.pdf"}},
+		{"include", []string{"docs"}, InputOptions{Recursive: true, Include: []string{"*.txt"}},
+			[]string{"docs/notes.txt", "docs/sub/d.txt"}},
+		{"include path", []string{"docs"}, InputOptions{Recursive: true, Include: []string{"sub/*"}},
+			[]string{"docs/sub/c.pdf", "docs/sub/d.txt"}},
+		{"glob", []string{"docs/a.pdf", "docs/*.pdf", "docs/*"}, InputOptions{}, []string{"docs/a.pdf", "docs/B.PDF"}},
+		{"explicit file kept", []string{"docs/notes.txt"}, InputOptions{Exclude: []string{"*.txt"}},
+			[]string{"docs/notes.txt"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandInputs(tt.args, tt.opts)
+			if err != nil {
+				t.Fatalf("ExpandInputs() error = %v", err)
+			}
+			want := make([]string, len(tt.want))
+			for i, w := range tt.want {
+				want[i] = filepath.FromSlash(w)
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("ExpandInputs(%v) = %v, want %v", tt.args, got, want)
+			}
+		})
+	}
+
+	for _, tt := range []struct {
+		name string
+		args []string
+		opts InputOptions
+	}{
+		{"directory without recursive", []string{"docs"}, InputOptions{}},
+		{"no glob match", []string{"*.doc"}, InputOptions{}},
+		{"invalid pattern", []string{"docs"}, InputOptions{Recursive: true, Include: []string{"["}}},
+	} {
+		if _, err := ExpandInputs(tt.args, tt.opts); err == nil {
+			t.Errorf("ExpandInputs() %s: expected error", tt.name)
+		}
+	}
+}
+
+func TestReadFileList(t *testing.T) {
+	inputTree(t)
+	list := "a.pdf\n\n# comment\n  docs/b.pdf \r\n"
+	if err := os.WriteFile("list.txt", []byte(list), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadFileList("list.txt")
+	if err != nil {
+		t.Fatalf("ReadFileList() error = %v", err)
+	}
+	if want := []string{"a.pdf", "docs/b.pdf"}; !slices.Equal(got, want) {
+		t.Errorf("ReadFileList() = %v, want %v", got, want)
+	}
+	if _, err := ReadFileList("missing.txt"); err == nil {
+		t.Error("ReadFileList() of a missing file: expected error")
+	}
+}
+
+func TestMirrorPath(t *testing.T) {
+	inputTree(t)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		input, want string
+	}{
+		{"a.pdf", "out/a.pdf"},
+		{"docs/sub/a.pdf", "out/docs/sub/a.pdf"},
+		{filepath.Join(wd, "docs", "a.pdf"), "out/docs/a.pdf"},
+		{filepath.Join(filepath.Dir(wd), "other", "a.pdf"), filepath.Join("out", filepath.Dir(wd), "other", "a.pdf")},
+	}
+	for _, tt := range tests {
+		if got := MirrorPath("out", tt.input); got != filepath.FromSlash(tt.want) {
+			t.Errorf("MirrorPath(out, %q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
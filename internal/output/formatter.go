@@ -6,6 +6,8 @@ import (
 	"io"
 	"os"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // OutputFormat represents the output format type.
@@ -16,6 +18,7 @@ const (
 	FormatJSON  OutputFormat = "json"
 	FormatCSV   OutputFormat = "csv"
 	FormatTSV   OutputFormat = "tsv"
+	FormatYAML  OutputFormat = "yaml"
 )
 
 // ParseOutputFormat parses a string into an OutputFormat.
@@ -27,6 +30,8 @@ func ParseOutputFormat(s string) OutputFormat {
 		return FormatCSV
 	case "tsv":
 		return FormatTSV
+	case "yaml", "yml":
+		return FormatYAML
 	default:
 		return FormatHuman
 	}
@@ -53,6 +58,8 @@ func (f *OutputFormatter) Print(data interface{}) error {
 	switch f.Format {
 	case FormatJSON:
 		return f.printJSON(data)
+	case FormatYAML:
+		return f.printYAML(data)
 	default:
 		return fmt.Errorf("unsupported format for Print: %s", f.Format)
 	}
@@ -65,6 +72,40 @@ func (f *OutputFormatter) printJSON(data interface{}) error {
 	return encoder.Encode(data)
 }
 
+// printYAML outputs data as YAML. Data is converted through JSON so that
+// json struct tags apply and field order is kept.
+func (f *OutputFormatter) printYAML(data interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+	return f.encodeYAML(&node)
+}
+
+// blockStyle clears the JSON flow and quoting styles so that the encoder
+// writes block-style YAML.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, n := range node.Content {
+		blockStyle(n)
+	}
+}
+
+// encodeYAML writes a YAML document for node.
+func (f *OutputFormatter) encodeYAML(node *yaml.Node) error {
+	encoder := yaml.NewEncoder(f.Writer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
 // PrintTable outputs tabular data in the configured format.
 func (f *OutputFormatter) PrintTable(headers []string, rows [][]string) error {
 	switch f.Format {
@@ -74,12 +115,14 @@ func (f *OutputFormatter) PrintTable(headers []string, rows [][]string) error {
 		return f.printTableCSV(headers, rows, ',')
 	case FormatTSV:
 		return f.printTableCSV(headers, rows, '\t')
+	case FormatYAML:
+		return f.printTableYAML(headers, rows)
 	default:
 		return f.printTableHuman(headers, rows)
 	}
 }
 
-// IsStructured returns true if the format is a structured format (JSON, CSV, TSV).
+// IsStructured returns true if the format is a structured format (JSON, CSV, TSV, YAML).
 func (f *OutputFormatter) IsStructured() bool {
-	return f.Format == FormatJSON || f.Format == FormatCSV || f.Format == FormatTSV
+	return f.Format == FormatJSON || f.Format == FormatCSV || f.Format == FormatTSV || f.Format == FormatYAML
 }
@@ -18,6 +18,8 @@ func TestParseOutputFormat(t *testing.T) {
 		{"CSV", FormatCSV},
 		{"tsv", FormatTSV},
 		{"TSV", FormatTSV},
+		{"yaml", FormatYAML},
+		{"yml", FormatYAML},
 		{"", FormatHuman},
 		{"invalid", FormatHuman},
 		{"xml", FormatHuman},
@@ -67,6 +69,7 @@ func TestOutputFormatterIsStructured(t *testing.T) {
 		{"json", true},
 		{"csv", true},
 		{"tsv", true},
+		{"yaml", true},
 		{"", false},
 		{"invalid", false},
 	}
@@ -92,6 +95,21 @@ func TestOutputFormatterPrint(t *testing.T) {
 		{"slice", []int{1, 2, 3}, "1"},
 	}
 
+	for _, tt := range tests {
+		t.Run(tt.name+" yaml", func(t *testing.T) {
+			var buf bytes.Buffer
+			formatter := NewOutputFormatter("yaml")
+			formatter.Writer = &buf
+
+			if err := formatter.Print(tt.data); err != nil {
+				t.Fatalf("Print() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.wantStr) {
+				t.Errorf("output = %q, want containing %q", buf.String(), tt.wantStr)
+			}
+		})
+	}
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var buf bytes.Buffer
@@ -130,6 +148,7 @@ func TestPrintTableFormats(t *testing.T) {
 		{"json", "", "["},
 		{"csv", "Name,Age", "Alice,30"},
 		{"tsv", "Name\tAge", "Alice\t30"},
+		{"yaml", "Name: Alice", "Age: \"30\""},
 		{"", "Name", "-"},
 	}
 
@@ -219,3 +238,23 @@ func TestOutputFormatConstants(t *testing.T) {
 		}
 	}
 }
+
+func TestOutputFormatterPrintYAMLBlockStyle(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewOutputFormatter("yaml")
+	formatter.Writer = &buf
+
+	data := struct {
+		Name  string   `json:"name"`
+		Flag  string   `json:"flag"`
+		Items []string `json:"items"`
+	}{"test", "true", []string{"a"}}
+	if err := formatter.Print(data); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+
+	want := "name: test\nflag: \"true\"\nitems:\n  - a\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/csv"
 	"fmt"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // printTableJSON outputs tabular data as a JSON array of objects.
@@ -23,6 +25,26 @@ func (f *OutputFormatter) printTableJSON(headers []string, rows [][]string) erro
 	return f.printJSON(result)
 }
 
+// printTableYAML outputs tabular data as a YAML sequence of mappings, keeping
+// the column order.
+func (f *OutputFormatter) printTableYAML(headers []string, rows [][]string) error {
+	seq := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, row := range rows {
+		m := &yaml.Node{Kind: yaml.MappingNode}
+		for i, header := range headers {
+			value := ""
+			if i < len(row) {
+				value = row[i]
+			}
+			m.Content = append(m.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: header},
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+		}
+		seq.Content = append(seq.Content, m)
+	}
+	return f.encodeYAML(seq)
+}
+
 // printTableCSV outputs tabular data as CSV or TSV.
 func (f *OutputFormatter) printTableCSV(headers []string, rows [][]string, delimiter rune) error {
 	w := csv.NewWriter(f.Writer)
@@ -202,3 +202,156 @@ func TestPageRangeStruct(t *testing.T) {
 		t.Errorf("PageRange = {%d, %d}, want {1, 5}", pr.Start, pr.End)
 	}
 }
+
+func TestParsePageSelection(t *testing.T) {
+	tests := []struct {
+		input   string
+		total   int
+		want    []int
+		wantErr bool
+	}{
+		{"1-3", 5, []int{1, 2, 3}, false},
+		{"3,1", 5, []int{3, 1}, false},
+		{"!1-3", 5, []int{4, 5}, false},
+		{"!2,!4", 5, []int{1, 3, 5}, false},
+		{"!2,4", 5, []int{4}, false},
+		{" ! 1 ", 3, []int{2, 3}, false},
+		{"", 5, nil, false},
+		{" , ", 5, nil, false},
+		{"1-3,2-4", 5, []int{1, 2, 3, 4}, false},
+		{"odd", 5, []int{1, 3, 5}, false},
+		{"even", 6, []int{2, 4, 6}, false},
+		{"ODD,Even", 4, []int{1, 3, 2, 4}, false},
+		{"end", 5, []int{5}, false},
+		{"z", 5, []int{5}, false},
+		{"end-2", 10, []int{8}, false},
+		{"end-2-end", 10, []int{8, 9, 10}, false},
+		{"3-end-1", 5, []int{3, 4}, false},
+		{"5-end", 7, []int{5, 6, 7}, false},
+		{"r1", 5, []int{5}, false},
+		{"r3", 5, []int{3}, false},
+		{"r3-z", 5, []int{3, 4, 5}, false},
+		{"z-1", 4, []int{4, 3, 2, 1}, false},
+		{"5-3", 5, []int{5, 4, 3}, false},
+		{"1-20:2", 20, []int{1, 3, 5, 7, 9, 11, 13, 15, 17, 19}, false},
+		{"1-10:3", 10, []int{1, 4, 7, 10}, false},
+		{"z-1:2", 5, []int{5, 3, 1}, false},
+		{"1-5:99", 5, []int{1}, false},
+		{"1 - 3 : 2", 5, []int{1, 3}, false},
+		{"1-10,!5", 10, []int{1, 2, 3, 4, 6, 7, 8, 9, 10}, false},
+		{"odd,!1", 5, []int{3, 5}, false},
+		{"!odd", 5, []int{2, 4}, false},
+		{"!r1", 3, []int{1, 2}, false},
+		{"even", 1, nil, true},
+		{"!1-5", 5, nil, true},
+		{"!odd,!even", 5, nil, true},
+		{"!", 5, nil, true},
+		{"!!1", 5, nil, true},
+		{"!6", 5, nil, true},
+		{"6", 5, nil, true},
+		{"0", 5, nil, true},
+		{"-1", 5, nil, true},
+		{"1-", 5, nil, true},
+		{"end-5", 5, nil, true},
+		{"r0", 5, nil, true},
+		{"r6", 5, nil, true},
+		{"r", 5, nil, true},
+		{"3:2", 5, nil, true},
+		{"1-5:0", 5, nil, true},
+		{"1-5:x", 5, nil, true},
+		{"1-2-3", 5, nil, true},
+		{"endx", 5, nil, true},
+		{"abc", 5, nil, true},
+		{"99999999999999999999", 5, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParsePageSelection(tt.input, tt.total)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvertPages(t *testing.T) {
+	tests := []struct {
+		pages []int
+		total int
+		want  []int
+	}{
+		{nil, 3, []int{1, 2, 3}},
+		{[]int{2}, 3, []int{1, 3}},
+		{[]int{3, 1, 1}, 4, []int{2, 4}},
+		{[]int{1, 2, 3}, 3, nil},
+	}
+
+	for _, tt := range tests {
+		got := InvertPages(tt.pages, tt.total)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("InvertPages(%v, %d) = %v, want %v", tt.pages, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestParseInvertedSelection(t *testing.T) {
+	tests := []struct {
+		input   string
+		total   int
+		want    []int
+		wantErr bool
+	}{
+		{"1-3", 5, []int{4, 5}, false},
+		{"1, 5-end", 6, []int{2, 3, 4}, false},
+		{"!1-3", 5, []int{1, 2, 3}, false},
+		{"1-3,!2", 5, []int{2, 4, 5}, false},
+		{"1-2,!2", 3, []int{2, 3}, false},
+		{"odd,!3", 5, []int{2, 3, 4}, false},
+		{"1-end", 3, nil, true},
+		{"", 3, nil, true},
+		{"9", 3, nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseInvertedSelection(tt.input, tt.total)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseInvertedSelection(%q, %d) error = %v, wantErr %v", tt.input, tt.total, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseInvertedSelection(%q, %d) = %v, want %v", tt.input, tt.total, got, tt.want)
+		}
+	}
+}
+
+func FuzzParsePageSelection(f *testing.F) {
+	for _, seed := range []string{"1-5", "odd", "even", "end-2", "r3", "1-20:2", "z-1", "!1-3", "1-10,!5", "end-2-end"} {
+		f.Add(seed, 20)
+	}
+
+	f.Fuzz(func(t *testing.T, input string, totalPages int) {
+		if totalPages < 1 || totalPages > 1000 {
+			return
+		}
+		pages, err := ParsePageSelection(input, totalPages)
+		if err != nil {
+			return
+		}
+
+		seen := make(map[int]bool, len(pages))
+		for _, p := range pages {
+			if p < 1 || p > totalPages {
+				t.Fatalf("ParsePageSelection(%q, %d) returned out-of-range page %d", input, totalPages, p)
+			}
+			if seen[p] {
+				t.Fatalf("ParsePageSelection(%q, %d) returned duplicate page %d", input, totalPages, p)
+			}
+			seen[p] = true
+		}
+	})
+}
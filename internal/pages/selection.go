@@ -0,0 +1,249 @@
+package pages
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParsePageSelection parses a page selection against a document with totalPages pages.
+//
+// A selection is a comma-separated list of terms. Each term is "odd", "even",
+// a page, or a range of two pages with an optional ":step". A page is a number,
+// "end" or "z" for the last page, "end-N" for N pages before the last, or "rN"
+// for the Nth page from the end. Ranges may be descending (z-1).
+//
+// Terms prefixed with "!" exclude pages. If a selection only has exclusions,
+// they are removed from all pages, so "!1-3" selects every page except 1-3.
+//
+// Pages are returned in selection order without duplicates. Returns nil for
+// an empty selection, and an error if any page is out of range or no page
+// remains selected.
+func ParsePageSelection(input string, totalPages int) ([]int, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, nil
+	}
+	if totalPages < 1 {
+		return nil, fmt.Errorf("invalid total pages: %d (must be >= 1)", totalPages)
+	}
+
+	var included, excluded []int
+	hasTerms, hasIncludes := false, false
+	for _, term := range strings.Split(input, ",") {
+		term = strings.ToLower(strings.TrimSpace(term))
+		if term == "" {
+			continue
+		}
+		hasTerms = true
+
+		exclude := false
+		if rest, ok := strings.CutPrefix(term, "!"); ok {
+			exclude = true
+			term = strings.TrimSpace(rest)
+			if term == "" {
+				return nil, fmt.Errorf("no pages after '!': use e.g. '!1-3'")
+			}
+			if strings.HasPrefix(term, "!") {
+				return nil, fmt.Errorf("invalid term '!%s': '!' may only be used once per term", term)
+			}
+		}
+
+		pages, err := parseSelectionTerm(term, totalPages)
+		if err != nil {
+			return nil, err
+		}
+		if exclude {
+			excluded = append(excluded, pages...)
+		} else {
+			hasIncludes = true
+			included = append(included, pages...)
+		}
+	}
+
+	if !hasTerms {
+		return nil, nil
+	}
+
+	var result []int
+	if hasIncludes {
+		skip := make(map[int]bool, len(excluded)+len(included))
+		for _, p := range excluded {
+			skip[p] = true
+		}
+		for _, p := range included {
+			if !skip[p] {
+				result = append(result, p)
+				skip[p] = true
+			}
+		}
+	} else {
+		result = InvertPages(excluded, totalPages)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("page selection %q selects no pages", input)
+	}
+	return result, nil
+}
+
+// ParseInvertedSelection returns the pages of a document with totalPages
+// pages that the selection input does not select, in ascending order. An
+// empty input selects all pages, so its inversion selects none.
+func ParseInvertedSelection(input string, totalPages int) ([]int, error) {
+	selected, err := ParsePageSelection(input, totalPages)
+	if err != nil {
+		return nil, err
+	}
+	var result []int
+	if selected != nil {
+		result = InvertPages(selected, totalPages)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("inverted page selection %q selects no pages", input)
+	}
+	return result, nil
+}
+
+// InvertPages returns the pages of a document with totalPages pages that are not in pages, in ascending order.
+func InvertPages(pages []int, totalPages int) []int {
+	excluded := make(map[int]bool, len(pages))
+	for _, p := range pages {
+		excluded[p] = true
+	}
+
+	var result []int
+	for p := 1; p <= totalPages; p++ {
+		if !excluded[p] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// parseSelectionTerm expands a single selection term without its "!" prefix.
+func parseSelectionTerm(term string, totalPages int) ([]int, error) {
+	switch term {
+	case "odd":
+		return generateStepRange(1, totalPages, 2), nil
+	case "even":
+		if totalPages < 2 {
+			return nil, nil
+		}
+		return generateStepRange(2, totalPages, 2), nil
+	}
+
+	body, stepStr, hasStep := strings.Cut(term, ":")
+	step := 1
+	if hasStep {
+		n, err := strconv.Atoi(strings.TrimSpace(stepStr))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid step in '%s': use a positive number (e.g., '1-20:2')", term)
+		}
+		step = n
+	}
+
+	start, rest, err := parseSelectionPage(strings.TrimSpace(body), totalPages)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page selection '%s': %w", term, err)
+	}
+
+	rest = strings.TrimSpace(rest)
+	if rest == "" {
+		if hasStep {
+			return nil, fmt.Errorf("invalid page selection '%s': a step needs a range (e.g., '1-20:2')", term)
+		}
+		if err := validatePageInRange(start, totalPages); err != nil {
+			return nil, err
+		}
+		return []int{start}, nil
+	}
+
+	rangeEnd, ok := strings.CutPrefix(rest, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid page selection '%s': unexpected '%s'", term, rest)
+	}
+	end, rest, err := parseSelectionPage(strings.TrimSpace(rangeEnd), totalPages)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page selection '%s': %w", term, err)
+	}
+	if rest = strings.TrimSpace(rest); rest != "" {
+		return nil, fmt.Errorf("invalid page selection '%s': unexpected '%s'", term, rest)
+	}
+
+	if err := validatePageInRange(start, totalPages); err != nil {
+		return nil, err
+	}
+	if err := validatePageInRange(end, totalPages); err != nil {
+		return nil, err
+	}
+	// Larger steps select only the first page of the range.
+	step = min(step, totalPages)
+	return generateStepRange(start, end, step), nil
+}
+
+// parseSelectionPage parses one page at the start of s and returns the remaining input.
+// "end-N" is read as a single page, so "end-2" is the page two before the last.
+func parseSelectionPage(s string, totalPages int) (int, string, error) {
+	switch {
+	case strings.HasPrefix(s, "end"):
+		rest := s[len("end"):]
+		if after, ok := strings.CutPrefix(rest, "-"); ok {
+			if n, tail := leadingNumber(after); tail != after {
+				return totalPages - n, tail, nil
+			}
+		}
+		return totalPages, rest, nil
+	case strings.HasPrefix(s, "z"):
+		return totalPages, s[1:], nil
+	case strings.HasPrefix(s, "r"):
+		n, tail := leadingNumber(s[1:])
+		if tail == s[1:] {
+			return 0, "", fmt.Errorf("'r' must be followed by a number (e.g., 'r3')")
+		}
+		if n < 1 {
+			return 0, "", fmt.Errorf("'r%d' is not a page: counting from the end starts at r1", n)
+		}
+		return totalPages - n + 1, tail, nil
+	}
+
+	n, tail := leadingNumber(s)
+	if tail == s {
+		return 0, "", fmt.Errorf("expected a page number, 'end', 'z' or 'rN' (valid: 1-%d)", totalPages)
+	}
+	return n, tail, nil
+}
+
+// leadingNumber parses the decimal digits at the start of s.
+// If s does not start with a digit, it returns 0 and s unchanged.
+func leadingNumber(s string) (int, string) {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i == 0 {
+		return 0, s
+	}
+	n, err := strconv.Atoi(s[:i])
+	if err != nil {
+		// Too many digits for an int: no document has that many pages.
+		return int(^uint(0) >> 1), s[i:]
+	}
+	return n, s[i:]
+}
+
+// generateStepRange creates page numbers from start to end (inclusive), taking every step-th page.
+// Supports both forward (1-5) and reverse (5-1) ranges.
+func generateStepRange(start, end, step int) []int {
+	if start <= end {
+		pages := make([]int, 0, (end-start)/step+1)
+		for p := start; p <= end; p += step {
+			pages = append(pages, p)
+		}
+		return pages
+	}
+	pages := make([]int, 0, (start-end)/step+1)
+	for p := start; p >= end; p -= step {
+		pages = append(pages, p)
+	}
+	return pages
+}
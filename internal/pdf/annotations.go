@@ -0,0 +1,234 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// AnnotationTypes lists the annotation types of ISO 32000.
+var AnnotationTypes = []string{
+	"Text", "Link", "FreeText", "Line", "Square", "Circle", "Polygon", "PolyLine",
+	"Highlight", "Underline", "Squiggly", "StrikeOut", "Stamp", "Caret", "Ink",
+	"Popup", "FileAttachment", "Sound", "Movie", "Widget", "Screen", "PrinterMark",
+	"TrapNet", "Watermark", "3D", "Redact", "Projection", "RichMedia",
+}
+
+// Annotation describes an annotation on a page.
+type Annotation struct {
+	Page     int    `json:"page"`
+	Type     string `json:"type"`
+	Author   string `json:"author,omitempty"`
+	Contents string `json:"contents,omitempty"`
+	Rect     Rect   `json:"rect"`
+}
+
+// AnnotationFilter selects annotations by type and page.
+type AnnotationFilter struct {
+	Types []string // annotation types as returned by AnnotationType (default: all)
+	Pages []int    // pages to search (default: all)
+}
+
+// AnnotationType returns the annotation type matching name, ignoring case.
+func AnnotationType(name string) (string, error) {
+	for _, t := range AnnotationTypes {
+		if strings.EqualFold(t, name) {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unknown annotation type %q", name)
+}
+
+// matches reports whether the filter selects annot.
+func (f AnnotationFilter) matches(annot types.Dict) bool {
+	return len(f.Types) == 0 || slices.Contains(f.Types, subtypeOf(annot))
+}
+
+// ListAnnotations returns the annotations of a PDF selected by filter, in
+// page order.
+func ListAnnotations(input string, filter AnnotationFilter, password string) ([]Annotation, error) {
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Annotation
+	for _, page := range selectedPages(filter.Pages, ctx.PageCount) {
+		d, _, _, err := ctx.PageDict(page, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read page %d: %w", page, err)
+		}
+		annots, err := ctx.DereferenceArray(d["Annots"])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read annotations of page %d: %w", page, err)
+		}
+		for _, a := range annots {
+			annot, err := ctx.DereferenceDict(a)
+			if err != nil || annot == nil || !filter.matches(annot) {
+				continue
+			}
+			result = append(result, annotationInfo(ctx, page, annot))
+		}
+	}
+	return result, nil
+}
+
+// RemoveAnnotations removes the annotations selected by filter, together with
+// their pop-up windows, and writes the result to output. Form field widgets
+// cannot be removed. It returns the number of annotations removed.
+func RemoveAnnotations(input, output string, filter AnnotationFilter, password string) (int, error) {
+	return editAnnotations(input, output, filter, false, password)
+}
+
+// FlattenAnnotations draws the annotations selected by filter into the page
+// content and removes them, together with their pop-up windows, and writes
+// the result to output. Annotations without an appearance stream are kept.
+// It returns the number of annotations flattened.
+func FlattenAnnotations(input, output string, filter AnnotationFilter, password string) (int, error) {
+	return editAnnotations(input, output, filter, true, password)
+}
+
+func editAnnotations(input, output string, filter AnnotationFilter, flatten bool, password string) (int, error) {
+	if slices.Contains(filter.Types, "Widget") {
+		return 0, fmt.Errorf("form field widgets cannot be removed or flattened here; use 'pdf form fill --flatten'")
+	}
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return 0, err
+	}
+
+	selected := func(annot types.Dict) bool {
+		if isWidget(annot) || !filter.matches(annot) {
+			return false
+		}
+		return !flatten || annotationAppearance(ctx, annot) != nil
+	}
+	// Pop-up windows go together with the annotation they belong to.
+	match := func(annot types.Dict) bool {
+		if subtypeOf(annot) == "Popup" {
+			if parent, err := ctx.DereferenceDict(annot["Parent"]); err == nil && parent != nil && selected(parent) {
+				return true
+			}
+		}
+		return selected(annot)
+	}
+	prefix := ""
+	if flatten {
+		prefix = "FlatAnnot"
+	}
+
+	count := 0
+	for _, page := range selectedPages(filter.Pages, ctx.PageCount) {
+		removed, err := removePageAnnotations(ctx, page, match, prefix)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update page %d: %w", page, err)
+		}
+		for _, annot := range removed {
+			// Pop-up windows only count when they were asked for.
+			if selected(annot) && (subtypeOf(annot) != "Popup" || slices.Contains(filter.Types, "Popup")) {
+				count++
+			}
+		}
+	}
+
+	if err := api.WriteContextFile(ctx, output); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// annotationInfo describes the annotation dictionary annot on page.
+func annotationInfo(ctx *model.Context, page int, annot types.Dict) Annotation {
+	a := Annotation{Page: page, Type: subtypeOf(annot)}
+	if v, found := annot.Find("T"); found {
+		a.Author, _ = ctx.DereferenceStringOrHexLiteral(v, model.V10, nil)
+	}
+	if v, found := annot.Find("Contents"); found {
+		a.Contents, _ = ctx.DereferenceStringOrHexLiteral(v, model.V10, nil)
+	}
+	if r, err := ctx.RectForArray(annot.ArrayEntry("Rect")); err == nil && r != nil {
+		a.Rect = toRect(r)
+	}
+	return a
+}
+
+// removePageAnnotations removes the annotations of a page for which match
+// returns true and returns them. With a prefix, their normal appearance
+// streams are drawn into the page content as XObjects named prefix1,
+// prefix2, ... first; hidden annotations are not drawn.
+func removePageAnnotations(ctx *model.Context, page int, match func(types.Dict) bool, prefix string) ([]types.Dict, error) {
+	d, _, inh, err := ctx.PageDict(page, false)
+	if err != nil {
+		return nil, err
+	}
+	annots, err := ctx.DereferenceArray(d["Annots"])
+	if err != nil || annots == nil {
+		return nil, err
+	}
+
+	// Names already used by the page are skipped, e.g. when flattening twice.
+	var used types.Dict
+	if inh != nil {
+		used, _ = ctx.DereferenceDict(inh.Resources["XObject"])
+	}
+	xobjects := types.Dict{}
+	var content bytes.Buffer
+	var kept types.Array
+	var removed []types.Dict
+	for _, a := range annots {
+		annot, err := ctx.DereferenceDict(a)
+		if err != nil || annot == nil || !match(annot) {
+			kept = append(kept, a)
+			continue
+		}
+		removed = append(removed, annot)
+		if prefix == "" {
+			continue
+		}
+		// Hidden (bit 2) and NoView (bit 6) annotations are not drawn.
+		if flags := annot.IntEntry("F"); flags != nil && *flags&(1<<1|1<<5) != 0 {
+			continue
+		}
+		ap := annotationAppearance(ctx, annot)
+		if ap == nil {
+			continue
+		}
+		cm, ok := appearanceMatrix(ctx, annot, *ap)
+		if !ok {
+			continue
+		}
+		name := ""
+		for n := len(xobjects) + 1; ; n++ {
+			name = fmt.Sprintf("%s%d", prefix, n)
+			_, taken := used[name]
+			if _, drawn := xobjects[name]; !taken && !drawn {
+				break
+			}
+		}
+		xobjects[name] = *ap
+		fmt.Fprintf(&content, "q %.4f %.4f %.4f %.4f %.4f %.4f cm /%s Do Q\n",
+			cm[0], cm[1], cm[2], cm[3], cm[4], cm[5], name)
+	}
+	if len(removed) == 0 {
+		return nil, nil
+	}
+
+	if len(kept) == 0 {
+		d.Delete("Annots")
+	} else {
+		d.Update("Annots", kept)
+	}
+	if len(xobjects) == 0 {
+		return removed, nil
+	}
+
+	if err := addPageXObjects(ctx, d, inh.Resources, xobjects); err != nil {
+		return nil, err
+	}
+	return removed, appendPageContent(ctx, d, content.Bytes())
+}
@@ -0,0 +1,159 @@
+package pdf
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// annotationsPDF returns the path to a three-page PDF with a comment (Text
+// annotation with pop-up) by "Reviewer", a highlight and a link on page 1, a
+// comment by "Alice" on page 2 and a link on page 3. The comments and the
+// highlight have appearance streams.
+func annotationsPDF() string {
+	return filepath.Join(testdataDir(), "annotations.pdf")
+}
+
+func annotationTypeCounts(t *testing.T, path string) map[string]int {
+	t.Helper()
+	annots, err := ListAnnotations(path, AnnotationFilter{}, "")
+	if err != nil {
+		t.Fatalf("ListAnnotations() error = %v", err)
+	}
+	counts := map[string]int{}
+	for _, a := range annots {
+		counts[a.Type]++
+	}
+	return counts
+}
+
+func TestListAnnotations(t *testing.T) {
+	annots, err := ListAnnotations(annotationsPDF(), AnnotationFilter{}, "")
+	if err != nil {
+		t.Fatalf("ListAnnotations() error = %v", err)
+	}
+	if len(annots) != 7 {
+		t.Fatalf("got %d annotations, want 7", len(annots))
+	}
+	first := annots[0]
+	want := Annotation{Page: 1, Type: "Text", Author: "Reviewer", Contents: "Please fix the totals", Rect: Rect{500, 700, 520, 720}}
+	if first != want {
+		t.Errorf("first annotation = %+v, want %+v", first, want)
+	}
+
+	annots, err = ListAnnotations(annotationsPDF(), AnnotationFilter{Types: []string{"Text"}, Pages: []int{2, 3}}, "")
+	if err != nil {
+		t.Fatalf("ListAnnotations() error = %v", err)
+	}
+	if len(annots) != 1 || annots[0].Author != "Alice" || annots[0].Page != 2 {
+		t.Errorf("filtered annotations = %+v", annots)
+	}
+}
+
+func TestAnnotationType(t *testing.T) {
+	if got, err := AnnotationType("highlight"); err != nil || got != "Highlight" {
+		t.Errorf("AnnotationType(highlight) = %q, %v", got, err)
+	}
+	if _, err := AnnotationType("Sticky"); err == nil {
+		t.Error("expected error for unknown type")
+	}
+}
+
+func TestRemoveAnnotations(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter AnnotationFilter
+		count  int
+		want   map[string]int
+	}{
+		{"all", AnnotationFilter{}, 5, map[string]int{}},
+		{"comments take their pop-ups", AnnotationFilter{Types: []string{"Text"}}, 2, map[string]int{"Highlight": 1, "Link": 2}},
+		{"types and pages", AnnotationFilter{Types: []string{"Link", "Highlight"}, Pages: []int{1}}, 2, map[string]int{"Text": 2, "Popup": 2, "Link": 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "out.pdf")
+			n, err := RemoveAnnotations(annotationsPDF(), output, tt.filter, "")
+			if err != nil {
+				t.Fatalf("RemoveAnnotations() error = %v", err)
+			}
+			if n != tt.count {
+				t.Errorf("removed %d annotations, want %d", n, tt.count)
+			}
+			got := annotationTypeCounts(t, output)
+			if len(got) != len(tt.want) {
+				t.Fatalf("remaining annotations = %v, want %v", got, tt.want)
+			}
+			for typ, c := range tt.want {
+				if got[typ] != c {
+					t.Errorf("remaining %s = %d, want %d", typ, got[typ], c)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveAnnotationsWidget(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "out.pdf")
+	if _, err := RemoveAnnotations(formPDF(), output, AnnotationFilter{Types: []string{"Widget"}}, ""); err == nil {
+		t.Error("expected error for widgets")
+	}
+
+	// Without types, form fields are kept.
+	if _, err := RemoveAnnotations(formPDF(), output, AnnotationFilter{}, ""); err != nil {
+		t.Fatalf("RemoveAnnotations() error = %v", err)
+	}
+	if got := len(fieldsByName(t, output)); got != 5 {
+		t.Errorf("got %d form fields, want 5", got)
+	}
+}
+
+func TestFlattenAnnotations(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "flat.pdf")
+	n, err := FlattenAnnotations(annotationsPDF(), output, AnnotationFilter{}, "")
+	if err != nil {
+		t.Fatalf("FlattenAnnotations() error = %v", err)
+	}
+	if n != 3 {
+		t.Errorf("flattened %d annotations, want 3", n)
+	}
+	// Links have no appearance and are kept.
+	if got := annotationTypeCounts(t, output); len(got) != 1 || got["Link"] != 2 {
+		t.Errorf("remaining annotations = %v", got)
+	}
+
+	ctx, err := readContext(output, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("PageDict() error = %v", err)
+	}
+	content, err := ctx.PageContent(page, 1)
+	if err != nil {
+		t.Fatalf("PageContent() error = %v", err)
+	}
+	for _, name := range []string{"/FlatAnnot1 Do", "/FlatAnnot2 Do"} {
+		if !strings.Contains(string(content), name) {
+			t.Errorf("page content does not contain %q:\n%s", name, content)
+		}
+	}
+
+	// Flattening again must not reuse the names of the first pass.
+	again := filepath.Join(t.TempDir(), "again.pdf")
+	if _, err := FlattenAnnotations(annotationsPDF(), again, AnnotationFilter{Types: []string{"Text"}}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FlattenAnnotations(again, output, AnnotationFilter{}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if ctx, err = readContext(output, ""); err != nil {
+		t.Fatal(err)
+	}
+	page, _, _, _ = ctx.PageDict(1, false)
+	content, _ = ctx.PageContent(page, 1)
+	if !strings.Contains(string(content), "/FlatAnnot2 Do") {
+		t.Errorf("second pass reused a name:\n%s", content)
+	}
+}
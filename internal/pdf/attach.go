@@ -0,0 +1,381 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// AttachmentRelationships lists the associated file relationships of
+// ISO 32000-2 that an attachment can have with the document.
+var AttachmentRelationships = []string{"Source", "Data", "Alternative", "Supplement", "Unspecified"}
+
+// Attachment describes a file embedded in a PDF.
+type Attachment struct {
+	Name         string     `json:"name"`
+	Size         int64      `json:"size"`
+	Description  string     `json:"description,omitempty"`
+	MIMEType     string     `json:"mimeType,omitempty"`
+	Relationship string     `json:"relationship,omitempty"`
+	Modified     *time.Time `json:"modified,omitempty"`
+	Data         []byte     `json:"-"` // set by ExtractAttachments only
+}
+
+// AttachmentFile describes a file to embed with AddAttachments.
+type AttachmentFile struct {
+	Path         string
+	Name         string // name in the PDF (default: the base name of Path)
+	Description  string
+	MIMEType     string // default: derived from the file extension
+	Relationship string // one of AttachmentRelationships, recorded as an associated file of the document
+}
+
+// embeddedFile is an entry of the EmbeddedFiles name tree.
+type embeddedFile struct {
+	key  string
+	ref  *types.IndirectRef
+	info Attachment
+}
+
+// ListAttachments returns the files embedded in a PDF.
+func ListAttachments(input, password string) ([]Attachment, error) {
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return nil, err
+	}
+	files, err := embeddedFiles(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Attachment, len(files))
+	for i, f := range files {
+		result[i] = f.info
+	}
+	return result, nil
+}
+
+// ExtractAttachments returns the named embedded files including their data,
+// or all of them if names is empty. Names match the attachment name or key.
+func ExtractAttachments(input string, names []string, password string) ([]Attachment, error) {
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return nil, err
+	}
+	files, err := embeddedFiles(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	if files, err = selectEmbeddedFiles(files, names); err != nil {
+		return nil, err
+	}
+	result := make([]Attachment, len(files))
+	for i, f := range files {
+		result[i] = f.info
+	}
+	return result, nil
+}
+
+// AddAttachments embeds files in a PDF and writes the result to output.
+// Adding a file under a name that is already attached is an error.
+func AddAttachments(input, output string, files []AttachmentFile, password string) error {
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return err
+	}
+	existing, err := embeddedFiles(ctx, false)
+	if err != nil {
+		return err
+	}
+	if err := ctx.LocateNameTree("EmbeddedFiles", true); err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		name := f.Name
+		if name == "" {
+			name = filepath.Base(f.Path)
+		}
+		if slices.ContainsFunc(existing, func(e embeddedFile) bool { return e.key == name || e.info.Name == name }) {
+			return fmt.Errorf("attachment %q already exists", name)
+		}
+		ref, err := addAttachment(ctx, name, f)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Path, err)
+		}
+		existing = append(existing, embeddedFile{key: name, ref: ref, info: Attachment{Name: name}})
+	}
+
+	return api.WriteContextFile(ctx, output)
+}
+
+// RemoveAttachments removes the named embedded files from a PDF, or all of
+// them if names is empty, and writes the result to output.
+func RemoveAttachments(input, output string, names []string, password string) error {
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return err
+	}
+	files, err := embeddedFiles(ctx, false)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("document has no attachments")
+	}
+	if files, err = selectEmbeddedFiles(files, names); err != nil {
+		return err
+	}
+
+	keys := make([]string, len(files))
+	removed := map[int]bool{}
+	for i, f := range files {
+		keys[i] = f.key
+		if f.ref != nil {
+			removed[f.ref.ObjectNumber.Value()] = true
+		}
+	}
+	if len(names) == 0 {
+		keys = nil
+	}
+	if _, err := ctx.RemoveAttachments(keys); err != nil {
+		return err
+	}
+	if err := removeAssociatedFiles(ctx, removed); err != nil {
+		return err
+	}
+
+	return api.WriteContextFile(ctx, output)
+}
+
+// AttachmentFileName returns a safe file name for extracting an attachment:
+// the base name of the attachment without any directory components.
+func AttachmentFileName(name string) string {
+	base := filepath.Base(api.SanitizePath(strings.ReplaceAll(name, "\\", "/")))
+	if base == "" || base == "." || base == ".." || base == string(filepath.Separator) {
+		return "attachment"
+	}
+	return base
+}
+
+// embeddedFiles returns the entries of the EmbeddedFiles name tree sorted by
+// key. With data set, the file contents are decoded as well.
+func embeddedFiles(ctx *model.Context, data bool) ([]embeddedFile, error) {
+	if err := ctx.LocateNameTree("EmbeddedFiles", false); err != nil {
+		return nil, err
+	}
+	tree := ctx.Names["EmbeddedFiles"]
+	if tree == nil {
+		return nil, nil
+	}
+
+	var files []embeddedFile
+	err := tree.Process(ctx.XRefTable, func(xRefTable *model.XRefTable, key string, o *types.Object) error {
+		f, err := embeddedFileInfo(ctx, key, *o, data)
+		if err != nil {
+			return fmt.Errorf("attachment %q: %w", key, err)
+		}
+		files = append(files, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(files, func(a, b embeddedFile) int { return strings.Compare(a.key, b.key) })
+	return files, nil
+}
+
+// embeddedFileInfo reads the file specification o of an embedded file.
+func embeddedFileInfo(ctx *model.Context, key string, o types.Object, data bool) (embeddedFile, error) {
+	f := embeddedFile{key: key, info: Attachment{Name: key}}
+	if ir, ok := o.(types.IndirectRef); ok {
+		f.ref = &ir
+	}
+
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return f, err
+	}
+	for _, k := range []string{"UF", "F"} {
+		if v, found := d.Find(k); found {
+			if s, err := ctx.DereferenceStringOrHexLiteral(v, model.V10, nil); err == nil && s != "" {
+				f.info.Name = s
+				break
+			}
+		}
+	}
+	if v, found := d.Find("Desc"); found {
+		f.info.Description, _ = ctx.DereferenceStringOrHexLiteral(v, model.V10, nil)
+	}
+	if n := d.NameEntry("AFRelationship"); n != nil {
+		f.info.Relationship = *n
+	}
+
+	ef := d.DictEntry("EF")
+	if ef == nil {
+		return f, nil
+	}
+	v, found := ef.Find("F")
+	if !found {
+		if v, found = ef.Find("UF"); !found {
+			return f, nil
+		}
+	}
+	sd, _, err := ctx.DereferenceStreamDict(v)
+	if err != nil || sd == nil {
+		return f, err
+	}
+	if n := sd.NameEntry("Subtype"); n != nil {
+		f.info.MIMEType = *n
+	}
+	if params := sd.DictEntry("Params"); params != nil {
+		if size := params.IntEntry("Size"); size != nil {
+			f.info.Size = int64(*size)
+		}
+		if s := params.StringEntry("ModDate"); s != nil {
+			if t, ok := types.DateTime(strings.TrimSpace(*s), true); ok {
+				f.info.Modified = &t
+			}
+		}
+	}
+
+	if data || f.info.Size == 0 {
+		if err := sd.Decode(); err != nil {
+			return f, err
+		}
+		f.info.Size = int64(len(sd.Content))
+		if data {
+			f.info.Data = sd.Content
+		}
+	}
+	return f, nil
+}
+
+// selectEmbeddedFiles returns the files matching names by name or key, or
+// all files if names is empty. A name that matches nothing is an error.
+func selectEmbeddedFiles(files []embeddedFile, names []string) ([]embeddedFile, error) {
+	if len(names) == 0 {
+		return files, nil
+	}
+	var selected []embeddedFile
+	for _, name := range names {
+		i := slices.IndexFunc(files, func(f embeddedFile) bool { return f.key == name })
+		if i < 0 {
+			i = slices.IndexFunc(files, func(f embeddedFile) bool { return f.info.Name == name })
+		}
+		if i < 0 {
+			return nil, fmt.Errorf("attachment %q not found", name)
+		}
+		if !slices.ContainsFunc(selected, func(f embeddedFile) bool { return f.key == files[i].key }) {
+			selected = append(selected, files[i])
+		}
+	}
+	return selected, nil
+}
+
+// addAttachment embeds the file f under name and returns the reference to
+// its file specification.
+func addAttachment(ctx *model.Context, name string, f AttachmentFile) (*types.IndirectRef, error) {
+	path := filepath.Clean(f.Path)
+	content, err := os.ReadFile(path) // #nosec G304 -- path is cleaned
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	modTime := time.Now()
+	if fi, err := os.Stat(path); err == nil {
+		modTime = fi.ModTime()
+	}
+
+	a := model.Attachment{Reader: bytes.NewReader(content), ID: name, FileName: name, Desc: f.Description, ModTime: &modTime}
+	d, err := ctx.NewFileSpecDictForAttachment(a)
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType := f.MIMEType
+	if mimeType == "" {
+		mimeType, _, _ = strings.Cut(mime.TypeByExtension(filepath.Ext(name)), ";")
+	}
+	if mimeType != "" {
+		if sd, _, err := ctx.DereferenceStreamDict(d.DictEntry("EF")["F"]); err == nil && sd != nil {
+			sd.InsertName("Subtype", mimeType)
+		}
+	}
+	if f.Relationship != "" {
+		if !slices.Contains(AttachmentRelationships, f.Relationship) {
+			return nil, fmt.Errorf("invalid relationship %q: use %s", f.Relationship, strings.Join(AttachmentRelationships, ", "))
+		}
+		d.InsertName("AFRelationship", f.Relationship)
+	}
+
+	ref, err := ctx.IndRefForNewObject(d)
+	if err != nil {
+		return nil, err
+	}
+	m := model.NameMap{name: []types.Dict{d}}
+	if err := ctx.Names["EmbeddedFiles"].Add(ctx.XRefTable, name, *ref, m, []string{"F", "UF"}); err != nil {
+		return nil, err
+	}
+
+	if f.Relationship != "" {
+		if err := addAssociatedFile(ctx, *ref); err != nil {
+			return nil, err
+		}
+	}
+	return ref, nil
+}
+
+// addAssociatedFile appends the file specification ref to the associated
+// files (AF) of the document, as hybrid invoice formats like ZUGFeRD require.
+func addAssociatedFile(ctx *model.Context, ref types.IndirectRef) error {
+	root, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+	var af types.Array
+	if o, found := root.Find("AF"); found {
+		if af, err = ctx.DereferenceArray(o); err != nil {
+			return err
+		}
+	}
+	root.Update("AF", append(af, ref))
+	return nil
+}
+
+// removeAssociatedFiles drops the file specifications with the given object
+// numbers from the associated files of the document.
+func removeAssociatedFiles(ctx *model.Context, removed map[int]bool) error {
+	root, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+	o, found := root.Find("AF")
+	if !found {
+		return nil
+	}
+	af, err := ctx.DereferenceArray(o)
+	if err != nil {
+		return err
+	}
+	kept := types.Array{}
+	for _, e := range af {
+		if ir, ok := e.(types.IndirectRef); ok && removed[ir.ObjectNumber.Value()] {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	if len(kept) == 0 {
+		root.Delete("AF")
+	} else {
+		root.Update("AF", kept)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package pdf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// attachedPDF returns a copy of the sample PDF with an XML invoice attached
+// as an associated file and a text file with a description.
+func attachedPDF(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	xml := filepath.Join(dir, "factur-x.xml")
+	notes := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(xml, []byte("<invoice/>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(notes, []byte("hello"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(dir, "attached.pdf")
+	files := []AttachmentFile{
+		{Path: xml, Relationship: "Alternative"},
+		{Path: notes, Description: "Review notes"},
+	}
+	if err := AddAttachments(samplePDF(), output, files, ""); err != nil {
+		t.Fatalf("AddAttachments() error = %v", err)
+	}
+	return output
+}
+
+func TestListAttachments(t *testing.T) {
+	attachments, err := ListAttachments(attachedPDF(t), "")
+	if err != nil {
+		t.Fatalf("ListAttachments() error = %v", err)
+	}
+	if len(attachments) != 2 {
+		t.Fatalf("got %d attachments, want 2", len(attachments))
+	}
+	xml, notes := attachments[0], attachments[1]
+	if xml.Name != "factur-x.xml" || xml.Size != 10 || xml.MIMEType != "text/xml" || xml.Relationship != "Alternative" {
+		t.Errorf("xml attachment = %+v", xml)
+	}
+	if notes.Name != "notes.txt" || notes.Size != 5 || notes.Description != "Review notes" || notes.Modified == nil {
+		t.Errorf("notes attachment = %+v", notes)
+	}
+}
+
+func TestListAttachmentsNone(t *testing.T) {
+	attachments, err := ListAttachments(samplePDF(), "")
+	if err != nil {
+		t.Fatalf("ListAttachments() error = %v", err)
+	}
+	if len(attachments) != 0 {
+		t.Errorf("got %d attachments, want none", len(attachments))
+	}
+}
+
+func TestAddAttachmentsDuplicate(t *testing.T) {
+	input := attachedPDF(t)
+	notes := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(notes, []byte("again"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err := AddAttachments(input, filepath.Join(t.TempDir(), "out.pdf"), []AttachmentFile{{Path: notes}}, "")
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected duplicate error, got %v", err)
+	}
+}
+
+func TestExtractAttachments(t *testing.T) {
+	input := attachedPDF(t)
+
+	attachments, err := ExtractAttachments(input, []string{"notes.txt"}, "")
+	if err != nil {
+		t.Fatalf("ExtractAttachments() error = %v", err)
+	}
+	if len(attachments) != 1 || string(attachments[0].Data) != "hello" {
+		t.Errorf("ExtractAttachments() = %+v", attachments)
+	}
+
+	if _, err := ExtractAttachments(input, []string{"missing.txt"}, ""); err == nil {
+		t.Error("expected error for unknown attachment")
+	}
+}
+
+func TestRemoveAttachments(t *testing.T) {
+	input := attachedPDF(t)
+	output := filepath.Join(t.TempDir(), "removed.pdf")
+	if err := RemoveAttachments(input, output, []string{"factur-x.xml"}, ""); err != nil {
+		t.Fatalf("RemoveAttachments() error = %v", err)
+	}
+
+	attachments, err := ListAttachments(output, "")
+	if err != nil {
+		t.Fatalf("ListAttachments() error = %v", err)
+	}
+	if len(attachments) != 1 || attachments[0].Name != "notes.txt" {
+		t.Errorf("remaining attachments = %+v", attachments)
+	}
+
+	ctx, err := readContext(output, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := ctx.Catalog()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := root.Find("AF"); found {
+		t.Error("associated files still reference the removed attachment")
+	}
+
+	if err := RemoveAttachments(samplePDF(), output, nil, ""); err == nil {
+		t.Error("expected error for a PDF without attachments")
+	}
+}
+
+func TestAttachmentFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"invoice.xml", "invoice.xml"},
+		{"data/invoice.xml", "invoice.xml"},
+		{"../../etc/passwd", "passwd"},
+		{`C:\temp\notes.txt`, "notes.txt"},
+		{"", "attachment"},
+		{"..", "attachment"},
+	}
+	for _, tt := range tests {
+		if got := AttachmentFileName(tt.name); got != tt.want {
+			t.Errorf("AttachmentFileName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
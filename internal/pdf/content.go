@@ -0,0 +1,323 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// tokenKind identifies the type of a content stream token.
+type tokenKind int
+
+const (
+	tokNumber      tokenKind = iota // integer or real
+	tokName                         // /Name, text without the slash
+	tokString                       // literal or hex string, text holds the decoded bytes
+	tokArrayStart                   // [
+	tokArrayEnd                     // ]
+	tokDictStart                    // <<
+	tokDictEnd                      // >>
+	tokKeyword                      // true, false or null
+	tokOperator                     // any other bare word
+	tokInlineImage                  // a complete BI ... ID ... EI sequence
+)
+
+// contentToken is a lexical token of a content stream or CMap.
+// start and end are byte offsets into the lexed data.
+type contentToken struct {
+	kind  tokenKind
+	text  string
+	start int
+	end   int
+}
+
+// contentOp is an operator together with its operands.
+// start and end span the operands and the operator in the lexed data.
+type contentOp struct {
+	name     string
+	operands []contentToken
+	start    int
+	end      int
+}
+
+// number returns the value of a numeric operand, or 0 if it is not a number.
+func (t contentToken) number() float64 {
+	if t.kind != tokNumber {
+		return 0
+	}
+	v, _ := strconv.ParseFloat(t.text, 64)
+	return v
+}
+
+// isContentSpace reports whether c is PDF white space.
+func isContentSpace(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+// isContentDelimiter reports whether c is a PDF delimiter character.
+func isContentDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+// lexContent splits a content stream or CMap into tokens.
+func lexContent(data []byte) ([]contentToken, error) {
+	var tokens []contentToken
+	i := 0
+	for i < len(data) {
+		c := data[i]
+		switch {
+		case isContentSpace(c):
+			i++
+			continue
+		case c == '%':
+			for i < len(data) && data[i] != '\n' && data[i] != '\r' {
+				i++
+			}
+			continue
+		}
+
+		start := i
+		switch c {
+		case '(':
+			s, end, err := lexLiteralString(data, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, contentToken{tokString, s, start, end})
+			i = end
+		case '<':
+			if i+1 < len(data) && data[i+1] == '<' {
+				tokens = append(tokens, contentToken{tokDictStart, "<<", start, i + 2})
+				i += 2
+				continue
+			}
+			end := bytes.IndexByte(data[i:], '>')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated hex string at offset %d", start)
+			}
+			end += i + 1
+			tokens = append(tokens, contentToken{tokString, decodeHexString(data[i+1 : end-1]), start, end})
+			i = end
+		case '>':
+			if i+1 < len(data) && data[i+1] == '>' {
+				tokens = append(tokens, contentToken{tokDictEnd, ">>", start, i + 2})
+				i += 2
+				continue
+			}
+			return nil, fmt.Errorf("unexpected '>' at offset %d", start)
+		case '[':
+			tokens = append(tokens, contentToken{tokArrayStart, "[", start, i + 1})
+			i++
+		case ']':
+			tokens = append(tokens, contentToken{tokArrayEnd, "]", start, i + 1})
+			i++
+		case '{', '}', ')':
+			tokens = append(tokens, contentToken{tokOperator, string(c), start, i + 1})
+			i++
+		case '/':
+			i++
+			for i < len(data) && !isContentSpace(data[i]) && !isContentDelimiter(data[i]) {
+				i++
+			}
+			tokens = append(tokens, contentToken{tokName, string(data[start+1 : i]), start, i})
+		default:
+			for i < len(data) && !isContentSpace(data[i]) && !isContentDelimiter(data[i]) {
+				i++
+			}
+			word := string(data[start:i])
+			switch {
+			case word == "true" || word == "false" || word == "null":
+				tokens = append(tokens, contentToken{tokKeyword, word, start, i})
+			case isNumber(word):
+				tokens = append(tokens, contentToken{tokNumber, word, start, i})
+			case word == "BI":
+				end, err := skipInlineImage(data, i)
+				if err != nil {
+					return nil, err
+				}
+				tokens = append(tokens, contentToken{tokInlineImage, "BI", start, end})
+				i = end
+			default:
+				tokens = append(tokens, contentToken{tokOperator, word, start, i})
+			}
+		}
+	}
+	return tokens, nil
+}
+
+// parseContentOps groups the tokens of a content stream into operations.
+func parseContentOps(data []byte) ([]contentOp, error) {
+	tokens, err := lexContent(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []contentOp
+	var operands []contentToken
+	for _, t := range tokens {
+		if t.kind != tokOperator && t.kind != tokInlineImage {
+			operands = append(operands, t)
+			continue
+		}
+		op := contentOp{name: t.text, operands: operands, start: t.start, end: t.end}
+		if len(operands) > 0 {
+			op.start = operands[0].start
+		}
+		ops = append(ops, op)
+		operands = nil
+	}
+	return ops, nil
+}
+
+// isNumber reports whether word is a PDF numeric object: an optional sign
+// followed by digits with at most one decimal point.
+func isNumber(word string) bool {
+	if word != "" && (word[0] == '+' || word[0] == '-') {
+		word = word[1:]
+	}
+	digits, dots := 0, 0
+	for i := 0; i < len(word); i++ {
+		switch c := word[i]; {
+		case c >= '0' && c <= '9':
+			digits++
+		case c == '.':
+			dots++
+		default:
+			return false
+		}
+	}
+	return digits > 0 && dots <= 1
+}
+
+// lexLiteralString decodes the literal string starting with '(' at data[start]
+// and returns it together with the offset after the closing parenthesis.
+func lexLiteralString(data []byte, start int) (string, int, error) {
+	var b []byte
+	depth := 0
+	for i := start; i < len(data); i++ {
+		c := data[i]
+		switch c {
+		case '(':
+			if depth > 0 {
+				b = append(b, c)
+			}
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return string(b), i + 1, nil
+			}
+			b = append(b, c)
+		case '\\':
+			i++
+			if i >= len(data) {
+				break
+			}
+			switch e := data[i]; e {
+			case 'n':
+				b = append(b, '\n')
+			case 'r':
+				b = append(b, '\r')
+			case 't':
+				b = append(b, '\t')
+			case 'b':
+				b = append(b, '\b')
+			case 'f':
+				b = append(b, '\f')
+			case '\r':
+				// Line continuation, also swallow a following LF.
+				if i+1 < len(data) && data[i+1] == '\n' {
+					i++
+				}
+			case '\n':
+				// Line continuation.
+			default:
+				if e >= '0' && e <= '7' {
+					v := 0
+					n := 0
+					for n < 3 && i < len(data) && data[i] >= '0' && data[i] <= '7' {
+						v = v*8 + int(data[i]-'0')
+						i++
+						n++
+					}
+					i--
+					b = append(b, byte(v))
+				} else {
+					b = append(b, e)
+				}
+			}
+		default:
+			b = append(b, c)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string at offset %d", start)
+}
+
+// decodeHexString decodes the contents of a hex string, ignoring white space.
+// An odd number of digits is padded with a trailing zero.
+func decodeHexString(hex []byte) string {
+	var b []byte
+	var hi byte
+	odd := false
+	for _, c := range hex {
+		var v byte
+		switch {
+		case c >= '0' && c <= '9':
+			v = c - '0'
+		case c >= 'a' && c <= 'f':
+			v = c - 'a' + 10
+		case c >= 'A' && c <= 'F':
+			v = c - 'A' + 10
+		default:
+			continue
+		}
+		if odd {
+			b = append(b, hi<<4|v)
+		} else {
+			hi = v
+		}
+		odd = !odd
+	}
+	if odd {
+		b = append(b, hi<<4)
+	}
+	return string(b)
+}
+
+// skipInlineImage returns the offset after the EI operator ending the inline
+// image whose BI operator ends at data[i].
+func skipInlineImage(data []byte, i int) (int, error) {
+	id := bytes.Index(data[i:], []byte("ID"))
+	if id < 0 {
+		return 0, fmt.Errorf("inline image without ID at offset %d", i)
+	}
+	// Image data starts after the single white space following ID.
+	pos := i + id + 3
+	for pos < len(data) {
+		ei := bytes.Index(data[pos:], []byte("EI"))
+		if ei < 0 {
+			break
+		}
+		ei += pos
+		before := ei == 0 || isContentSpace(data[ei-1])
+		after := ei+2 == len(data) || isContentSpace(data[ei+2]) || isContentDelimiter(data[ei+2])
+		if before && after {
+			return ei + 2, nil
+		}
+		pos = ei + 2
+	}
+	return 0, fmt.Errorf("inline image without EI at offset %d", i)
+}
+
+// encodeHexString formats b as a PDF hex string.
+func encodeHexString(b []byte) string {
+	return fmt.Sprintf("<%X>", b)
+}
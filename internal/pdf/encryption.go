@@ -1,7 +1,12 @@
 package pdf
 
 import (
+	"bytes"
+	"fmt"
+	"strings"
+
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 )
 
@@ -21,3 +26,16 @@ func Encrypt(input, output, userPW, ownerPW string) error {
 func Decrypt(input, output, password string) error {
 	return api.DecryptFile(input, output, NewConfig(password))
 }
+
+// CheckPassword reads the PDF data with password and returns the read
+// error, nil if the password opens it. Taking the file content lets
+// callers try many passwords without reading the file again.
+func CheckPassword(data []byte, password string) error {
+	_, err := api.ReadContext(bytes.NewReader(data), NewConfig(password))
+	if err != nil && strings.HasPrefix(err.Error(), "precis:") {
+		// AES-256 passwords are normalized with SASLprep, which rejects
+		// some characters; such a password cannot open the file.
+		return fmt.Errorf("%w: %v", pdfcpu.ErrWrongPassword, err)
+	}
+	return err
+}
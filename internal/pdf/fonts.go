@@ -0,0 +1,530 @@
+package pdf
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Default font metrics in glyph space units, used when a font does not define them.
+const (
+	defaultAscent  = 800
+	defaultDescent = -200
+	defaultCIDW    = 1000
+)
+
+// fontInfo holds what is needed to decode and measure the text shown with a font.
+type fontInfo struct {
+	composite  bool              // Type0 font with multi-byte codes
+	codespace  []codespaceRange  // code lengths of a composite font
+	toUnicode  *cmap             // optional ToUnicode mapping
+	encoding   map[byte]rune     // simple fonts: code to Unicode
+	widths     map[int]float64   // code (simple) or CID (composite) to width in glyph space
+	missing    float64           // width of codes without an entry
+	widthScale float64           // glyph space to text space scale times 1000 (Type3 fonts)
+	ascent     float64           // in glyph space units
+	descent    float64           // in glyph space units
+	coreName   string            // standard 14 font used for missing widths
+	cache      map[string]string // decoded codes
+}
+
+// codespaceRange is one range of valid character codes of a CMap.
+type codespaceRange struct {
+	low  []byte
+	high []byte
+}
+
+// cmap maps character codes to Unicode text.
+type cmap struct {
+	codespace []codespaceRange
+	chars     map[string]string
+}
+
+// splitCodes splits the bytes of a shown string into character codes.
+func (f *fontInfo) splitCodes(s string) []string {
+	if !f.composite {
+		codes := make([]string, len(s))
+		for i := 0; i < len(s); i++ {
+			codes[i] = s[i : i+1]
+		}
+		return codes
+	}
+
+	var codes []string
+	for i := 0; i < len(s); {
+		n := codeLength(f.codespace, s[i:])
+		if i+n > len(s) {
+			n = len(s) - i
+		}
+		codes = append(codes, s[i:i+n])
+		i += n
+	}
+	return codes
+}
+
+// codeLength returns the length of the code at the start of s.
+// Without a matching range, composite fonts default to two-byte codes.
+func codeLength(ranges []codespaceRange, s string) int {
+	for _, r := range ranges {
+		n := len(r.low)
+		if n == 0 || n > len(s) || len(r.high) != n {
+			continue
+		}
+		match := true
+		for i := 0; i < n; i++ {
+			if s[i] < r.low[i] || s[i] > r.high[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return n
+		}
+	}
+	return 2
+}
+
+// decode returns the Unicode text of a character code.
+func (f *fontInfo) decode(code string) string {
+	if text, ok := f.cache[code]; ok {
+		return text
+	}
+
+	text := ""
+	if f.toUnicode != nil {
+		text = f.toUnicode.chars[code]
+	}
+	if text == "" && !f.composite && len(code) == 1 {
+		if r, ok := f.encoding[code[0]]; ok {
+			text = string(r)
+		} else {
+			text = string(winAnsiRune(code[0]))
+		}
+	}
+	f.cache[code] = text
+	return text
+}
+
+// width returns the width of a character code in glyph space units (1/1000 em).
+func (f *fontInfo) width(code string) float64 {
+	key := 0
+	for i := 0; i < len(code); i++ {
+		key = key<<8 | int(code[i])
+	}
+
+	w, ok := f.widths[key]
+	switch {
+	case ok:
+	case f.coreName != "" && len(code) == 1:
+		w = float64(font.CharWidth(f.coreName, rune(code[0])))
+	default:
+		w = f.missing
+	}
+	return w * f.widthScale
+}
+
+// isSingleByteSpace reports whether code is the single-byte code 32, the only
+// code word spacing applies to.
+func isSingleByteSpace(code string) bool {
+	return len(code) == 1 && code[0] == ' '
+}
+
+// loadFont builds the font information for a font dictionary.
+func loadFont(ctx *model.Context, o types.Object) *fontInfo {
+	f := &fontInfo{
+		widths:     map[int]float64{},
+		widthScale: 1,
+		ascent:     defaultAscent,
+		descent:    defaultDescent,
+		cache:      map[string]string{},
+	}
+
+	if ctx == nil || o == nil {
+		return f
+	}
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return f
+	}
+
+	if tu, found := d.Find("ToUnicode"); found {
+		f.toUnicode = loadCMap(ctx, tu)
+	}
+
+	subtype := ""
+	if st := d.Subtype(); st != nil {
+		subtype = *st
+	}
+	baseFont := ""
+	if bf := d.NameEntry("BaseFont"); bf != nil {
+		baseFont = *bf
+		if i := strings.Index(baseFont, "+"); i >= 0 {
+			baseFont = baseFont[i+1:]
+		}
+	}
+
+	if subtype == "Type0" {
+		f.composite = true
+		loadCompositeFont(ctx, f, d)
+		return f
+	}
+
+	loadSimpleEncoding(ctx, f, d)
+	loadSimpleWidths(ctx, f, d)
+	if font.IsCoreFont(baseFont) {
+		f.coreName = baseFont
+		if bb := font.BoundingBox(baseFont); bb != nil {
+			f.ascent, f.descent = bb.UR.Y, bb.LL.Y
+		}
+	}
+	if subtype == "Type3" {
+		if m, err := ctx.DereferenceArray(d["FontMatrix"]); err == nil && len(m) == 6 {
+			if v, err := ctx.DereferenceNumber(m[0]); err == nil {
+				f.widthScale = v * 1000
+			}
+		}
+	}
+	loadFontDescriptor(ctx, f, d)
+	return f
+}
+
+// loadCompositeFont reads the encoding and CID widths of a Type0 font.
+func loadCompositeFont(ctx *model.Context, f *fontInfo, d types.Dict) {
+	if enc, found := d.Find("Encoding"); found {
+		if m := loadCMap(ctx, enc); m != nil {
+			f.codespace = m.codespace
+		}
+	}
+
+	f.missing = defaultCIDW
+	descendants, err := ctx.DereferenceArray(d["DescendantFonts"])
+	if err != nil || len(descendants) == 0 {
+		return
+	}
+	cid, err := ctx.DereferenceDict(descendants[0])
+	if err != nil || cid == nil {
+		return
+	}
+	if dw, err := ctx.DereferenceNumber(cid["DW"]); err == nil && cid["DW"] != nil {
+		f.missing = dw
+	}
+	loadFontDescriptor(ctx, f, cid)
+
+	w, err := ctx.DereferenceArray(cid["W"])
+	if err != nil {
+		return
+	}
+	// W holds "c [w1 w2 ...]" and "cfirst clast w" entries.
+	for i := 0; i+1 < len(w); {
+		first, err := ctx.DereferenceNumber(w[i])
+		if err != nil {
+			return
+		}
+		if arr, err := ctx.DereferenceArray(w[i+1]); err == nil && arr != nil {
+			for j, o := range arr {
+				if v, err := ctx.DereferenceNumber(o); err == nil {
+					f.widths[int(first)+j] = v
+				}
+			}
+			i += 2
+			continue
+		}
+		if i+2 >= len(w) {
+			return
+		}
+		last, err1 := ctx.DereferenceNumber(w[i+1])
+		v, err2 := ctx.DereferenceNumber(w[i+2])
+		if err1 != nil || err2 != nil {
+			return
+		}
+		for c := int(first); c <= int(last) && c-int(first) < 0x10000; c++ {
+			f.widths[c] = v
+		}
+		i += 3
+	}
+}
+
+// loadSimpleEncoding reads the Encoding of a simple font, including Differences.
+func loadSimpleEncoding(ctx *model.Context, f *fontInfo, d types.Dict) {
+	o, found := d.Find("Encoding")
+	if !found {
+		return
+	}
+	o, err := ctx.Dereference(o)
+	if err != nil {
+		return
+	}
+
+	f.encoding = map[byte]rune{}
+	base := ""
+	var differences types.Array
+	switch enc := o.(type) {
+	case types.Name:
+		base = enc.Value()
+	case types.Dict:
+		if n := enc.NameEntry("BaseEncoding"); n != nil {
+			base = *n
+		}
+		differences, _ = ctx.DereferenceArray(enc["Differences"])
+	}
+
+	if base == "MacRomanEncoding" {
+		for code, r := range macRomanHigh {
+			f.encoding[code] = r
+		}
+	}
+
+	code := 0
+	for _, item := range differences {
+		item, _ = ctx.Dereference(item)
+		switch v := item.(type) {
+		case types.Integer:
+			code = v.Value()
+		case types.Float:
+			code = int(v.Value())
+		case types.Name:
+			if r, ok := glyphNameToRune(v.Value()); ok && code >= 0 && code < 256 {
+				f.encoding[byte(code)] = r
+			}
+			code++
+		}
+	}
+}
+
+// loadSimpleWidths reads the Widths array of a simple font.
+func loadSimpleWidths(ctx *model.Context, f *fontInfo, d types.Dict) {
+	widths, err := ctx.DereferenceArray(d["Widths"])
+	if err != nil || widths == nil {
+		return
+	}
+	first := 0
+	if fc, err := ctx.DereferenceNumber(d["FirstChar"]); err == nil && d["FirstChar"] != nil {
+		first = int(fc)
+	}
+	for i, o := range widths {
+		if v, err := ctx.DereferenceNumber(o); err == nil {
+			f.widths[first+i] = v
+		}
+	}
+}
+
+// loadFontDescriptor reads ascent, descent and missing width from a font descriptor.
+func loadFontDescriptor(ctx *model.Context, f *fontInfo, d types.Dict) {
+	fd, err := ctx.DereferenceDict(d["FontDescriptor"])
+	if err != nil || fd == nil {
+		return
+	}
+	if v, err := ctx.DereferenceNumber(fd["Ascent"]); err == nil && fd["Ascent"] != nil && v > 0 {
+		f.ascent = v
+	}
+	if v, err := ctx.DereferenceNumber(fd["Descent"]); err == nil && fd["Descent"] != nil && v < 0 {
+		f.descent = v
+	}
+	if v, err := ctx.DereferenceNumber(fd["MissingWidth"]); err == nil && fd["MissingWidth"] != nil && !f.composite {
+		f.missing = v
+	}
+}
+
+// loadCMap reads a CMap stream. Predefined CMaps given by name only provide
+// their code length: Identity and the other standard CMaps use two bytes.
+func loadCMap(ctx *model.Context, o types.Object) *cmap {
+	o, err := ctx.Dereference(o)
+	if err != nil || o == nil {
+		return nil
+	}
+	if _, ok := o.(types.Name); ok {
+		return &cmap{codespace: []codespaceRange{{low: []byte{0, 0}, high: []byte{0xFF, 0xFF}}}}
+	}
+
+	sd, _, err := ctx.DereferenceStreamDict(o)
+	if err != nil || sd == nil {
+		return nil
+	}
+	if err := sd.Decode(); err != nil {
+		return nil
+	}
+	return parseCMap(sd.Content)
+}
+
+// parseCMap reads the code space ranges and Unicode mappings of a CMap.
+func parseCMap(data []byte) *cmap {
+	tokens, err := lexContent(data)
+	if err != nil {
+		return nil
+	}
+
+	m := &cmap{chars: map[string]string{}}
+	section := ""
+	var operands []contentToken
+	for _, t := range tokens {
+		if t.kind != tokOperator {
+			operands = append(operands, t)
+			continue
+		}
+		switch t.text {
+		case "begincodespacerange", "beginbfchar", "beginbfrange", "begincidrange", "begincidchar":
+			section = t.text
+		case "endcodespacerange":
+			for i := 0; i+1 < len(operands); i += 2 {
+				m.codespace = append(m.codespace, codespaceRange{low: []byte(operands[i].text), high: []byte(operands[i+1].text)})
+			}
+			section = ""
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				if operands[i].kind == tokString && operands[i+1].kind == tokString {
+					m.chars[operands[i].text] = decodeUTF16BE(operands[i+1].text)
+				}
+			}
+			section = ""
+		case "endbfrange":
+			parseBFRanges(m, operands)
+			section = ""
+		default:
+			if section == "" {
+				operands = operands[:0]
+			}
+			continue
+		}
+		operands = operands[:0]
+	}
+	return m
+}
+
+// parseBFRanges adds the mappings of a bfrange section to m.
+// Each range is "<lo> <hi> <dst>" or "<lo> <hi> [<dst1> <dst2> ...]".
+func parseBFRanges(m *cmap, operands []contentToken) {
+	for i := 0; i+2 < len(operands); {
+		lo, hi := []byte(operands[i].text), []byte(operands[i+1].text)
+		if len(lo) == 0 || len(lo) != len(hi) || len(lo) > 4 {
+			return
+		}
+		start, end := bytesToInt(lo), bytesToInt(hi)
+		if end < start || end-start > 0xFFFF {
+			return
+		}
+
+		if operands[i+2].kind == tokArrayStart {
+			j := i + 3
+			for c := start; j < len(operands) && operands[j].kind == tokString; c++ {
+				m.chars[string(intToBytes(c, len(lo)))] = decodeUTF16BE(operands[j].text)
+				j++
+			}
+			i = j + 1 // skip ]
+			continue
+		}
+
+		dst := []rune(decodeUTF16BE(operands[i+2].text))
+		for c := start; c <= end && len(dst) > 0; c++ {
+			m.chars[string(intToBytes(c, len(lo)))] = string(dst)
+			dst[len(dst)-1]++
+		}
+		i += 3
+	}
+}
+
+// bytesToInt interprets b as a big-endian unsigned integer.
+func bytesToInt(b []byte) int {
+	v := 0
+	for _, c := range b {
+		v = v<<8 | int(c)
+	}
+	return v
+}
+
+// intToBytes encodes v as a big-endian unsigned integer of n bytes.
+func intToBytes(v, n int) []byte {
+	b := make([]byte, n)
+	for i := n - 1; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+	return b
+}
+
+// decodeUTF16BE decodes UTF-16BE text as used in ToUnicode CMaps.
+func decodeUTF16BE(s string) string {
+	if len(s)%2 != 0 {
+		return s
+	}
+	units := make([]uint16, len(s)/2)
+	for i := range units {
+		units[i] = uint16(s[2*i])<<8 | uint16(s[2*i+1])
+	}
+	return string(utf16.Decode(units))
+}
+
+// winAnsiRune maps a WinAnsiEncoding code to Unicode. Codes outside
+// 0x80-0x9F match Latin-1.
+func winAnsiRune(c byte) rune {
+	if r, ok := winAnsiHigh[c]; ok {
+		return r
+	}
+	return rune(c)
+}
+
+// glyphNameToRune maps a glyph name from an encoding's Differences to Unicode.
+// It understands single letters, uniXXXX and uXXXX names, and common punctuation.
+func glyphNameToRune(name string) (rune, bool) {
+	if i := strings.IndexByte(name, '.'); i > 0 {
+		name = name[:i]
+	}
+	if len(name) == 1 {
+		return rune(name[0]), true
+	}
+	if r, ok := glyphNames[name]; ok {
+		return r, true
+	}
+	if hex, ok := strings.CutPrefix(name, "uni"); ok && len(hex) >= 4 {
+		if v, err := strconv.ParseUint(hex[:4], 16, 32); err == nil {
+			return rune(v), true
+		}
+	}
+	if hex, ok := strings.CutPrefix(name, "u"); ok && len(hex) >= 4 && len(hex) <= 6 {
+		if v, err := strconv.ParseUint(hex, 16, 32); err == nil {
+			return rune(v), true
+		}
+	}
+	return 0, false
+}
+
+// winAnsiHigh lists the WinAnsiEncoding codes that differ from Latin-1.
+var winAnsiHigh = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡',
+	0x88: 'ˆ', 0x89: '‰', 0x8A: 'Š', 0x8B: '‹', 0x8C: 'Œ', 0x8E: 'Ž',
+	0x91: '‘', 0x92: '’', 0x93: '“', 0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—',
+	0x98: '˜', 0x99: '™', 0x9A: 'š', 0x9B: '›', 0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
+
+// macRomanHigh lists the most common MacRomanEncoding codes above 0x7F.
+var macRomanHigh = map[byte]rune{
+	0x80: 'Ä', 0x81: 'Å', 0x82: 'Ç', 0x83: 'É', 0x84: 'Ñ', 0x85: 'Ö', 0x86: 'Ü',
+	0x87: 'á', 0x88: 'à', 0x89: 'â', 0x8A: 'ä', 0x8B: 'ã', 0x8C: 'å', 0x8D: 'ç',
+	0x8E: 'é', 0x8F: 'è', 0x90: 'ê', 0x91: 'ë', 0x92: 'í', 0x93: 'ì', 0x94: 'î',
+	0x95: 'ï', 0x96: 'ñ', 0x97: 'ó', 0x98: 'ò', 0x99: 'ô', 0x9A: 'ö', 0x9B: 'õ',
+	0x9C: 'ú', 0x9D: 'ù', 0x9E: 'û', 0x9F: 'ü', 0xA5: '•', 0xA7: 'ß', 0xA9: '©',
+	0xAE: 'Æ', 0xAF: 'Ø', 0xBE: 'æ', 0xBF: 'ø', 0xC9: '…', 0xCA: ' ',
+	0xD0: '–', 0xD1: '—', 0xD2: '“', 0xD3: '”', 0xD4: '‘', 0xD5: '’',
+}
+
+// glyphNames maps common glyph names to Unicode.
+var glyphNames = map[string]rune{
+	"space": ' ', "exclam": '!', "quotedbl": '"', "numbersign": '#', "dollar": '$',
+	"percent": '%', "ampersand": '&', "quotesingle": '\'', "quoteright": '’',
+	"parenleft": '(', "parenright": ')', "asterisk": '*', "plus": '+', "comma": ',',
+	"hyphen": '-', "minus": '−', "period": '.', "slash": '/', "colon": ':',
+	"semicolon": ';', "less": '<', "equal": '=', "greater": '>', "question": '?',
+	"at": '@', "bracketleft": '[', "backslash": '\\', "bracketright": ']',
+	"asciicircum": '^', "underscore": '_', "grave": '`', "quoteleft": '‘',
+	"braceleft": '{', "bar": '|', "braceright": '}', "asciitilde": '~',
+	"zero": '0', "one": '1', "two": '2', "three": '3', "four": '4',
+	"five": '5', "six": '6', "seven": '7', "eight": '8', "nine": '9',
+	"endash": '–', "emdash": '—', "bullet": '•', "ellipsis": '…',
+	"quotedblleft": '“', "quotedblright": '”', "quotesinglbase": '‚',
+	"quotedblbase": '„', "fi": 'ﬁ', "fl": 'ﬂ', "section": '§', "paragraph": '¶',
+	"copyright": '©', "registered": '®', "trademark": '™', "degree": '°',
+	"nbspace": ' ', "Euro": '€', "sterling": '£', "yen": '¥', "cent": '¢',
+}
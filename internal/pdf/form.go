@@ -0,0 +1,416 @@
+package pdf
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/form"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Form field types reported by ListFormFields.
+const (
+	FieldText     = "text"
+	FieldDate     = "date"
+	FieldCheckBox = "checkbox"
+	FieldRadio    = "radio"
+	FieldComboBox = "combobox"
+	FieldListBox  = "listbox"
+)
+
+// FormField describes an interactive form field.
+type FormField struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Value   string   `json:"value"`            // for list boxes, the selected values joined with ", "
+	Values  []string `json:"values,omitempty"` // selected values of a list box
+	Options []string `json:"options,omitempty"`
+	Pages   []int    `json:"pages"`
+	Locked  bool     `json:"locked"`
+}
+
+// FillFormOptions controls FillForm.
+type FillFormOptions struct {
+	Flatten bool // merge the field appearances into the page content and remove the form
+}
+
+// ListFormFields returns the fields of the interactive form of a PDF.
+// A document without a form has no fields.
+func ListFormFields(input, password string) ([]FormField, error) {
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return nil, err
+	}
+	f, err := exportForm(ctx, input)
+	if err != nil || f == nil {
+		return nil, err
+	}
+	return formFields(f), nil
+}
+
+// FillForm sets the form fields named in values and writes the result to
+// output. Check boxes take true/false, yes/no or on/off; radio buttons, combo
+// boxes and list boxes take one of their options. List boxes may take several,
+// also as a single value separated by semicolons. Fields not in values keep
+// their value; naming a field that does not exist is an error.
+func FillForm(input, output string, values map[string][]string, opts FillFormOptions, password string) error {
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return err
+	}
+	f, err := exportForm(ctx, input)
+	if err != nil {
+		return err
+	}
+	if f == nil {
+		return fmt.Errorf("document has no form fields")
+	}
+
+	if len(values) > 0 {
+		fill, err := fillData(f, values)
+		if err != nil {
+			return err
+		}
+		if _, _, err := form.FillForm(ctx, form.FillDetails(fill, nil), nil, form.JSON); err != nil {
+			return err
+		}
+	}
+
+	if opts.Flatten {
+		if err := flattenForm(ctx); err != nil {
+			return err
+		}
+	}
+
+	return api.WriteContextFile(ctx, output)
+}
+
+// exportForm returns the form of the document, or nil if it has none.
+func exportForm(ctx *model.Context, source string) (*form.Form, error) {
+	if ctx.Form == nil {
+		return nil, nil
+	}
+	if _, found := ctx.Form.Find("Fields"); !found {
+		return nil, nil
+	}
+	fg, ok, err := form.ExportForm(ctx.XRefTable, source)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || len(fg.Forms) == 0 {
+		return nil, nil
+	}
+	return &fg.Forms[0], nil
+}
+
+// formFields converts an exported form to a list of fields in form order.
+func formFields(f *form.Form) []FormField {
+	var fields []FormField
+	for _, tf := range f.TextFields {
+		fields = append(fields, FormField{Name: tf.Name, Type: FieldText, Value: tf.Value, Pages: tf.Pages, Locked: tf.Locked})
+	}
+	for _, df := range f.DateFields {
+		fields = append(fields, FormField{Name: df.Name, Type: FieldDate, Value: df.Value, Pages: df.Pages, Locked: df.Locked})
+	}
+	for _, cb := range f.CheckBoxes {
+		fields = append(fields, FormField{Name: cb.Name, Type: FieldCheckBox, Value: strconv.FormatBool(cb.Value), Pages: cb.Pages, Locked: cb.Locked})
+	}
+	for _, rb := range f.RadioButtonGroups {
+		fields = append(fields, FormField{Name: rb.Name, Type: FieldRadio, Value: rb.Value, Options: rb.Options, Pages: rb.Pages, Locked: rb.Locked})
+	}
+	for _, cb := range f.ComboBoxes {
+		fields = append(fields, FormField{Name: cb.Name, Type: FieldComboBox, Value: cb.Value, Options: cb.Options, Pages: cb.Pages, Locked: cb.Locked})
+	}
+	for _, lb := range f.ListBoxes {
+		fields = append(fields, FormField{
+			Name: lb.Name, Type: FieldListBox, Value: strings.Join(lb.Values, ", "), Values: lb.Values,
+			Options: lb.Options, Pages: lb.Pages, Locked: lb.Locked,
+		})
+	}
+	return fields
+}
+
+// fillData returns a form holding only the fields of f named in values, set
+// to their new values.
+func fillData(f *form.Form, values map[string][]string) (*form.Form, error) {
+	fill := &form.Form{}
+	used := map[string]bool{}
+	value := func(name string) ([]string, bool) {
+		v, ok := values[name]
+		if ok {
+			used[name] = true
+		}
+		return v, ok
+	}
+
+	for _, tf := range f.TextFields {
+		if v, ok := value(tf.Name); ok {
+			c := *tf
+			c.Value = strings.Join(v, "\n")
+			fill.TextFields = append(fill.TextFields, &c)
+		}
+	}
+	for _, df := range f.DateFields {
+		if v, ok := value(df.Name); ok {
+			c := *df
+			c.Value = strings.Join(v, "")
+			fill.DateFields = append(fill.DateFields, &c)
+		}
+	}
+	for _, cb := range f.CheckBoxes {
+		if v, ok := value(cb.Name); ok {
+			checked, err := parseCheckBox(cb.Name, v)
+			if err != nil {
+				return nil, err
+			}
+			c := *cb
+			c.Value = checked
+			fill.CheckBoxes = append(fill.CheckBoxes, &c)
+		}
+	}
+	for _, rb := range f.RadioButtonGroups {
+		if v, ok := value(rb.Name); ok {
+			choice, err := singleOption(rb.Name, v, rb.Options, false)
+			if err != nil {
+				return nil, err
+			}
+			c := *rb
+			c.Value = choice
+			fill.RadioButtonGroups = append(fill.RadioButtonGroups, &c)
+		}
+	}
+	for _, cb := range f.ComboBoxes {
+		if v, ok := value(cb.Name); ok {
+			choice, err := singleOption(cb.Name, v, cb.Options, cb.Editable)
+			if err != nil {
+				return nil, err
+			}
+			c := *cb
+			c.Value = choice
+			fill.ComboBoxes = append(fill.ComboBoxes, &c)
+		}
+	}
+	for _, lb := range f.ListBoxes {
+		if v, ok := value(lb.Name); ok {
+			v = listBoxValues(v)
+			if len(v) > 1 && !lb.Multi {
+				return nil, fmt.Errorf("field %q takes a single value", lb.Name)
+			}
+			for _, s := range v {
+				if !slices.Contains(lb.Options, s) {
+					return nil, fmt.Errorf("invalid value %q for field %q: options are %s", s, lb.Name, strings.Join(lb.Options, ", "))
+				}
+			}
+			c := *lb
+			c.Values = v
+			fill.ListBoxes = append(fill.ListBoxes, &c)
+		}
+	}
+
+	for name := range values {
+		if !used[name] {
+			return nil, fmt.Errorf("unknown form field %q", name)
+		}
+	}
+	return fill, nil
+}
+
+// listBoxValues splits a single semicolon-separated value into list box values.
+func listBoxValues(v []string) []string {
+	if len(v) != 1 {
+		return v
+	}
+	var values []string
+	for _, s := range strings.Split(v[0], ";") {
+		if s = strings.TrimSpace(s); s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// parseCheckBox returns the state of a check box given as true/false, yes/no or on/off.
+func parseCheckBox(name string, v []string) (bool, error) {
+	if len(v) == 1 {
+		switch strings.ToLower(strings.TrimSpace(v[0])) {
+		case "true", "yes", "on", "1", "x":
+			return true, nil
+		case "false", "no", "off", "0", "":
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("invalid value %q for check box %q: use true or false", strings.Join(v, ", "), name)
+}
+
+// singleOption returns the single value v, which must be one of options
+// unless the field is editable. An empty value clears the selection.
+func singleOption(name string, v, options []string, editable bool) (string, error) {
+	if len(v) > 1 {
+		return "", fmt.Errorf("field %q takes a single value", name)
+	}
+	s := strings.Join(v, "")
+	if s == "" || editable || slices.Contains(options, s) {
+		return s, nil
+	}
+	return "", fmt.Errorf("invalid value %q for field %q: options are %s", s, name, strings.Join(options, ", "))
+}
+
+// flattenForm draws the appearance of every visible form field into its
+// page content, then removes the widgets and the interactive form. Fields
+// without an appearance are dropped.
+func flattenForm(ctx *model.Context) error {
+	for page := 1; page <= ctx.PageCount; page++ {
+		if _, err := removePageAnnotations(ctx, page, isWidget, "FlatField"); err != nil {
+			return fmt.Errorf("failed to flatten page %d: %w", page, err)
+		}
+	}
+	if root, err := ctx.Catalog(); err == nil {
+		root.Delete("AcroForm")
+	}
+	return nil
+}
+
+// isWidget reports whether annot is the widget annotation of a form field.
+func isWidget(annot types.Dict) bool {
+	return subtypeOf(annot) == "Widget"
+}
+
+// annotationAppearance returns the normal appearance stream of an annotation
+// in its current state.
+func annotationAppearance(ctx *model.Context, annot types.Dict) *types.IndirectRef {
+	ap, err := ctx.DereferenceDict(annot["AP"])
+	if err != nil || ap == nil {
+		return nil
+	}
+	n, found := ap.Find("N")
+	if !found {
+		return nil
+	}
+	if ir, ok := n.(types.IndirectRef); ok {
+		if o, err := ctx.Dereference(ir); err == nil {
+			if _, isStream := o.(types.StreamDict); isStream {
+				return &ir
+			}
+		}
+	}
+	// Check boxes and radio buttons have one appearance per state.
+	states, err := ctx.DereferenceDict(n)
+	if err != nil || states == nil {
+		return nil
+	}
+	as := annot.NameEntry("AS")
+	if as == nil {
+		return nil
+	}
+	if ir, ok := states[*as].(types.IndirectRef); ok {
+		return &ir
+	}
+	return nil
+}
+
+// appearanceMatrix returns the matrix that maps the appearance stream ap onto
+// the annotation rectangle, as described in ISO 32000-1, 12.5.5.
+func appearanceMatrix(ctx *model.Context, annot types.Dict, ap types.IndirectRef) (matrix, bool) {
+	sd, _, err := ctx.DereferenceStreamDict(ap)
+	if err != nil || sd == nil {
+		return matrix{}, false
+	}
+	sd.InsertName("Type", "XObject")
+	sd.InsertName("Subtype", "Form")
+
+	rect, err := ctx.RectForArray(annot.ArrayEntry("Rect"))
+	if err != nil || rect == nil {
+		return matrix{}, false
+	}
+	bbox, err := ctx.RectForArray(sd.ArrayEntry("BBox"))
+	if err != nil || bbox == nil {
+		return matrix{}, false
+	}
+	m := identity
+	if a := sd.ArrayEntry("Matrix"); len(a) == 6 {
+		for i, o := range a {
+			v, err := ctx.DereferenceNumber(o)
+			if err != nil {
+				return matrix{}, false
+			}
+			m[i] = v
+		}
+	}
+
+	box := transformedBounds(m, bbox.LL.X, bbox.LL.Y, bbox.UR.X, bbox.UR.Y)
+	if box.Width() == 0 || box.Height() == 0 {
+		return matrix{}, false
+	}
+	sx := rect.Width() / box.Width()
+	sy := rect.Height() / box.Height()
+	return matrix{sx, 0, 0, sy, rect.LL.X - sx*box.LLX, rect.LL.Y - sy*box.LLY}, true
+}
+
+// addPageXObjects adds XObjects to the resources of a page. The inherited
+// resources are copied into the page so that other pages are not affected.
+func addPageXObjects(ctx *model.Context, page, inherited types.Dict, xobjects types.Dict) error {
+	res := types.Dict{}
+	for k, v := range inherited {
+		res[k] = v
+	}
+	xo := types.Dict{}
+	if old, err := ctx.DereferenceDict(res["XObject"]); err == nil {
+		for k, v := range old {
+			xo[k] = v
+		}
+	}
+	for k, v := range xobjects {
+		for {
+			if _, taken := xo[k]; !taken {
+				break
+			}
+			k += "_"
+		}
+		xo[k] = v
+	}
+	res["XObject"] = xo
+	page["Resources"] = res
+	return nil
+}
+
+// appendPageContent draws content on top of the existing page content. The
+// existing content is wrapped in q/Q so that its graphics state does not leak.
+func appendPageContent(ctx *model.Context, page types.Dict, content []byte) error {
+	var streams types.Array
+	switch c := page["Contents"].(type) {
+	case types.IndirectRef:
+		if o, err := ctx.Dereference(c); err == nil {
+			if a, ok := o.(types.Array); ok {
+				streams = append(streams, a...)
+				break
+			}
+		}
+		streams = append(streams, c)
+	case types.Array:
+		streams = append(streams, c...)
+	}
+
+	var refs types.Array
+	for _, data := range [][]byte{[]byte("q\n"), append([]byte("Q\n"), content...)} {
+		sd, err := ctx.NewStreamDictForBuf(data)
+		if err != nil {
+			return err
+		}
+		if err := sd.Encode(); err != nil {
+			return err
+		}
+		ir, err := ctx.IndRefForNewObject(*sd)
+		if err != nil {
+			return err
+		}
+		refs = append(refs, *ir)
+	}
+
+	contents := append(types.Array{refs[0]}, streams...)
+	page.Update("Contents", append(contents, refs[1]))
+	return nil
+}
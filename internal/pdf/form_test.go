@@ -0,0 +1,178 @@
+package pdf
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// formPDF returns the path to a one-page PDF with a text field "name", a
+// check box "agree", a radio button group "size" (S, M, L), a combo box "city"
+// (London, Paris) and a multi-select list box "langs" (Go, C, Rust).
+func formPDF() string {
+	return filepath.Join(testdataDir(), "form.pdf")
+}
+
+func fieldsByName(t *testing.T, path string) map[string]FormField {
+	t.Helper()
+	fields, err := ListFormFields(path, "")
+	if err != nil {
+		t.Fatalf("ListFormFields() error = %v", err)
+	}
+	m := map[string]FormField{}
+	for _, f := range fields {
+		m[f.Name] = f
+	}
+	return m
+}
+
+func TestListFormFields(t *testing.T) {
+	fields := fieldsByName(t, formPDF())
+
+	want := map[string]string{
+		"name":  FieldText,
+		"agree": FieldCheckBox,
+		"size":  FieldRadio,
+		"city":  FieldComboBox,
+		"langs": FieldListBox,
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("got %d fields, want %d", len(fields), len(want))
+	}
+	for name, typ := range want {
+		f, ok := fields[name]
+		if !ok {
+			t.Errorf("field %q missing", name)
+			continue
+		}
+		if f.Type != typ || !slices.Equal(f.Pages, []int{1}) {
+			t.Errorf("field %q = %+v, want type %s on page 1", name, f, typ)
+		}
+	}
+	if got := fields["city"]; got.Value != "London" || !slices.Equal(got.Options, []string{"London", "Paris"}) {
+		t.Errorf("city = %+v", got)
+	}
+}
+
+func TestListFormFieldsNoForm(t *testing.T) {
+	fields, err := ListFormFields(samplePDF(), "")
+	if err != nil {
+		t.Fatalf("ListFormFields() error = %v", err)
+	}
+	if len(fields) != 0 {
+		t.Errorf("got %d fields, want none", len(fields))
+	}
+}
+
+func TestFillForm(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "filled.pdf")
+	values := map[string][]string{
+		"name":  {"Jane Doe"},
+		"agree": {"yes"},
+		"size":  {"M"},
+		"city":  {"Paris"},
+		"langs": {"Go;Rust"},
+	}
+	if err := FillForm(formPDF(), output, values, FillFormOptions{}, ""); err != nil {
+		t.Fatalf("FillForm() error = %v", err)
+	}
+
+	fields := fieldsByName(t, output)
+	for name, want := range map[string]string{
+		"name":  "Jane Doe",
+		"agree": "true",
+		"size":  "M",
+		"city":  "Paris",
+		"langs": "Go, Rust",
+	} {
+		if got := fields[name].Value; got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestFillFormInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string][]string
+		wantErr string
+	}{
+		{"unknown field", map[string][]string{"nmae": {"x"}}, "unknown form field"},
+		{"invalid option", map[string][]string{"city": {"Rome"}}, "options are London, Paris"},
+		{"invalid check box", map[string][]string{"agree": {"maybe"}}, "check box"},
+		{"several radio values", map[string][]string{"size": {"S", "M"}}, "single value"},
+		{"invalid list value", map[string][]string{"langs": {"Go", "Java"}}, "Java"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "filled.pdf")
+			err := FillForm(formPDF(), output, tt.values, FillFormOptions{}, "")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("FillForm() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFillFormNoForm(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "filled.pdf")
+	if err := FillForm(samplePDF(), output, map[string][]string{"name": {"x"}}, FillFormOptions{}, ""); err == nil {
+		t.Error("FillForm() on a PDF without a form should fail")
+	}
+}
+
+func TestFillFormFlatten(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "flat.pdf")
+	values := map[string][]string{"name": {"Jane Doe"}}
+	if err := FillForm(formPDF(), output, values, FillFormOptions{Flatten: true}, ""); err != nil {
+		t.Fatalf("FillForm() error = %v", err)
+	}
+
+	if fields := fieldsByName(t, output); len(fields) != 0 {
+		t.Errorf("flattened PDF still has %d fields", len(fields))
+	}
+
+	ctx, err := readContext(output, "")
+	if err != nil {
+		t.Fatalf("readContext() error = %v", err)
+	}
+	page, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("PageDict() error = %v", err)
+	}
+	if _, found := page.Find("Annots"); found {
+		t.Error("widgets were not removed")
+	}
+
+	res, err := ctx.DereferenceDict(page["Resources"])
+	if err != nil {
+		t.Fatalf("resources: %v", err)
+	}
+	xobjects, err := ctx.DereferenceDict(res["XObject"])
+	if err != nil || len(xobjects) == 0 {
+		t.Fatalf("no appearance XObjects on the page: %v", err)
+	}
+	found := false
+	for _, o := range xobjects {
+		sd, _, err := ctx.DereferenceStreamDict(o)
+		if err != nil || sd == nil || sd.Decode() != nil {
+			continue
+		}
+		if strings.Contains(string(sd.Content), "(Jane Doe) Tj") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("filled value is not part of the page")
+	}
+
+	content, err := ctx.PageContent(page, 1)
+	if err != nil {
+		t.Fatalf("PageContent() error = %v", err)
+	}
+	if !strings.Contains(string(content), "/FlatField1 Do") {
+		t.Errorf("page content does not draw the fields:\n%s", content)
+	}
+}
@@ -0,0 +1,331 @@
+package pdf
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// pageSizeTolerance is the maximum difference in points for a page to match a named paper size.
+const pageSizeTolerance = 1.0
+
+// namedPageSizes lists the paper sizes recognized when describing page dimensions.
+var namedPageSizes = []string{"A3", "A4", "A5", "Letter", "Legal", "Tabloid"}
+
+// Rect is a rectangle in PDF user space, expressed in points.
+type Rect struct {
+	LLX float64 `json:"llx"`
+	LLY float64 `json:"lly"`
+	URX float64 `json:"urx"`
+	URY float64 `json:"ury"`
+}
+
+// Width returns the horizontal span of the rectangle.
+func (r Rect) Width() float64 {
+	return r.URX - r.LLX
+}
+
+// Height returns the vertical span of the rectangle.
+func (r Rect) Height() float64 {
+	return r.URY - r.LLY
+}
+
+// String formats the rectangle in PDF array notation.
+func (r Rect) String() string {
+	return fmt.Sprintf("[%s %s %s %s]", formatPoints(r.LLX), formatPoints(r.LLY), formatPoints(r.URX), formatPoints(r.URY))
+}
+
+// PageBoxes holds the effective page boundaries of a single page.
+// Boxes that are not set explicitly inherit from their parent box as defined by the PDF specification.
+type PageBoxes struct {
+	Page     int
+	Rotation int
+	MediaBox Rect
+	CropBox  Rect
+	TrimBox  Rect
+	BleedBox Rect
+	ArtBox   Rect
+}
+
+// PageSize describes a distinct page size and the pages that use it.
+type PageSize struct {
+	Width  float64
+	Height float64
+	Name   string
+	Pages  []int
+}
+
+// ResizeOptions controls how pages are resized.
+type ResizeOptions struct {
+	Size  string  // paper size name (A4, Letter, A4L, ...) or WxH in points
+	Scale float64 // scale factor, mutually exclusive with Size
+	Fit   bool    // match each page's orientation instead of enforcing the orientation of Size
+}
+
+// GetPageBoxes returns the page boundaries for every page of a PDF.
+func GetPageBoxes(path, password string) ([]PageBoxes, error) {
+	cleanPath := filepath.Clean(path)
+	f, err := os.Open(cleanPath) // #nosec G304 -- path is cleaned
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	pbs, err := api.Boxes(f, nil, NewConfig(password))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page boxes: %w", err)
+	}
+
+	result := make([]PageBoxes, len(pbs))
+	for i, pb := range pbs {
+		result[i] = PageBoxes{
+			Page:     i + 1,
+			Rotation: pb.Rot,
+			MediaBox: toRect(pb.MediaBox()),
+			CropBox:  toRect(pb.CropBox()),
+			TrimBox:  toRect(pb.TrimBox()),
+			BleedBox: toRect(pb.BleedBox()),
+			ArtBox:   toRect(pb.ArtBox()),
+		}
+	}
+	return result, nil
+}
+
+// GetPageSizes returns the distinct page sizes of a PDF in order of first appearance.
+// Sizes account for page rotation, so a rotated Letter page is reported as landscape.
+func GetPageSizes(path, password string) ([]PageSize, error) {
+	cleanPath := filepath.Clean(path)
+	f, err := os.Open(cleanPath) // #nosec G304 -- path is cleaned
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	dims, err := api.PageDims(f, NewConfig(password))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page dimensions: %w", err)
+	}
+	return groupPageSizes(dims), nil
+}
+
+// groupPageSizes groups page dimensions into distinct sizes, preserving first-appearance order.
+func groupPageSizes(dims []types.Dim) []PageSize {
+	var sizes []PageSize
+	index := make(map[string]int)
+
+	for i, d := range dims {
+		w, h := roundPoints(d.Width), roundPoints(d.Height)
+		key := formatPoints(w) + "x" + formatPoints(h)
+		if idx, ok := index[key]; ok {
+			sizes[idx].Pages = append(sizes[idx].Pages, i+1)
+			continue
+		}
+		index[key] = len(sizes)
+		sizes = append(sizes, PageSize{
+			Width:  w,
+			Height: h,
+			Name:   PageSizeName(w, h),
+			Pages:  []int{i + 1},
+		})
+	}
+	return sizes
+}
+
+// PageSizeName returns the name of a known paper size matching the given dimensions,
+// with a " landscape" suffix for landscape pages. Returns empty string if unknown.
+func PageSizeName(width, height float64) string {
+	for _, name := range namedPageSizes {
+		d := types.PaperSize[name]
+		if math.Abs(d.Width-width) <= pageSizeTolerance && math.Abs(d.Height-height) <= pageSizeTolerance {
+			return name
+		}
+		if math.Abs(d.Width-height) <= pageSizeTolerance && math.Abs(d.Height-width) <= pageSizeTolerance {
+			return name + " landscape"
+		}
+	}
+	return ""
+}
+
+// ValidateBox checks that a crop box definition can be parsed.
+func ValidateBox(box string) error {
+	b, err := api.Box(box, types.POINTS)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return fmt.Errorf("empty box definition")
+	}
+	return nil
+}
+
+// Crop sets the crop box of the selected pages.
+// The box uses pdfcpu box syntax: margins ("10", "10 20", "10 10 10 10" for top right bottom left)
+// or an absolute rectangle ("[0 0 500 700]").
+func Crop(input, output, box string, pages []int, password string) error {
+	b, err := api.Box(box, types.POINTS)
+	if err != nil {
+		return fmt.Errorf("invalid crop box: %w", err)
+	}
+	if b == nil {
+		return fmt.Errorf("invalid crop box: empty definition")
+	}
+	return api.CropFile(input, output, pagesToStrings(pages), b, NewConfig(password))
+}
+
+// Resize changes the page size of the selected pages, scaling the content to fit.
+func Resize(input, output string, opts ResizeOptions, pages []int, password string) error {
+	res, err := parseResizeOptions(opts)
+	if err != nil {
+		return err
+	}
+	return api.ResizeFile(input, output, pagesToStrings(pages), res, NewConfig(password))
+}
+
+// parseResizeOptions converts ResizeOptions into a pdfcpu resize configuration.
+func parseResizeOptions(opts ResizeOptions) (*model.Resize, error) {
+	if opts.Scale > 0 && opts.Size != "" {
+		return nil, fmt.Errorf("specify either a size or a scale factor, not both")
+	}
+
+	var desc string
+	switch {
+	case opts.Scale > 0:
+		desc = "scalefactor:" + strconv.FormatFloat(opts.Scale, 'f', -1, 64)
+	case opts.Size != "":
+		dim, name, err := ParsePageDim(opts.Size)
+		if err != nil {
+			return nil, err
+		}
+		if name != "" {
+			desc = "formsize:" + name
+		} else {
+			desc = fmt.Sprintf("dimensions:%s %s", formatPoints(dim.Width), formatPoints(dim.Height))
+		}
+		if !opts.Fit {
+			desc += ", enforce:on"
+		}
+	default:
+		return nil, fmt.Errorf("a size or a scale factor is required")
+	}
+
+	res, err := pdfcpu.ParseResizeConfig(desc, types.POINTS)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resize options: %w", err)
+	}
+	return res, nil
+}
+
+// ParsePageDim parses a page size given as a paper size name (A4, Letter, A4L for landscape)
+// or as WxH in points (e.g., 612x792). For named sizes, the canonical pdfcpu name is returned.
+func ParsePageDim(size string) (*types.Dim, string, error) {
+	size = strings.TrimSpace(size)
+	if size == "" {
+		return nil, "", fmt.Errorf("empty page size")
+	}
+
+	if w, h, ok := strings.Cut(strings.ToLower(size), "x"); ok {
+		width, errW := strconv.ParseFloat(strings.TrimSpace(w), 64)
+		height, errH := strconv.ParseFloat(strings.TrimSpace(h), 64)
+		if errW == nil && errH == nil {
+			if width <= 0 || height <= 0 {
+				return nil, "", fmt.Errorf("invalid page size %q: dimensions must be positive", size)
+			}
+			return &types.Dim{Width: width, Height: height}, "", nil
+		}
+	}
+
+	name, landscape, ok := canonicalPaperSize(size)
+	if !ok {
+		return nil, "", fmt.Errorf("unknown page size %q (use a name like A4 or Letter, or WxH in points)", size)
+	}
+
+	d := *types.PaperSize[name]
+	if landscape && d.Portrait() {
+		d.Width, d.Height = d.Height, d.Width
+		name += "L"
+	}
+	return &d, name, nil
+}
+
+// ParseRect parses a rectangle given as "llx lly urx ury" in points,
+// optionally in brackets or separated by commas (e.g., "[36 36 236 96]").
+func ParseRect(s string) (Rect, error) {
+	fields := strings.FieldsFunc(strings.Trim(strings.TrimSpace(s), "[]"), func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(fields) != 4 {
+		return Rect{}, fmt.Errorf("invalid rectangle %q: use \"llx lly urx ury\"", s)
+	}
+	var v [4]float64
+	for i, f := range fields {
+		n, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return Rect{}, fmt.Errorf("invalid rectangle %q: %q is not a number", s, f)
+		}
+		v[i] = n
+	}
+	r := Rect{LLX: v[0], LLY: v[1], URX: v[2], URY: v[3]}
+	if r.Width() <= 0 || r.Height() <= 0 {
+		return Rect{}, fmt.Errorf("invalid rectangle %q: upper right must be above and right of lower left", s)
+	}
+	return r, nil
+}
+
+// canonicalPaperSize finds the pdfcpu paper size name for s, ignoring case.
+// A trailing "L" selects landscape orientation.
+func canonicalPaperSize(s string) (name string, landscape bool, ok bool) {
+	if name, ok := lookupPaperSize(s); ok {
+		return name, false, true
+	}
+	if n := len(s); n > 1 && (s[n-1] == 'L' || s[n-1] == 'l') {
+		if name, ok := lookupPaperSize(s[:n-1]); ok {
+			return name, true, true
+		}
+	}
+	return "", false, false
+}
+
+// lookupPaperSize returns the pdfcpu paper size key matching s case-insensitively.
+func lookupPaperSize(s string) (string, bool) {
+	if _, ok := types.PaperSize[s]; ok {
+		return s, true
+	}
+	names := make([]string, 0, len(types.PaperSize))
+	for name := range types.PaperSize {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.EqualFold(name, s) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// toRect converts a pdfcpu rectangle to a Rect, returning the zero Rect for nil input.
+func toRect(r *types.Rectangle) Rect {
+	if r == nil {
+		return Rect{}
+	}
+	return Rect{LLX: r.LL.X, LLY: r.LL.Y, URX: r.UR.X, URY: r.UR.Y}
+}
+
+// roundPoints rounds a point value to two decimal places.
+func roundPoints(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// formatPoints formats a point value without trailing zeros.
+func formatPoints(v float64) string {
+	return strconv.FormatFloat(roundPoints(v), 'f', -1, 64)
+}
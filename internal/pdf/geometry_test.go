@@ -0,0 +1,234 @@
+package pdf
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestParsePageDim(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantW    float64
+		wantH    float64
+		wantName string
+		wantErr  bool
+	}{
+		{"A4", 595, 842, "A4", false},
+		{"a4", 595, 842, "A4", false},
+		{"A4L", 842, 595, "A4L", false},
+		{"letter", 612, 792, "Letter", false},
+		{"612x792", 612, 792, "", false},
+		{"200 x 300", 200, 300, "", false},
+		{"0x100", 0, 0, "", true},
+		{"", 0, 0, "", true},
+		{"NoSuchSize", 0, 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			dim, name, err := ParsePageDim(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if dim.Width != tt.wantW || dim.Height != tt.wantH {
+				t.Errorf("dim = %vx%v, want %vx%v", dim.Width, dim.Height, tt.wantW, tt.wantH)
+			}
+			if name != tt.wantName {
+				t.Errorf("name = %q, want %q", name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestPageSizeName(t *testing.T) {
+	tests := []struct {
+		width, height float64
+		want          string
+	}{
+		{612, 792, "Letter"},
+		{792, 612, "Letter landscape"},
+		{595.28, 841.89, "A4"},
+		{612, 1008, "Legal"},
+		{100, 100, ""},
+	}
+
+	for _, tt := range tests {
+		if got := PageSizeName(tt.width, tt.height); got != tt.want {
+			t.Errorf("PageSizeName(%v, %v) = %q, want %q", tt.width, tt.height, got, tt.want)
+		}
+	}
+}
+
+func TestGroupPageSizes(t *testing.T) {
+	dims := []types.Dim{
+		{Width: 612, Height: 792},
+		{Width: 595, Height: 842},
+		{Width: 612, Height: 792},
+		{Width: 612.001, Height: 792},
+	}
+
+	got := groupPageSizes(dims)
+	if len(got) != 2 {
+		t.Fatalf("got %d sizes, want 2: %+v", len(got), got)
+	}
+	if !reflect.DeepEqual(got[0].Pages, []int{1, 3, 4}) {
+		t.Errorf("Letter pages = %v, want [1 3 4]", got[0].Pages)
+	}
+	if got[1].Name != "A4" || !reflect.DeepEqual(got[1].Pages, []int{2}) {
+		t.Errorf("second size = %+v, want A4 on page 2", got[1])
+	}
+}
+
+func TestGetPageBoxes(t *testing.T) {
+	pdfFile := samplePDF()
+	if _, err := os.Stat(pdfFile); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	boxes, err := GetPageBoxes(pdfFile, "")
+	if err != nil {
+		t.Fatalf("GetPageBoxes() error = %v", err)
+	}
+	if len(boxes) != 3 {
+		t.Fatalf("got %d pages, want 3", len(boxes))
+	}
+	want := Rect{LLX: 0, LLY: 0, URX: 612, URY: 792}
+	if boxes[0].MediaBox != want || boxes[0].TrimBox != want {
+		t.Errorf("page 1 boxes = %+v, want media/trim %v", boxes[0], want)
+	}
+}
+
+func TestCrop(t *testing.T) {
+	pdfFile := samplePDF()
+	if _, err := os.Stat(pdfFile); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output := filepath.Join(tmpDir, "cropped.pdf")
+	if err := Crop(pdfFile, output, "10 20 30 40", []int{1}, ""); err != nil {
+		t.Fatalf("Crop() error = %v", err)
+	}
+
+	boxes, err := GetPageBoxes(output, "")
+	if err != nil {
+		t.Fatalf("GetPageBoxes() error = %v", err)
+	}
+	want := Rect{LLX: 40, LLY: 30, URX: 592, URY: 782}
+	if boxes[0].CropBox != want {
+		t.Errorf("page 1 crop box = %v, want %v", boxes[0].CropBox, want)
+	}
+	if boxes[1].CropBox != boxes[1].MediaBox {
+		t.Errorf("page 2 should not be cropped, got %v", boxes[1].CropBox)
+	}
+}
+
+func TestCropInvalidBox(t *testing.T) {
+	if err := ValidateBox("not a box"); err == nil {
+		t.Error("ValidateBox() expected error for invalid box")
+	}
+	if err := ValidateBox(""); err == nil {
+		t.Error("ValidateBox() expected error for empty box")
+	}
+}
+
+func TestResize(t *testing.T) {
+	pdfFile := samplePDF()
+	if _, err := os.Stat(pdfFile); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output := filepath.Join(tmpDir, "resized.pdf")
+	if err := Resize(pdfFile, output, ResizeOptions{Size: "A4", Fit: true}, nil, ""); err != nil {
+		t.Fatalf("Resize() error = %v", err)
+	}
+
+	sizes, err := GetPageSizes(output, "")
+	if err != nil {
+		t.Fatalf("GetPageSizes() error = %v", err)
+	}
+	if len(sizes) != 1 || sizes[0].Name != "A4" {
+		t.Errorf("sizes = %+v, want all pages A4", sizes)
+	}
+}
+
+func TestResizeInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts ResizeOptions
+	}{
+		{"empty", ResizeOptions{}},
+		{"size and scale", ResizeOptions{Size: "A4", Scale: 0.5}},
+		{"unknown size", ResizeOptions{Size: "Foo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseResizeOptions(tt.opts); err == nil {
+				t.Error("parseResizeOptions() expected error")
+			}
+		})
+	}
+}
+
+func TestGetInfoPageSizes(t *testing.T) {
+	pdfFile := samplePDF()
+	if _, err := os.Stat(pdfFile); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	info, err := GetInfo(pdfFile, "")
+	if err != nil {
+		t.Fatalf("GetInfo() error = %v", err)
+	}
+	if len(info.PageSizes) != 1 {
+		t.Fatalf("PageSizes = %+v, want 1 size", info.PageSizes)
+	}
+	if info.PageSizes[0].Name != "Letter" || len(info.PageSizes[0].Pages) != 3 {
+		t.Errorf("PageSizes[0] = %+v, want Letter on 3 pages", info.PageSizes[0])
+	}
+}
+
+func TestParseRect(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Rect
+		wantErr bool
+	}{
+		{"36 36 236 96", Rect{36, 36, 236, 96}, false},
+		{"[0 0 100.5 50]", Rect{0, 0, 100.5, 50}, false},
+		{"10,20, 30,40", Rect{10, 20, 30, 40}, false},
+		{"0 0 100", Rect{}, true},
+		{"0 0 a 50", Rect{}, true},
+		{"100 0 0 50", Rect{}, true},
+		{"", Rect{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRect(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRect(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRect(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
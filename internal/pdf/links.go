@@ -0,0 +1,295 @@
+package pdf
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Link types reported by ListLinks.
+const (
+	LinkURI    = "uri"    // web or mail link
+	LinkGoTo   = "goto"   // destination in the document
+	LinkRemote = "remote" // destination in another PDF
+	LinkLaunch = "launch" // opens a file or application
+	LinkOther  = "other"  // any other action, e.g. JavaScript
+)
+
+// Link describes a link annotation.
+type Link struct {
+	Page     int    `json:"page"`
+	Type     string `json:"type"`
+	Target   string `json:"target"`             // URI, named destination, file or action type
+	DestPage int    `json:"destPage,omitempty"` // target page of an internal link
+	Rect     Rect   `json:"rect"`
+	Broken   bool   `json:"broken"` // internal link whose destination does not exist
+}
+
+// LinkMapping replaces the URI prefix Old with New.
+type LinkMapping struct {
+	Old string
+	New string
+}
+
+// LinkRewrite describes a URI changed by RewriteLinks.
+type LinkRewrite struct {
+	Page int    `json:"page"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// ParseLinkMapping parses a mapping given as old=new.
+func ParseLinkMapping(s string) (LinkMapping, error) {
+	old, replacement, ok := strings.Cut(s, "=")
+	if !ok || old == "" {
+		return LinkMapping{}, fmt.Errorf("invalid mapping %q: use old=new", s)
+	}
+	return LinkMapping{Old: old, New: replacement}, nil
+}
+
+// ListLinks returns the links on the given pages of a PDF (all pages if
+// pages is empty), in page order. Internal links are resolved to their
+// target page; links to destinations that do not exist are marked broken.
+func ListLinks(input string, pages []int, password string) ([]Link, error) {
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return nil, err
+	}
+	pageNumbers, err := pageObjectNumbers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []Link
+	err = visitLinks(ctx, pages, func(page int, annot types.Dict, action types.Dict) {
+		link := Link{Page: page}
+		if r, err := ctx.RectForArray(annot.ArrayEntry("Rect")); err == nil && r != nil {
+			link.Rect = toRect(r)
+		}
+
+		dest, hasDest := annot.Find("Dest")
+		switch s := actionType(ctx, action); {
+		case action == nil && hasDest, s == "GoTo":
+			if action != nil {
+				dest = action["D"]
+			}
+			link.Type = LinkGoTo
+			link.Target, link.DestPage = resolveDestination(ctx, dest, pageNumbers)
+			link.Broken = link.DestPage == 0
+		case s == "URI":
+			link.Type = LinkURI
+			link.Target = actionString(ctx, action, "URI")
+		case s == "GoToR":
+			link.Type = LinkRemote
+			link.Target = fileSpecName(ctx, action["F"])
+		case s == "Launch":
+			link.Type = LinkLaunch
+			link.Target = fileSpecName(ctx, action["F"])
+		default:
+			link.Type = LinkOther
+			link.Target = s
+		}
+		links = append(links, link)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// FindLinkRewrites returns the URI links that RewriteLinks would change.
+func FindLinkRewrites(input string, mappings []LinkMapping, password string) ([]LinkRewrite, error) {
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return nil, err
+	}
+	return rewriteLinks(ctx, mappings, false)
+}
+
+// RewriteLinks replaces URI prefixes in the links of a PDF and writes the
+// result to output. When several mappings match a URI, the longest prefix
+// wins. It returns the links that were changed.
+func RewriteLinks(input, output string, mappings []LinkMapping, password string) ([]LinkRewrite, error) {
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return nil, err
+	}
+	rewrites, err := rewriteLinks(ctx, mappings, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := api.WriteContextFile(ctx, output); err != nil {
+		return nil, err
+	}
+	return rewrites, nil
+}
+
+func rewriteLinks(ctx *model.Context, mappings []LinkMapping, apply bool) ([]LinkRewrite, error) {
+	sorted := append([]LinkMapping{}, mappings...)
+	sort.SliceStable(sorted, func(i, j int) bool { return len(sorted[i].Old) > len(sorted[j].Old) })
+
+	var rewrites []LinkRewrite
+	seen := map[int]bool{}
+	err := visitLinks(ctx, nil, func(page int, annot types.Dict, action types.Dict) {
+		if actionType(ctx, action) != "URI" {
+			return
+		}
+		// An action shared by several links is rewritten only once.
+		if ir, ok := annot["A"].(types.IndirectRef); ok {
+			if seen[ir.ObjectNumber.Value()] {
+				return
+			}
+			seen[ir.ObjectNumber.Value()] = true
+		}
+		uri := actionString(ctx, action, "URI")
+		for _, m := range sorted {
+			if !strings.HasPrefix(uri, m.Old) {
+				continue
+			}
+			replaced := m.New + strings.TrimPrefix(uri, m.Old)
+			if replaced == uri {
+				return
+			}
+			rewrites = append(rewrites, LinkRewrite{Page: page, Old: uri, New: replaced})
+			if apply {
+				// URIs are 7-bit ASCII strings, so no text encoding is needed.
+				if escaped, err := types.Escape(replaced); err == nil {
+					action.Update("URI", types.StringLiteral(*escaped))
+				}
+			}
+			return
+		}
+	})
+	return rewrites, err
+}
+
+// visitLinks calls fn for each link annotation on the given pages (all pages
+// if pages is empty) with its action dictionary, which is nil for links that
+// have a destination instead.
+func visitLinks(ctx *model.Context, pages []int, fn func(page int, annot, action types.Dict)) error {
+	for _, page := range selectedPages(pages, ctx.PageCount) {
+		d, _, _, err := ctx.PageDict(page, false)
+		if err != nil {
+			return fmt.Errorf("failed to read page %d: %w", page, err)
+		}
+		annots, err := ctx.DereferenceArray(d["Annots"])
+		if err != nil {
+			return fmt.Errorf("failed to read annotations of page %d: %w", page, err)
+		}
+		for _, a := range annots {
+			annot, err := ctx.DereferenceDict(a)
+			if err != nil || annot == nil || subtypeOf(annot) != "Link" {
+				continue
+			}
+			action, err := ctx.DereferenceDict(annot["A"])
+			if err != nil {
+				action = nil
+			}
+			fn(page, annot, action)
+		}
+	}
+	return nil
+}
+
+// pageObjectNumbers maps the object number of each page dictionary to its page number.
+func pageObjectNumbers(ctx *model.Context) (map[int]int, error) {
+	m := map[int]int{}
+	for page := 1; page <= ctx.PageCount; page++ {
+		_, ir, _, err := ctx.PageDict(page, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read page %d: %w", page, err)
+		}
+		if ir != nil {
+			m[ir.ObjectNumber.Value()] = page
+		}
+	}
+	return m, nil
+}
+
+// resolveDestination returns a description of the destination o and its
+// page number, or 0 if the destination does not exist.
+func resolveDestination(ctx *model.Context, o types.Object, pageNumbers map[int]int) (string, int) {
+	o, _ = ctx.Dereference(o)
+	var name string
+	switch d := o.(type) {
+	case types.Name:
+		name = d.Value()
+	case types.StringLiteral, types.HexLiteral:
+		name, _ = ctx.DereferenceStringOrHexLiteral(d, model.V10, nil)
+	case types.Dict:
+		o, _ = ctx.Dereference(d["D"])
+	}
+	if name != "" {
+		o = namedDestination(ctx, name)
+	}
+
+	page := 0
+	if arr, ok := o.(types.Array); ok && len(arr) > 0 {
+		if ir, ok := arr[0].(types.IndirectRef); ok {
+			page = pageNumbers[ir.ObjectNumber.Value()]
+		}
+	}
+	switch {
+	case name != "":
+		return name, page
+	case page > 0:
+		return "page " + strconv.Itoa(page), page
+	default:
+		return "missing page", 0
+	}
+}
+
+// namedDestination looks up a named destination in the Dests name tree of
+// the document and in the Dests dictionary of older PDFs.
+func namedDestination(ctx *model.Context, name string) types.Object {
+	var o types.Object
+	if err := ctx.LocateNameTree("Dests", false); err == nil && ctx.Names["Dests"] != nil {
+		o, _ = ctx.Names["Dests"].Value(name)
+	}
+	if o == nil {
+		if root, err := ctx.Catalog(); err == nil {
+			if dests, err := ctx.DereferenceDict(root["Dests"]); err == nil && dests != nil {
+				o = dests[name]
+			}
+		}
+	}
+	o, _ = ctx.Dereference(o)
+	if d, ok := o.(types.Dict); ok {
+		o, _ = ctx.Dereference(d["D"])
+	}
+	return o
+}
+
+// actionString returns the text of a string entry of an action.
+func actionString(ctx *model.Context, action types.Dict, key string) string {
+	if v, found := action.Find(key); found {
+		s, _ := ctx.DereferenceStringOrHexLiteral(v, model.V10, nil)
+		return s
+	}
+	return ""
+}
+
+// fileSpecName returns the file name of a file specification, which is a
+// string or a dictionary.
+func fileSpecName(ctx *model.Context, o types.Object) string {
+	o, _ = ctx.Dereference(o)
+	if d, ok := o.(types.Dict); ok {
+		for _, k := range []string{"UF", "F"} {
+			if v, found := d.Find(k); found {
+				s, _ := ctx.DereferenceStringOrHexLiteral(v, model.V10, nil)
+				return s
+			}
+		}
+		return ""
+	}
+	if o == nil {
+		return ""
+	}
+	s, _ := ctx.DereferenceStringOrHexLiteral(o, model.V10, nil)
+	return s
+}
@@ -0,0 +1,108 @@
+package pdf
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// linksPDF returns the path to a three-page PDF whose first page has two URI
+// links, a link to the named destination "chapter2" (page 2), a GoTo action
+// to page 3, a link to an object that is not a page and a GoTo action to the
+// undefined name "appendix"; page 2 has a URI link and a link to other.pdf.
+func linksPDF() string {
+	return filepath.Join(testdataDir(), "links.pdf")
+}
+
+func TestListLinks(t *testing.T) {
+	links, err := ListLinks(linksPDF(), nil, "")
+	if err != nil {
+		t.Fatalf("ListLinks() error = %v", err)
+	}
+
+	want := []struct {
+		typ      string
+		target   string
+		destPage int
+		broken   bool
+	}{
+		{LinkURI, "http://intranet.example.com/docs/guide.html", 0, false},
+		{LinkURI, "mailto:help@example.com", 0, false},
+		{LinkGoTo, "chapter2", 2, false},
+		{LinkGoTo, "page 3", 3, false},
+		{LinkGoTo, "missing page", 0, true},
+		{LinkGoTo, "appendix", 0, true},
+		{LinkURI, "http://intranet.example.com/wiki", 0, false},
+		{LinkRemote, "other.pdf", 0, false},
+	}
+	if len(links) != len(want) {
+		t.Fatalf("got %d links, want %d: %+v", len(links), len(want), links)
+	}
+	for i, w := range want {
+		l := links[i]
+		if l.Type != w.typ || l.Target != w.target || l.DestPage != w.destPage || l.Broken != w.broken {
+			t.Errorf("link %d = %+v, want %+v", i, l, w)
+		}
+	}
+	if links[0].Rect != (Rect{72, 700, 272, 714}) {
+		t.Errorf("link rect = %v", links[0].Rect)
+	}
+
+	links, err = ListLinks(linksPDF(), []int{2}, "")
+	if err != nil {
+		t.Fatalf("ListLinks() error = %v", err)
+	}
+	if len(links) != 2 || links[0].Page != 2 {
+		t.Errorf("links on page 2 = %+v", links)
+	}
+}
+
+func TestParseLinkMapping(t *testing.T) {
+	m, err := ParseLinkMapping("http://a=https://b=c")
+	if err != nil || m.Old != "http://a" || m.New != "https://b=c" {
+		t.Errorf("ParseLinkMapping() = %+v, %v", m, err)
+	}
+	for _, s := range []string{"", "novalue", "=new"} {
+		if _, err := ParseLinkMapping(s); err == nil {
+			t.Errorf("ParseLinkMapping(%q) expected error", s)
+		}
+	}
+}
+
+func TestRewriteLinks(t *testing.T) {
+	mappings := []LinkMapping{
+		{Old: "http://", New: "https://"},
+		{Old: "http://intranet.example.com/docs", New: "https://docs.example.org"},
+	}
+
+	found, err := FindLinkRewrites(linksPDF(), mappings, "")
+	if err != nil {
+		t.Fatalf("FindLinkRewrites() error = %v", err)
+	}
+	if len(found) != 2 {
+		t.Fatalf("FindLinkRewrites() = %+v, want 2 rewrites", found)
+	}
+
+	output := filepath.Join(t.TempDir(), "relinked.pdf")
+	rewrites, err := RewriteLinks(linksPDF(), output, mappings, "")
+	if err != nil {
+		t.Fatalf("RewriteLinks() error = %v", err)
+	}
+	if len(rewrites) != len(found) {
+		t.Errorf("RewriteLinks() = %+v, want %+v", rewrites, found)
+	}
+
+	links, err := ListLinks(output, nil, "")
+	if err != nil {
+		t.Fatalf("ListLinks() error = %v", err)
+	}
+	// The longest matching prefix wins.
+	if got := links[0].Target; got != "https://docs.example.org/guide.html" {
+		t.Errorf("first link = %q", got)
+	}
+	if got := links[6].Target; got != "https://intranet.example.com/wiki" {
+		t.Errorf("wiki link = %q", got)
+	}
+	if got := links[1].Target; got != "mailto:help@example.com" {
+		t.Errorf("mail link changed to %q", got)
+	}
+}
@@ -1,12 +1,17 @@
 package pdf
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 // Info holds PDF document information
@@ -24,6 +29,7 @@ type Info struct {
 	CreatedDate string
 	ModDate     string
 	Encrypted   bool
+	PageSizes   []PageSize
 }
 
 // GetInfo returns information about a PDF file
@@ -48,16 +54,19 @@ func GetInfo(path, password string) (*Info, error) {
 	}
 
 	info := &Info{
-		FilePath:  path,
-		FileSize:  fileInfo.Size(),
-		Pages:     pdfInfoResult.PageCount,
-		Version:   pdfInfoResult.Version,
-		Title:     pdfInfoResult.Title,
-		Author:    pdfInfoResult.Author,
-		Subject:   pdfInfoResult.Subject,
-		Creator:   pdfInfoResult.Creator,
-		Producer:  pdfInfoResult.Producer,
-		Encrypted: pdfInfoResult.Encrypted,
+		FilePath:    path,
+		FileSize:    fileInfo.Size(),
+		Pages:       pdfInfoResult.PageCount,
+		Version:     pdfInfoResult.Version,
+		Title:       pdfInfoResult.Title,
+		Author:      pdfInfoResult.Author,
+		Subject:     pdfInfoResult.Subject,
+		Creator:     pdfInfoResult.Creator,
+		Producer:    pdfInfoResult.Producer,
+		CreatedDate: FormatPDFDate(pdfInfoResult.CreationDate),
+		ModDate:     FormatPDFDate(pdfInfoResult.ModificationDate),
+		Encrypted:   pdfInfoResult.Encrypted,
+		PageSizes:   pageSizesFromBoundaries(pdfInfoResult.PageBoundaries),
 	}
 
 	if len(pdfInfoResult.Keywords) > 0 {
@@ -67,6 +76,24 @@ func GetInfo(path, password string) (*Info, error) {
 	return info, nil
 }
 
+// pageSizesFromBoundaries derives the distinct page sizes from page boundaries,
+// swapping width and height for pages rotated by 90 or 270 degrees.
+func pageSizesFromBoundaries(pbs []model.PageBoundaries) []PageSize {
+	dims := make([]types.Dim, 0, len(pbs))
+	for _, pb := range pbs {
+		mb := pb.MediaBox()
+		if mb == nil {
+			continue
+		}
+		d := mb.Dimensions()
+		if pb.Rot%180 != 0 {
+			d.Width, d.Height = d.Height, d.Width
+		}
+		dims = append(dims, d)
+	}
+	return groupPageSizes(dims)
+}
+
 // PageCount returns the number of pages in a PDF
 func PageCount(path, password string) (int, error) {
 	// Note: PageCountFile doesn't use config in newer pdfcpu versions
@@ -84,25 +111,45 @@ type Metadata struct {
 	Producer    string
 	CreatedDate string
 	ModDate     string
+	Custom      map[string]string // non-standard document information entries
+	Encrypted   bool              // pdfcpu writes its own dates and producer
 }
 
 // GetMetadata returns the metadata of a PDF
 func GetMetadata(input, password string) (*Metadata, error) {
-	info, err := GetInfo(input, password)
+	ctx, err := readContext(input, password)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to read PDF info: %w", err)
 	}
 
-	return &Metadata{
-		Title:       info.Title,
-		Author:      info.Author,
-		Subject:     info.Subject,
-		Keywords:    info.Keywords,
-		Creator:     info.Creator,
-		Producer:    info.Producer,
-		CreatedDate: info.CreatedDate,
-		ModDate:     info.ModDate,
-	}, nil
+	meta := &Metadata{Encrypted: ctx.Encrypt != nil}
+	for key, value := range infoDict(ctx) {
+		text := infoText(ctx, value)
+		switch key {
+		case "Title":
+			meta.Title = text
+		case "Author":
+			meta.Author = text
+		case "Subject":
+			meta.Subject = text
+		case "Keywords":
+			meta.Keywords = text
+		case "Creator":
+			meta.Creator = text
+		case "Producer":
+			meta.Producer = text
+		case "CreationDate":
+			meta.CreatedDate = FormatPDFDate(text)
+		case "ModDate":
+			meta.ModDate = FormatPDFDate(text)
+		default:
+			if meta.Custom == nil {
+				meta.Custom = map[string]string{}
+			}
+			meta.Custom[key] = text
+		}
+	}
+	return meta, nil
 }
 
 // SetMetadata sets metadata on a PDF
@@ -129,3 +176,279 @@ func SetMetadata(input, output string, meta *Metadata, password string) error {
 
 	return api.AddPropertiesFile(input, output, properties, NewConfig(password))
 }
+
+// standardInfoKeys maps lower-case names and aliases to document information keys.
+var standardInfoKeys = map[string]string{
+	"title":        "Title",
+	"author":       "Author",
+	"subject":      "Subject",
+	"keywords":     "Keywords",
+	"creator":      "Creator",
+	"producer":     "Producer",
+	"creationdate": "CreationDate",
+	"created":      "CreationDate",
+	"moddate":      "ModDate",
+	"modified":     "ModDate",
+	"trapped":      "Trapped",
+}
+
+// InfoKey returns the document information key for a user-supplied field name.
+// Standard fields match case-insensitively (also "created" and "modified");
+// any other valid PDF name is returned unchanged as a custom key.
+func InfoKey(name string) (string, error) {
+	if key, ok := standardInfoKeys[strings.ToLower(name)]; ok {
+		return key, nil
+	}
+	if name == "" || strings.ContainsAny(name, " \t\r\n()<>[]{}/%#") {
+		return "", fmt.Errorf("invalid metadata key %q", name)
+	}
+	return name, nil
+}
+
+// isStandardInfoKey reports whether key is defined by the PDF specification.
+func isStandardInfoKey(key string) bool {
+	return standardInfoKeys[strings.ToLower(key)] == key
+}
+
+// ParseDate parses a date given as "now", YYYY-MM-DD, "YYYY-MM-DD HH:MM:SS",
+// RFC 3339 or a PDF date string (D:YYYYMMDDHHmmSS).
+func ParseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "now") {
+		return time.Now(), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	if t, ok := types.DateTime(s, true); ok {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD, \"YYYY-MM-DD HH:MM:SS\", RFC 3339 or now", s)
+}
+
+// FormatPDFDate formats a PDF date string as RFC 3339, or returns it unchanged
+// if it cannot be parsed.
+func FormatPDFDate(s string) string {
+	if s == "" {
+		return ""
+	}
+	t, ok := types.DateTime(s, true)
+	if !ok {
+		return s
+	}
+	return t.Format(time.RFC3339)
+}
+
+// MetadataUpdate describes changes to the document information dictionary.
+// Keys are document information keys as returned by InfoKey.
+type MetadataUpdate struct {
+	Set     map[string]string
+	Clear   []string
+	SyncXMP bool // rewrite the XMP metadata to match the updated information
+}
+
+// UpdateMetadata applies update to the document information of input and
+// writes the result to output. Unless it is set or cleared, ModDate becomes
+// the current time; all other entries, including the creation date and the
+// producer, keep their values.
+//
+// For encrypted files pdfcpu's own creation date, modification date and
+// producer are written, so setting those entries is not supported.
+func UpdateMetadata(input, output string, update MetadataUpdate, password string) error {
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return err
+	}
+
+	info := types.Dict{}
+	for key, value := range infoDict(ctx) {
+		if o, err := ctx.Dereference(value); err == nil && o != nil {
+			info[key] = o
+		}
+	}
+	touched := map[string]bool{}
+	for _, name := range update.Clear {
+		for key := range info {
+			if strings.EqualFold(key, name) {
+				delete(info, key)
+			}
+		}
+		touched[name] = true
+	}
+	for key, value := range update.Set {
+		o, err := infoValue(key, value)
+		if err != nil {
+			return err
+		}
+		info[key] = o
+		touched[key] = true
+	}
+	if !touched["ModDate"] {
+		info["ModDate"] = types.StringLiteral(types.DateString(time.Now()))
+	}
+
+	encrypted := ctx.Encrypt != nil
+	if encrypted {
+		for _, key := range []string{"CreationDate", "ModDate", "Producer"} {
+			if _, set := update.Set[key]; set {
+				return fmt.Errorf("cannot set %s of an encrypted PDF; decrypt it first", key)
+			}
+		}
+	}
+
+	if update.SyncXMP {
+		if err := setXMP(ctx, info); err != nil {
+			return fmt.Errorf("failed to update XMP metadata: %w", err)
+		}
+	}
+
+	// pdfcpu changes the dates and producer of the dictionary it writes, so it
+	// gets a copy and the final dictionary is appended as an incremental update.
+	ir, err := ctx.IndRefForNewObject(info.Clone())
+	if err != nil {
+		return err
+	}
+	ctx.Info = ir
+	if encrypted {
+		return api.WriteContextFile(ctx, output)
+	}
+
+	ctx.WriteObjectStream = false
+	ctx.WriteXRefStream = false
+	if err := api.WriteContextFile(ctx, output); err != nil {
+		return err
+	}
+	return appendInfoRevision(output, info)
+}
+
+// infoDict returns the document information dictionary, or nil if there is none.
+func infoDict(ctx *model.Context) types.Dict {
+	if ctx.Info == nil {
+		return nil
+	}
+	d, err := ctx.DereferenceDict(*ctx.Info)
+	if err != nil {
+		return nil
+	}
+	return d
+}
+
+// infoText returns a document information entry as text.
+func infoText(ctx *model.Context, o types.Object) string {
+	o, err := ctx.Dereference(o)
+	if err != nil || o == nil {
+		return ""
+	}
+	switch v := o.(type) {
+	case types.StringLiteral, types.HexLiteral:
+		if s, err := types.StringOrHexLiteral(v); err == nil {
+			return *s
+		}
+	case types.Name:
+		return v.Value()
+	}
+	return o.String()
+}
+
+// infoValue converts a value for a document information key to a PDF object.
+func infoValue(key, value string) (types.Object, error) {
+	switch key {
+	case "CreationDate", "ModDate":
+		t, err := ParseDate(value)
+		if err != nil {
+			return nil, err
+		}
+		return types.StringLiteral(types.DateString(t)), nil
+	case "Trapped":
+		for _, v := range []string{"True", "False", "Unknown"} {
+			if strings.EqualFold(value, v) {
+				return types.Name(v), nil
+			}
+		}
+		return nil, fmt.Errorf("invalid Trapped value %q: use True, False or Unknown", value)
+	}
+	return textString(value)
+}
+
+// textString encodes value as a PDF text string, using UTF-16 for non-ASCII text.
+func textString(value string) (types.StringLiteral, error) {
+	encode := types.EscapedUTF16String
+	if isASCII(value) {
+		encode = types.Escape
+	}
+	s, err := encode(value)
+	if err != nil {
+		return "", err
+	}
+	return types.StringLiteral(*s), nil
+}
+
+// isASCII reports whether s only contains ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// appendInfoRevision appends an incremental update to the PDF at path that
+// replaces its document information dictionary with info.
+func appendInfoRevision(path string, info types.Dict) error {
+	ctx, err := readContext(path, "")
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	prev, err := lastStartXRef(data)
+	if err != nil {
+		return err
+	}
+
+	objNr := *ctx.XRefTable.Size
+	trailer := types.Dict{
+		"Size": types.Integer(objNr + 1),
+		"Root": *ctx.Root,
+		"Info": *types.NewIndirectRef(objNr, 0),
+		"Prev": types.Integer(prev),
+	}
+	if ctx.ID != nil {
+		trailer["ID"] = ctx.ID
+	}
+
+	var b bytes.Buffer
+	offset := len(data)
+	fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", objNr, info.PDFString())
+	xref := offset + b.Len()
+	fmt.Fprintf(&b, "xref\n%d 1\n%010d 00000 n \ntrailer\n%s\nstartxref\n%d\n%%%%EOF\n",
+		objNr, offset, trailer.PDFString(), xref)
+
+	f, err := os.OpenFile(filepath.Clean(path), os.O_APPEND|os.O_WRONLY, 0) // #nosec G304 -- path is cleaned
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b.Bytes()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// lastStartXRef returns the cross-reference offset given by the last startxref keyword.
+func lastStartXRef(data []byte) (int, error) {
+	i := bytes.LastIndex(data, []byte("startxref"))
+	if i < 0 {
+		return 0, fmt.Errorf("missing startxref")
+	}
+	fields := strings.Fields(string(data[i+len("startxref"):]))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("missing startxref offset")
+	}
+	return strconv.Atoi(fields[0])
+}
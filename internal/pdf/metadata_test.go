@@ -3,7 +3,10 @@ package pdf
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
 )
 
 func TestGetMetadata(t *testing.T) {
@@ -331,3 +334,115 @@ func TestConvertToPDFANonExistent(t *testing.T) {
 		t.Error("ConvertToPDFA() expected error for non-existent file")
 	}
 }
+
+func TestInfoKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"author", "Author", false},
+		{"TITLE", "Title", false},
+		{"created", "CreationDate", false},
+		{"ModDate", "ModDate", false},
+		{"Department", "Department", false},
+		{"", "", true},
+		{"has space", "", true},
+		{"a/b", "", true},
+	}
+	for _, tt := range tests {
+		got, err := InfoKey(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("InfoKey(%q) = %q, %v; want %q, error %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	for _, s := range []string{"2024-03-01", "2024-03-01 10:20:30", "2024-03-01T10:20:30+02:00", "D:20240301102030Z", "now"} {
+		if _, err := ParseDate(s); err != nil {
+			t.Errorf("ParseDate(%q) error = %v", s, err)
+		}
+	}
+	if _, err := ParseDate("yesterday"); err == nil {
+		t.Error("ParseDate(\"yesterday\") expected error")
+	}
+
+	if got := FormatPDFDate("D:20240301102030+02'00'"); got != "2024-03-01T10:20:30+02:00" {
+		t.Errorf("FormatPDFDate() = %q", got)
+	}
+	if got := FormatPDFDate("not a date"); got != "not a date" {
+		t.Errorf("FormatPDFDate() = %q, want input unchanged", got)
+	}
+}
+
+func TestUpdateMetadata(t *testing.T) {
+	pdfFile := samplePDF()
+	if _, err := os.Stat(pdfFile); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	first := filepath.Join(tmpDir, "first.pdf")
+	err = UpdateMetadata(pdfFile, first, MetadataUpdate{Set: map[string]string{
+		"Title":        "Q3 (Report)",
+		"Author":       "Jane Doe",
+		"Department":   "Legal",
+		"CreationDate": "2024-03-01 10:00:00",
+		"Producer":     "Scanner 3000",
+	}}, "")
+	if err != nil {
+		t.Fatalf("UpdateMetadata() error = %v", err)
+	}
+
+	second := filepath.Join(tmpDir, "second.pdf")
+	err = UpdateMetadata(first, second, MetadataUpdate{
+		Set:     map[string]string{"ModDate": "2025-01-02"},
+		Clear:   []string{"Author", "department"},
+		SyncXMP: true,
+	}, "")
+	if err != nil {
+		t.Fatalf("UpdateMetadata() error = %v", err)
+	}
+	if err := api.ValidateFile(second, NewConfig("")); err != nil {
+		t.Fatalf("updated file is invalid: %v", err)
+	}
+
+	meta, err := GetMetadata(second, "")
+	if err != nil {
+		t.Fatalf("GetMetadata() error = %v", err)
+	}
+	if meta.Title != "Q3 (Report)" || meta.Author != "" || meta.Producer != "Scanner 3000" {
+		t.Errorf("GetMetadata() = %+v", meta)
+	}
+	if len(meta.Custom) != 0 {
+		t.Errorf("custom fields = %v, want none", meta.Custom)
+	}
+	if !strings.HasPrefix(meta.CreatedDate, "2024-03-01T10:00:00") || !strings.HasPrefix(meta.ModDate, "2025-01-02T00:00:00") {
+		t.Errorf("dates = %q, %q", meta.CreatedDate, meta.ModDate)
+	}
+
+	xmp, err := GetXMP(second, "")
+	if err != nil {
+		t.Fatalf("GetXMP() error = %v", err)
+	}
+	if !strings.Contains(xmp, "Q3 (Report)") || !strings.Contains(xmp, "<xmp:CreateDate>2024-03-01T10:00:00") {
+		t.Errorf("XMP not in sync:\n%s", xmp)
+	}
+	if strings.Contains(xmp, "Jane Doe") {
+		t.Error("XMP still contains the cleared author")
+	}
+}
+
+func TestUpdateMetadataInvalidDate(t *testing.T) {
+	err := UpdateMetadata(samplePDF(), filepath.Join(os.TempDir(), "unused.pdf"),
+		MetadataUpdate{Set: map[string]string{"CreationDate": "someday"}}, "")
+	if err == nil {
+		t.Error("UpdateMetadata() expected error for invalid date")
+	}
+}
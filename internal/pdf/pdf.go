@@ -3,8 +3,12 @@
 package pdf
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 
+	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 )
 
@@ -29,3 +33,19 @@ func pagesToStrings(pages []int) []string {
 	}
 	return result
 }
+
+// readContext reads and validates a PDF into a pdfcpu context for inspection.
+func readContext(path, password string) (*model.Context, error) {
+	cleanPath := filepath.Clean(path)
+	f, err := os.Open(cleanPath) // #nosec G304 -- path is cleaned
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	ctx, err := api.ReadAndValidate(f, NewConfig(password))
+	if err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
@@ -0,0 +1,398 @@
+package pdf
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/hhrutter/pkcs7"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Public-key encryption follows the PDF public-key security handler
+// (Adobe.PubSec, adbe.pkcs7.s5) with AES-256: a random seed and the
+// permissions are enveloped in CMS for every recipient, and the file key is
+// the SHA-256 hash of the seed and the envelopes.
+
+// allPermissions is the P value that grants every permission.
+const allPermissions int32 = -4
+
+// pkcs7Mu guards pkcs7.ContentEncryptionAlgorithm, a package variable.
+var pkcs7Mu sync.Mutex
+
+// Recipient is the certificate and private key of a recipient of a
+// public-key encrypted PDF.
+type Recipient struct {
+	Certificate *x509.Certificate
+	key         *rsa.PrivateKey
+}
+
+// LoadRecipientCertificates loads the certificates of recipients from a
+// PEM, DER (.cer, .crt) or PKCS#7 (.p7c) file.
+func LoadRecipientCertificates(path string) ([]*x509.Certificate, error) {
+	certs, err := pdfcpu.LoadCertificatesFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate %s: %w", filepath.Base(path), err)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate found in %s", filepath.Base(path))
+	}
+	return certs, nil
+}
+
+// LoadRecipient loads the certificate and private key of a recipient from
+// the same files as LoadSigner. Only RSA keys can decrypt.
+func LoadRecipient(certPath, keyPath, password string) (*Recipient, error) {
+	s, err := LoadSigner(certPath, keyPath, password)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := s.key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("only RSA keys can decrypt a PDF, not %T", s.key)
+	}
+	return &Recipient{Certificate: s.Certificate, key: key}, nil
+}
+
+// EncryptForRecipients encrypts a PDF with AES-256 so that any of the
+// recipients can open it with the private key of their certificate.
+func EncryptForRecipients(input, output string, recipients []*x509.Certificate) error {
+	if len(recipients) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+	for _, c := range recipients {
+		if _, ok := c.PublicKey.(*rsa.PublicKey); !ok {
+			return fmt.Errorf("certificate of %s: only RSA certificates can be used for encryption", c.Subject.CommonName)
+		}
+	}
+
+	ctx, err := readContext(input, "")
+	if err != nil {
+		return err
+	}
+	if ctx.Encrypt != nil {
+		return fmt.Errorf("PDF is already encrypted; decrypt it first")
+	}
+
+	seed := make([]byte, 20)
+	if _, err := rand.Read(seed); err != nil {
+		return err
+	}
+	content := append(seed, 0xFF, 0xFF, 0xFF, 0xFC) // allPermissions, big-endian
+
+	pkcs7Mu.Lock()
+	pkcs7.ContentEncryptionAlgorithm = pkcs7.EncryptionAlgorithmAES256CBC
+	envelope, err := pkcs7.Encrypt(content, recipients)
+	pkcs7Mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encrypt for recipients: %w", err)
+	}
+
+	cf := types.Dict{
+		"CFM":             types.Name("AESV3"),
+		"Length":          types.Integer(256),
+		"Recipients":      types.Array{types.HexLiteral(hex.EncodeToString(envelope))},
+		"EncryptMetadata": types.Boolean(true),
+	}
+	d := types.Dict{
+		"Filter":    types.Name("Adobe.PubSec"),
+		"SubFilter": types.Name("adbe.pkcs7.s5"),
+		"V":         types.Integer(5),
+		"Length":    types.Integer(256),
+		"CF":        types.Dict{"DefaultCryptFilter": cf},
+		"StmF":      types.Name("DefaultCryptFilter"),
+		"StrF":      types.Name("DefaultCryptFilter"),
+	}
+	ref, err := ctx.IndRefForNewObject(d)
+	if err != nil {
+		return err
+	}
+
+	ctx.Encrypt = ref
+	ctx.EncKey = pubSecFileKey(seed, [][]byte{envelope}, true)
+	ctx.E = &model.Enc{R: 5, V: 5, L: 256, P: int(allPermissions), Emd: true}
+	ctx.AES4Strings, ctx.AES4Streams, ctx.AES4EmbeddedStreams = true, true, true
+	return api.WriteContextFile(ctx, output)
+}
+
+// DecryptWithKey removes public-key encryption from a PDF using the private
+// key of one of its recipients.
+//
+// pdfcpu only reads the standard security handler, so the public-key
+// encryption dictionary is replaced in place by an equivalent standard one
+// for the same file key, protected by a one-time password.
+func DecryptWithKey(input, output string, recipient *Recipient) error {
+	data, err := os.ReadFile(filepath.Clean(input)) // #nosec G304 -- path is cleaned
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	password, err := randomPassword()
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, loc := range pubSecDicts(data) {
+		d, err := parseDictAt(data, loc)
+		if err != nil {
+			continue
+		}
+		std, err := standardEncryptDict(d, recipient, password)
+		if err != nil {
+			return err
+		}
+		s := std.PDFString()
+		if len(s) > loc[1]-loc[0] {
+			return fmt.Errorf("unsupported certificate encryption dictionary")
+		}
+		copy(data[loc[0]:loc[1]], s+string(bytes.Repeat([]byte{' '}, loc[1]-loc[0]-len(s))))
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("PDF does not use certificate security")
+	}
+
+	var buf bytes.Buffer
+	if err := api.Decrypt(bytes.NewReader(data), &buf, NewConfig(password)); err != nil {
+		return err
+	}
+	return fileio.AtomicWrite(output, buf.Bytes())
+}
+
+// pubSecFilter finds the Filter entry of a public-key encryption dictionary.
+var pubSecFilter = regexp.MustCompile(`/Filter\s*/Adobe\.PubSec\b`)
+
+// objHeader and objEnd match the start and end of an indirect object.
+var (
+	objHeader = regexp.MustCompile(`\d+\s+\d+\s+obj\b`)
+	objEnd    = regexp.MustCompile(`\bendobj\b`)
+)
+
+// pubSecDicts returns the byte ranges of the public-key encryption
+// dictionaries in data: the text between "obj" and "endobj" of every
+// object with an Adobe.PubSec filter. Encryption dictionaries are never
+// compressed, so they can be found in the raw file.
+func pubSecDicts(data []byte) [][2]int {
+	var locs [][2]int
+	for _, m := range pubSecFilter.FindAllIndex(data, -1) {
+		headers := objHeader.FindAllIndex(data[:m[0]], -1)
+		if len(headers) == 0 {
+			continue
+		}
+		start := headers[len(headers)-1][1]
+		end := objEnd.FindIndex(data[m[1]:])
+		if end == nil {
+			continue
+		}
+		if len(locs) > 0 && locs[len(locs)-1][0] == start {
+			continue
+		}
+		locs = append(locs, [2]int{start, m[1] + end[0]})
+	}
+	return locs
+}
+
+// parseDictAt parses the dictionary at loc in data.
+func parseDictAt(data []byte, loc [2]int) (types.Dict, error) {
+	s := string(data[loc[0]:loc[1]])
+	obj, err := model.ParseObject(&s)
+	if err != nil {
+		return nil, err
+	}
+	d, ok := obj.(types.Dict)
+	if !ok {
+		return nil, fmt.Errorf("encryption dictionary expected")
+	}
+	return d, nil
+}
+
+// standardEncryptDict recovers the file key of the public-key encryption
+// dictionary d and returns a standard AES-256 (revision 5) encryption
+// dictionary with the same file key and permissions for password.
+func standardEncryptDict(d types.Dict, recipient *Recipient, password string) (types.Dict, error) {
+	if v := d.IntEntry("V"); v == nil || *v != 5 {
+		return nil, fmt.Errorf("unsupported certificate encryption: only AES-256 is supported")
+	}
+	cfName := d.NameEntry("StmF")
+	if cfName == nil || *cfName == "Identity" {
+		cfName = d.NameEntry("StrF")
+	}
+	cfs := d.DictEntry("CF")
+	if cfName == nil || cfs == nil || cfs.DictEntry(*cfName) == nil {
+		return nil, fmt.Errorf("unsupported certificate encryption: missing crypt filter")
+	}
+	cf := cfs.DictEntry(*cfName)
+	if cfm := cf.NameEntry("CFM"); cfm == nil || *cfm != "AESV3" {
+		return nil, fmt.Errorf("unsupported certificate encryption: only AES-256 is supported")
+	}
+	encryptMetadata := true
+	if emd := cf.BooleanEntry("EncryptMetadata"); emd != nil {
+		encryptMetadata = *emd
+	}
+
+	var envelopes [][]byte
+	for _, obj := range cf.ArrayEntry("Recipients") {
+		env, err := stringBytes(obj)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient: %w", err)
+		}
+		envelopes = append(envelopes, env)
+	}
+
+	var content []byte
+	for _, env := range envelopes {
+		p7, err := pkcs7.Parse(env)
+		if err != nil {
+			continue
+		}
+		if content, err = p7.Decrypt(recipient.Certificate, recipient.key); err == nil {
+			break
+		}
+		content = nil
+	}
+	if len(content) < 20 {
+		return nil, fmt.Errorf("no recipient of the PDF matches the certificate of %s", recipient.Certificate.Subject.CommonName)
+	}
+	p := allPermissions
+	if len(content) >= 24 {
+		p = int32(binary.BigEndian.Uint32(content[20:24])) // #nosec G115 -- P is a signed 32-bit value
+	}
+	fileKey := pubSecFileKey(content[:20], envelopes, encryptMetadata)
+
+	std := types.Dict{
+		"Filter": types.Name("Standard"),
+		"V":      types.Integer(5),
+		"R":      types.Integer(5),
+		"Length": types.Integer(256),
+		"P":      types.Integer(p),
+		"CF": types.Dict{"StdCF": types.Dict{
+			"AuthEvent": types.Name("DocOpen"),
+			"CFM":       types.Name("AESV3"),
+			"Length":    types.Integer(256),
+		}},
+	}
+	for _, key := range []string{"StmF", "StrF", "EFF"} {
+		if n := d.NameEntry(key); n != nil {
+			name := "StdCF"
+			if *n == "Identity" {
+				name = "Identity"
+			}
+			std[key] = types.Name(name)
+		}
+	}
+	if !encryptMetadata {
+		std["EncryptMetadata"] = types.Boolean(false)
+	}
+	if err := setStandardKeys(std, fileKey, p, password); err != nil {
+		return nil, err
+	}
+	return std, nil
+}
+
+// setStandardKeys adds the U, UE, O, OE and Perms entries of revision 5 to d,
+// so that password opens the document as user and as owner.
+func setStandardKeys(d types.Dict, fileKey []byte, p int32, password string) error {
+	salts := make([]byte, 32)
+	if _, err := rand.Read(salts); err != nil {
+		return err
+	}
+	pw := []byte(password)
+
+	// U is the hash of the password and a validation salt, followed by the
+	// validation and key salts; UE is the file key encrypted with the hash
+	// of the password and the key salt. O and OE also cover U.
+	hashU := sha256.Sum256(append(append([]byte{}, pw...), salts[0:8]...))
+	u := append(hashU[:], salts[0:16]...)
+	ue, err := encryptFileKey(fileKey, append(append([]byte{}, pw...), salts[8:16]...))
+	if err != nil {
+		return err
+	}
+	hashO := sha256.Sum256(append(append(append([]byte{}, pw...), salts[16:24]...), u...))
+	o := append(hashO[:], salts[16:32]...)
+	oe, err := encryptFileKey(fileKey, append(append(append([]byte{}, pw...), salts[24:32]...), u...))
+	if err != nil {
+		return err
+	}
+
+	block, err := aes.NewCipher(fileKey)
+	if err != nil {
+		return err
+	}
+	perms := make([]byte, 16)
+	binary.LittleEndian.PutUint32(perms, uint32(p))
+	copy(perms[4:], []byte{0xFF, 0xFF, 0xFF, 0xFF, 'T', 'a', 'd', 'b'})
+	if _, ok := d["EncryptMetadata"]; ok {
+		perms[8] = 'F'
+	}
+	if _, err := rand.Read(perms[12:]); err != nil {
+		return err
+	}
+	block.Encrypt(perms, perms)
+
+	for key, b := range map[string][]byte{"U": u, "UE": ue, "O": o, "OE": oe, "Perms": perms} {
+		d[key] = types.HexLiteral(hex.EncodeToString(b))
+	}
+	return nil
+}
+
+// encryptFileKey encrypts the file key with the SHA-256 hash of input as
+// AES-256 key, without an initialization vector.
+func encryptFileKey(fileKey, input []byte) ([]byte, error) {
+	key := sha256.Sum256(input)
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(fileKey))
+	cipher.NewCBCEncrypter(block, make([]byte, aes.BlockSize)).CryptBlocks(out, fileKey)
+	return out, nil
+}
+
+// pubSecFileKey computes the AES-256 file key from the seed and the
+// recipient envelopes.
+func pubSecFileKey(seed []byte, envelopes [][]byte, encryptMetadata bool) []byte {
+	h := sha256.New()
+	h.Write(seed)
+	for _, env := range envelopes {
+		h.Write(env)
+	}
+	if !encryptMetadata {
+		h.Write([]byte{0xFF, 0xFF, 0xFF, 0xFF})
+	}
+	return h.Sum(nil)
+}
+
+// stringBytes returns the raw bytes of a string object.
+func stringBytes(obj types.Object) ([]byte, error) {
+	switch s := obj.(type) {
+	case types.HexLiteral:
+		return s.Bytes()
+	case types.StringLiteral:
+		return types.Unescape(s.Value())
+	}
+	return nil, fmt.Errorf("string expected")
+}
+
+// randomPassword returns a one-time password for the standard security handler.
+func randomPassword() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,126 @@
+package pdf
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeRecipient writes a self-signed RSA certificate for name and its
+// private key to PEM files.
+func writeRecipient(t *testing.T, name string) (certPath, keyPath string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	certPath = filepath.Join(dir, name+".pem")
+	keyPath = filepath.Join(dir, name+".key")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(certPath, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certPath, keyPath
+}
+
+func TestEncryptForRecipients(t *testing.T) {
+	aliceCert, aliceKey := writeRecipient(t, "alice")
+	bobCert, bobKey := writeRecipient(t, "bob")
+	eveCert, eveKey := writeRecipient(t, "eve")
+
+	var certs []*x509.Certificate
+	for _, path := range []string{aliceCert, bobCert} {
+		c, err := LoadRecipientCertificates(path)
+		if err != nil {
+			t.Fatalf("LoadRecipientCertificates() error = %v", err)
+		}
+		certs = append(certs, c...)
+	}
+
+	dir := t.TempDir()
+	encrypted := filepath.Join(dir, "encrypted.pdf")
+	if err := EncryptForRecipients(samplePDF(), encrypted, certs); err != nil {
+		t.Fatalf("EncryptForRecipients() error = %v", err)
+	}
+	data, _ := os.ReadFile(encrypted)
+	if !strings.Contains(string(data), "/Adobe.PubSec") {
+		t.Error("output should use the public-key security handler")
+	}
+	if _, err := GetInfo(encrypted, ""); err == nil {
+		t.Error("reading the encrypted PDF without a key should fail")
+	}
+	if err := EncryptForRecipients(encrypted, filepath.Join(dir, "twice.pdf"), certs); err == nil {
+		t.Error("EncryptForRecipients() of an encrypted PDF should fail")
+	}
+
+	want, err := GetInfo(samplePDF(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []struct{ cert, key string }{{aliceCert, aliceKey}, {bobCert, bobKey}} {
+		recipient, err := LoadRecipient(r.cert, r.key, "")
+		if err != nil {
+			t.Fatalf("LoadRecipient() error = %v", err)
+		}
+		decrypted := filepath.Join(dir, "decrypted.pdf")
+		if err := DecryptWithKey(encrypted, decrypted, recipient); err != nil {
+			t.Fatalf("DecryptWithKey(%s) error = %v", recipient.Certificate.Subject.CommonName, err)
+		}
+		info, err := GetInfo(decrypted, "")
+		if err != nil {
+			t.Fatalf("GetInfo() of the decrypted PDF error = %v", err)
+		}
+		if info.Encrypted || info.Pages != want.Pages || info.Title != want.Title {
+			t.Errorf("decrypted info = %+v, want %+v", info, want)
+		}
+	}
+
+	eve, err := LoadRecipient(eveCert, eveKey, "")
+	if err != nil {
+		t.Fatalf("LoadRecipient() error = %v", err)
+	}
+	if err := DecryptWithKey(encrypted, filepath.Join(dir, "eve.pdf"), eve); err == nil || !strings.Contains(err.Error(), "certificate of eve") {
+		t.Errorf("DecryptWithKey() with another key error = %v", err)
+	}
+	if err := DecryptWithKey(samplePDF(), filepath.Join(dir, "plain.pdf"), eve); err == nil {
+		t.Error("DecryptWithKey() of an unencrypted PDF should fail")
+	}
+}
+
+func TestEncryptForRecipientsErrors(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "out.pdf")
+	if err := EncryptForRecipients(samplePDF(), output, nil); err == nil {
+		t.Error("EncryptForRecipients() without recipients should fail")
+	}
+	pki := newTestPKI(t)
+	if err := EncryptForRecipients(samplePDF(), output, []*x509.Certificate{pki.cert}); err == nil || !strings.Contains(err.Error(), "RSA") {
+		t.Errorf("EncryptForRecipients() with an ECDSA certificate error = %v", err)
+	}
+	if _, err := LoadRecipient(pki.writePEM(t), "", ""); err == nil {
+		t.Error("LoadRecipient() with an ECDSA key should fail")
+	}
+}
@@ -0,0 +1,651 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// redactionPadding is the space in points added around each redaction box.
+const redactionPadding = 1.0
+
+// RedactOptions controls what is redacted and how the redacted areas are drawn.
+type RedactOptions struct {
+	Patterns []*regexp.Regexp // text to redact
+	Color    string           // box color: name (black), #RRGGBB or "r g b"; empty uses black
+}
+
+// RedactionMatch is a piece of page text matched by a redaction pattern.
+type RedactionMatch struct {
+	Page  int
+	Text  string
+	Boxes []Rect // one box per line the match spans, in user space
+}
+
+// FindRedactions returns the text on the selected pages (nil for all pages) that
+// matches any of the patterns, without changing the file.
+func FindRedactions(input string, patterns []*regexp.Regexp, pages []int, password string) ([]RedactionMatch, error) {
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []RedactionMatch
+	for _, page := range selectedPages(pages, ctx.PageCount) {
+		pt, err := readPageText(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		found, _ := pt.match(patterns)
+		matches = append(matches, found...)
+	}
+	return matches, nil
+}
+
+// Redact removes the text matching any of the patterns from the selected pages
+// (nil for all pages) and covers it with opaque boxes. The text operators that
+// draw the matched characters are rewritten, so the text cannot be recovered by
+// copying or extracting it. Other text keeps its position.
+//
+// Text inside form XObjects and annotations is not searched; UnsearchedPages
+// lists the pages that have such text. Images and vector graphics under a box
+// are covered, not removed.
+func Redact(input, output string, opts RedactOptions, pages []int, password string) ([]RedactionMatch, error) {
+	if len(opts.Patterns) == 0 {
+		return nil, fmt.Errorf("no redaction patterns")
+	}
+	fill, err := redactionColor(opts.Color)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []RedactionMatch
+	for _, page := range selectedPages(pages, ctx.PageCount) {
+		pt, err := readPageText(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		found, removed := pt.match(opts.Patterns)
+		if len(found) == 0 {
+			continue
+		}
+		matches = append(matches, found...)
+
+		content := pt.rewrite(removed)
+		content = append(content, redactionBoxes(found, fill)...)
+		if err := setPageContent(ctx, pt.dict, content); err != nil {
+			return nil, fmt.Errorf("failed to update page %d: %w", page, err)
+		}
+	}
+
+	if err := api.WriteContextFile(ctx, output); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// UnsearchedPages returns the selected pages (nil for all pages) that may draw
+// text Redact does not search: text in form XObjects or in the appearance
+// streams of annotations. Matches in that text are neither found nor removed.
+func UnsearchedPages(input string, pages []int, password string) ([]int, error) {
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return nil, err
+	}
+
+	var unsearched []int
+	for _, page := range selectedPages(pages, ctx.PageCount) {
+		d, _, _, err := ctx.PageDict(page, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read page %d: %w", page, err)
+		}
+		if formsHaveText(ctx, d["Resources"], 0) || annotationsHaveText(ctx, d) {
+			unsearched = append(unsearched, page)
+		}
+	}
+	return unsearched, nil
+}
+
+// maxFormDepth limits how deeply nested form XObjects are checked for text.
+const maxFormDepth = 8
+
+// formsHaveText reports whether a form XObject in the resources res, or in
+// the resources of those forms, shows text.
+func formsHaveText(ctx *model.Context, res types.Object, depth int) bool {
+	if depth > maxFormDepth {
+		return false
+	}
+	rd, err := ctx.DereferenceDict(res)
+	if err != nil || rd == nil {
+		return false
+	}
+	xobjects, err := ctx.DereferenceDict(rd["XObject"])
+	if err != nil {
+		return false
+	}
+	for _, o := range xobjects {
+		sd, _, err := ctx.DereferenceStreamDict(o)
+		if err != nil || sd == nil {
+			continue
+		}
+		if st := sd.Subtype(); st != nil && *st == "Form" && streamHasText(ctx, sd, depth+1) {
+			return true
+		}
+	}
+	return false
+}
+
+// annotationsHaveText reports whether an appearance stream of an annotation
+// of the page shows text.
+func annotationsHaveText(ctx *model.Context, page types.Dict) bool {
+	annots, err := ctx.DereferenceArray(page["Annots"])
+	if err != nil {
+		return false
+	}
+	for _, a := range annots {
+		ad, err := ctx.DereferenceDict(a)
+		if err != nil || ad == nil {
+			continue
+		}
+		ap, err := ctx.DereferenceDict(ad["AP"])
+		if err != nil {
+			continue
+		}
+		// Each appearance is a stream or a dictionary of streams, one per state.
+		for _, o := range ap {
+			appearance, err := ctx.Dereference(o)
+			if err != nil {
+				continue
+			}
+			states := []types.Object{appearance}
+			if d, ok := appearance.(types.Dict); ok {
+				states = states[:0]
+				for _, state := range d {
+					states = append(states, state)
+				}
+			}
+			for _, state := range states {
+				if sd, _, err := ctx.DereferenceStreamDict(state); err == nil && sd != nil && streamHasText(ctx, sd, 1) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// streamHasText reports whether a form or appearance stream, or a form it
+// uses, shows text. A stream that cannot be read counts as showing text.
+func streamHasText(ctx *model.Context, sd *types.StreamDict, depth int) bool {
+	if err := sd.Decode(); err != nil {
+		return true
+	}
+	ops, err := parseContentOps(sd.Content)
+	if err != nil {
+		return true
+	}
+	for _, op := range ops {
+		switch op.name {
+		case "Tj", "TJ", "'", "\"":
+			return true
+		}
+	}
+	return formsHaveText(ctx, sd.Dict["Resources"], depth)
+}
+
+// selectedPages returns pages, or every page of the document if pages is empty.
+func selectedPages(pages []int, total int) []int {
+	if len(pages) > 0 {
+		return pages
+	}
+	all := make([]int, total)
+	for i := range all {
+		all[i] = i + 1
+	}
+	return all
+}
+
+// redactionColor parses the box color, defaulting to black.
+func redactionColor(s string) (color.SimpleColor, error) {
+	if s == "" {
+		return color.Black, nil
+	}
+	c, err := color.ParseColor(s)
+	if err != nil {
+		return c, fmt.Errorf("invalid color %q: use a name (black), #RRGGBB or \"r g b\"", s)
+	}
+	return c, nil
+}
+
+// matrix is a PDF transformation matrix [a b c d e f].
+type matrix [6]float64
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+// mul returns m × n, i.e. the transformation m followed by n.
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[1]*n[2],
+		m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2],
+		m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4],
+		m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+// apply transforms the point (x, y).
+func (m matrix) apply(x, y float64) (float64, float64) {
+	return x*m[0] + y*m[2] + m[4], x*m[1] + y*m[3] + m[5]
+}
+
+// translation returns a matrix that moves by (tx, ty).
+func translation(tx, ty float64) matrix {
+	return matrix{1, 0, 0, 1, tx, ty}
+}
+
+// glyph is one shown character code with its position on the page.
+type glyph struct {
+	text    string  // decoded Unicode text, empty if unknown
+	code    string  // character code bytes
+	op      int     // index of the showing operator
+	operand int     // index of the string operand within the operator
+	box     Rect    // glyph bounds in user space
+	x, y    float64 // origin in user space
+	endX    float64 // origin of the next glyph in user space
+	size    float64 // font size in user space
+	adjust  float64 // TJ adjustment that advances like this glyph
+	start   int     // byte offset of text in the page text
+	end     int
+}
+
+// textState is the part of the graphics state needed to position text.
+type textState struct {
+	ctm     matrix
+	tc, tw  float64 // character and word spacing
+	th      float64 // horizontal scaling
+	tl      float64 // leading
+	tfs     float64 // font size
+	rise    float64
+	font    *fontInfo
+	tm, tlm matrix
+}
+
+// pageText is the positioned text of one page together with its parsed content.
+type pageText struct {
+	page   int
+	dict   types.Dict
+	data   []byte
+	ops    []contentOp
+	glyphs []glyph
+	text   string
+}
+
+// readPageText parses the content of a page and positions every glyph.
+func readPageText(ctx *model.Context, page int) (*pageText, error) {
+	d, _, _, err := ctx.PageDict(page, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page %d: %w", page, err)
+	}
+
+	pt := &pageText{page: page, dict: d}
+	data, err := ctx.PageContent(d, page)
+	if err != nil && err != model.ErrNoContent {
+		return nil, fmt.Errorf("failed to read content of page %d: %w", page, err)
+	}
+	pt.data = data
+	if pt.ops, err = parseContentOps(data); err != nil {
+		return nil, fmt.Errorf("failed to parse content of page %d: %w", page, err)
+	}
+
+	fonts := pageFonts(ctx, d)
+	pt.walk(fonts)
+	pt.buildText()
+	return pt, nil
+}
+
+// pageFonts returns a lazy lookup of the fonts in a page's resources.
+func pageFonts(ctx *model.Context, page types.Dict) func(name string) *fontInfo {
+	var fontDict types.Dict
+	if res, err := ctx.DereferenceDict(page["Resources"]); err == nil && res != nil {
+		fontDict, _ = ctx.DereferenceDict(res["Font"])
+	}
+
+	cache := map[string]*fontInfo{}
+	return func(name string) *fontInfo {
+		if f, ok := cache[name]; ok {
+			return f
+		}
+		f := loadFont(ctx, fontDict[name])
+		cache[name] = f
+		return f
+	}
+}
+
+// walk interprets the text and graphics state operators of the page and
+// records every shown glyph.
+func (pt *pageText) walk(fonts func(name string) *fontInfo) {
+	st := textState{ctm: identity, th: 1, tm: identity, tlm: identity, font: loadFont(nil, nil)}
+	var stack []textState
+
+	for i, op := range pt.ops {
+		args := op.operands
+		num := func(k int) float64 {
+			if k < len(args) {
+				return args[k].number()
+			}
+			return 0
+		}
+
+		switch op.name {
+		case "q":
+			stack = append(stack, st)
+		case "Q":
+			if n := len(stack); n > 0 {
+				st = stack[n-1]
+				stack = stack[:n-1]
+			}
+		case "cm":
+			if len(args) == 6 {
+				st.ctm = matrix{num(0), num(1), num(2), num(3), num(4), num(5)}.mul(st.ctm)
+			}
+		case "BT":
+			st.tm, st.tlm = identity, identity
+		case "Tc":
+			st.tc = num(0)
+		case "Tw":
+			st.tw = num(0)
+		case "Tz":
+			st.th = num(0) / 100
+		case "TL":
+			st.tl = num(0)
+		case "Ts":
+			st.rise = num(0)
+		case "Tf":
+			if len(args) == 2 && args[0].kind == tokName {
+				st.font = fonts(args[0].text)
+				st.tfs = num(1)
+			}
+		case "Td", "TD":
+			if op.name == "TD" {
+				st.tl = -num(1)
+			}
+			st.tlm = translation(num(0), num(1)).mul(st.tlm)
+			st.tm = st.tlm
+		case "Tm":
+			if len(args) == 6 {
+				st.tlm = matrix{num(0), num(1), num(2), num(3), num(4), num(5)}
+				st.tm = st.tlm
+			}
+		case "T*":
+			st.nextLine()
+		case "Tj":
+			pt.show(&st, i, len(args)-1)
+		case "'":
+			st.nextLine()
+			pt.show(&st, i, len(args)-1)
+		case "\"":
+			st.tw, st.tc = num(0), num(1)
+			st.nextLine()
+			pt.show(&st, i, len(args)-1)
+		case "TJ":
+			for k, a := range args {
+				switch a.kind {
+				case tokString:
+					pt.show(&st, i, k)
+				case tokNumber:
+					st.tm = translation(-a.number()/1000*st.tfs*st.th, 0).mul(st.tm)
+				}
+			}
+		}
+	}
+}
+
+// nextLine moves to the start of the next line.
+func (st *textState) nextLine() {
+	st.tlm = translation(0, -st.tl).mul(st.tlm)
+	st.tm = st.tlm
+}
+
+// show records the glyphs of the string operand k of operator op and advances the text matrix.
+func (pt *pageText) show(st *textState, op, k int) {
+	args := pt.ops[op].operands
+	if k < 0 || k >= len(args) || args[k].kind != tokString {
+		return
+	}
+
+	f := st.font
+	for _, code := range f.splitCodes(args[k].text) {
+		w0 := f.width(code) / 1000
+		spacing := st.tc
+		if isSingleByteSpace(code) {
+			spacing += st.tw
+		}
+
+		trm := matrix{st.tfs * st.th, 0, 0, st.tfs, 0, st.rise}.mul(st.tm).mul(st.ctm)
+		g := glyph{text: f.decode(code), code: code, op: op, operand: k}
+		g.x, g.y = trm.apply(0, 0)
+		g.endX, _ = trm.apply(w0, 0)
+		ux, uy := trm.apply(0, 1)
+		g.size = math.Hypot(ux-g.x, uy-g.y)
+		g.box = transformedBounds(trm, 0, f.descent/1000, w0, f.ascent/1000)
+		if st.tfs != 0 {
+			g.adjust = -(w0*1000 + spacing*1000/st.tfs)
+		}
+		pt.glyphs = append(pt.glyphs, g)
+
+		st.tm = translation((w0*st.tfs+spacing)*st.th, 0).mul(st.tm)
+	}
+}
+
+// transformedBounds returns the bounding box of the rectangle (x0, y0)-(x1, y1) transformed by m.
+func transformedBounds(m matrix, x0, y0, x1, y1 float64) Rect {
+	r := Rect{LLX: math.Inf(1), LLY: math.Inf(1), URX: math.Inf(-1), URY: math.Inf(-1)}
+	for _, p := range [][2]float64{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}} {
+		x, y := m.apply(p[0], p[1])
+		r.LLX, r.LLY = math.Min(r.LLX, x), math.Min(r.LLY, y)
+		r.URX, r.URY = math.Max(r.URX, x), math.Max(r.URY, y)
+	}
+	return r
+}
+
+// buildText joins the glyphs into the page text, inserting line breaks and
+// spaces where the layout implies them, and records each glyph's byte range.
+func (pt *pageText) buildText() {
+	var b strings.Builder
+	for i := range pt.glyphs {
+		g := &pt.glyphs[i]
+		if i > 0 {
+			prev := pt.glyphs[i-1]
+			size := math.Max(math.Max(g.size, prev.size), 1)
+			switch {
+			case math.Abs(g.y-prev.y) > size/2:
+				b.WriteByte('\n')
+			case g.x-prev.endX > size/5 && prev.text != " " && g.text != " ":
+				b.WriteByte(' ')
+			}
+		}
+		g.start = b.Len()
+		b.WriteString(g.text)
+		g.end = b.Len()
+	}
+	pt.text = b.String()
+}
+
+// match finds the pattern matches on the page and returns them together
+// with the indexes of the glyphs they cover.
+func (pt *pageText) match(patterns []*regexp.Regexp) ([]RedactionMatch, map[int]bool) {
+	removed := map[int]bool{}
+	var matches []RedactionMatch
+	for _, re := range patterns {
+		for _, loc := range re.FindAllStringIndex(pt.text, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			m := RedactionMatch{Page: pt.page, Text: pt.text[loc[0]:loc[1]]}
+			var line *Rect
+			lastY := math.NaN()
+			for i, g := range pt.glyphs {
+				covered := g.start < loc[1] && g.end > loc[0]
+				if g.start == g.end {
+					covered = g.start > loc[0] && g.start < loc[1]
+				}
+				if !covered {
+					continue
+				}
+				removed[i] = true
+				if line == nil || math.Abs(g.y-lastY) > math.Max(g.size, 1)/2 {
+					m.Boxes = append(m.Boxes, g.box)
+					line = &m.Boxes[len(m.Boxes)-1]
+					lastY = g.y
+					continue
+				}
+				line.LLX, line.LLY = math.Min(line.LLX, g.box.LLX), math.Min(line.LLY, g.box.LLY)
+				line.URX, line.URY = math.Max(line.URX, g.box.URX), math.Max(line.URY, g.box.URY)
+			}
+			if len(m.Boxes) > 0 {
+				matches = append(matches, m)
+			}
+		}
+	}
+	return matches, removed
+}
+
+// rewrite returns the page content with the removed glyphs taken out of their
+// text operators. Each removed glyph is replaced by a TJ adjustment of the same
+// width, so the remaining text does not move.
+func (pt *pageText) rewrite(removed map[int]bool) []byte {
+	byOp := map[int][]int{}
+	for i := range pt.glyphs {
+		if removed[i] {
+			op := pt.glyphs[i].op
+			if _, ok := byOp[op]; !ok {
+				byOp[op] = nil
+			}
+		}
+	}
+	for i, g := range pt.glyphs {
+		if _, ok := byOp[g.op]; ok {
+			byOp[g.op] = append(byOp[g.op], i)
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString("q\n")
+	last := 0
+	for i, op := range pt.ops {
+		glyphs, ok := byOp[i]
+		if !ok {
+			continue
+		}
+		out.Write(pt.data[last:op.start])
+		out.WriteString(pt.rewriteOp(op, glyphs, removed))
+		last = op.end
+	}
+	out.Write(pt.data[last:])
+	out.WriteString("\nQ\n")
+	return out.Bytes()
+}
+
+// rewriteOp returns a replacement for a text showing operator without the removed glyphs.
+func (pt *pageText) rewriteOp(op contentOp, glyphs []int, removed map[int]bool) string {
+	var parts []string
+	var kept []byte
+	pending := 0.0
+
+	flushText := func() {
+		if len(kept) > 0 {
+			parts = append(parts, encodeHexString(kept))
+			kept = nil
+		}
+	}
+	flushAdjust := func() {
+		if pending != 0 {
+			parts = append(parts, formatFloat(math.Round(pending*1000)/1000))
+			pending = 0
+		}
+	}
+
+	next := 0
+	first := 0
+	var prefix string
+	switch op.name {
+	case "'":
+		prefix = "T* "
+	case "\"":
+		if len(op.operands) >= 2 {
+			prefix = op.operands[0].text + " Tw " + op.operands[1].text + " Tc T* "
+		}
+		first = 2
+	}
+
+	for k := first; k < len(op.operands); k++ {
+		a := op.operands[k]
+		switch a.kind {
+		case tokNumber:
+			flushText()
+			flushAdjust()
+			parts = append(parts, a.text)
+		case tokString:
+			for next < len(glyphs) && pt.glyphs[glyphs[next]].operand == k {
+				idx := glyphs[next]
+				next++
+				if removed[idx] {
+					flushText()
+					pending += pt.glyphs[idx].adjust
+					continue
+				}
+				flushAdjust()
+				kept = append(kept, pt.glyphs[idx].code...)
+			}
+		}
+	}
+	flushText()
+	flushAdjust()
+
+	return prefix + "[" + strings.Join(parts, " ") + "] TJ"
+}
+
+// redactionBoxes returns content that fills the match boxes with an opaque color.
+func redactionBoxes(matches []RedactionMatch, fill color.SimpleColor) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "q %s %s %s rg\n", formatFloat(float64(fill.R)), formatFloat(float64(fill.G)), formatFloat(float64(fill.B)))
+	for _, m := range matches {
+		for _, r := range m.Boxes {
+			fmt.Fprintf(&b, "%.2f %.2f %.2f %.2f re f\n",
+				r.LLX-redactionPadding, r.LLY-redactionPadding,
+				r.Width()+2*redactionPadding, r.Height()+2*redactionPadding)
+		}
+	}
+	b.WriteString("Q\n")
+	return b.Bytes()
+}
+
+// setPageContent replaces the content streams of a page with a single new stream.
+func setPageContent(ctx *model.Context, page types.Dict, content []byte) error {
+	sd, err := ctx.NewStreamDictForBuf(content)
+	if err != nil {
+		return err
+	}
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+	ir, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		return err
+	}
+	page.Update("Contents", *ir)
+	return nil
+}
@@ -0,0 +1,194 @@
+package pdf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseContentOps(t *testing.T) {
+	data := []byte("BT /F1 12 Tf 72 700 Td (a\\(b\\)) Tj [<4142> -250 (C)] TJ ET % comment\n")
+	ops, err := parseContentOps(data)
+	if err != nil {
+		t.Fatalf("parseContentOps() error = %v", err)
+	}
+
+	var names []string
+	for _, op := range ops {
+		names = append(names, op.name)
+	}
+	if got := strings.Join(names, " "); got != "BT Tf Td Tj TJ ET" {
+		t.Fatalf("operators = %q", got)
+	}
+	if got := ops[3].operands[0].text; got != "a(b)" {
+		t.Errorf("Tj operand = %q, want %q", got, "a(b)")
+	}
+	if got := ops[4].operands[1].text; got != "AB" {
+		t.Errorf("hex operand = %q, want %q", got, "AB")
+	}
+	if got := string(data[ops[2].start:ops[2].end]); got != "72 700 Td" {
+		t.Errorf("Td span = %q", got)
+	}
+}
+
+func TestParseCMap(t *testing.T) {
+	data := []byte(`begincodespacerange <0000> <FFFF> endcodespacerange
+2 beginbfchar <0003> <0020> <0011> <0041> endbfchar
+1 beginbfrange <0020> <0022> <0061> endbfrange`)
+	m := parseCMap(data)
+	if m == nil {
+		t.Fatal("parseCMap() = nil")
+	}
+	tests := map[string]string{"\x00\x03": " ", "\x00\x11": "A", "\x00\x20": "a", "\x00\x22": "c"}
+	for code, want := range tests {
+		if got := m.chars[code]; got != want {
+			t.Errorf("chars[%X] = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestFindRedactions(t *testing.T) {
+	input := samplePDF()
+	if _, err := os.Stat(input); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	matches, err := FindRedactions(input, []*regexp.Regexp{regexp.MustCompile(`Page \d`)}, []int{1, 3}, "")
+	if err != nil {
+		t.Fatalf("FindRedactions() error = %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("FindRedactions() found %d matches, want 2", len(matches))
+	}
+	if matches[1].Page != 3 || matches[1].Text != "Page 3" || len(matches[1].Boxes) != 1 {
+		t.Errorf("second match = %+v", matches[1])
+	}
+}
+
+func TestRedact(t *testing.T) {
+	input := samplePDF()
+	if _, err := os.Stat(input); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output := filepath.Join(tmpDir, "redacted.pdf")
+	opts := RedactOptions{Patterns: []*regexp.Regexp{regexp.MustCompile(`Page 2`)}}
+	matches, err := Redact(input, output, opts, nil, "")
+	if err != nil {
+		t.Fatalf("Redact() error = %v", err)
+	}
+	if len(matches) != 1 || matches[0].Page != 2 {
+		t.Fatalf("Redact() matches = %+v", matches)
+	}
+
+	text, err := ExtractText(context.Background(), output, nil, "")
+	if err != nil {
+		t.Fatalf("ExtractText() error = %v", err)
+	}
+	if strings.Contains(text, "Page 2") {
+		t.Error("redacted text is still extractable")
+	}
+	if !strings.Contains(text, "Page 1") || !strings.Contains(text, "Page 3") {
+		t.Errorf("other pages lost their text: %q", text)
+	}
+
+	// Nothing left to find in the redacted file.
+	left, err := FindRedactions(output, opts.Patterns, nil, "")
+	if err != nil {
+		t.Fatalf("FindRedactions() error = %v", err)
+	}
+	if len(left) != 0 {
+		t.Errorf("FindRedactions() after redaction = %+v", left)
+	}
+}
+
+func TestRedactPartialKeepsText(t *testing.T) {
+	input := samplePDF()
+	if _, err := os.Stat(input); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output := filepath.Join(tmpDir, "redacted.pdf")
+	opts := RedactOptions{Patterns: []*regexp.Regexp{regexp.MustCompile(`age`)}, Color: "#FF0000"}
+	if _, err := Redact(input, output, opts, []int{1}, ""); err != nil {
+		t.Fatalf("Redact() error = %v", err)
+	}
+
+	ctx, err := readContext(output, "")
+	if err != nil {
+		t.Fatalf("readContext() error = %v", err)
+	}
+	pt, err := readPageText(ctx, 1)
+	if err != nil {
+		t.Fatalf("readPageText() error = %v", err)
+	}
+	if pt.text != "P 1" {
+		t.Errorf("page text = %q, want %q", pt.text, "P 1")
+	}
+	// The "1" must not move when the characters before it are removed.
+	if last := pt.glyphs[len(pt.glyphs)-1]; last.x < 160 || last.x > 164 {
+		t.Errorf("last glyph x = %g, want about 162", last.x)
+	}
+}
+
+func TestRedactInvalidColor(t *testing.T) {
+	opts := RedactOptions{Patterns: []*regexp.Regexp{regexp.MustCompile(`x`)}, Color: "nope"}
+	if _, err := Redact(samplePDF(), "out.pdf", opts, nil, ""); err == nil {
+		t.Error("Redact() with invalid color should fail")
+	}
+}
+
+func TestUnsearchedPages(t *testing.T) {
+	input := samplePDF()
+	if _, err := os.Stat(input); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	// A text watermark is drawn from a form XObject on every page.
+	stamped := filepath.Join(t.TempDir(), "stamped.pdf")
+	if err := AddWatermark(input, stamped, "SSN 123-45-6789", nil, ""); err != nil {
+		t.Fatalf("AddWatermark() error = %v", err)
+	}
+	patterns := []*regexp.Regexp{regexp.MustCompile(`\d{3}-\d{2}-\d{4}`)}
+	if matches, err := FindRedactions(stamped, patterns, nil, ""); err != nil || len(matches) != 0 {
+		t.Fatalf("FindRedactions() = %+v, %v; text in form XObjects is not searched", matches, err)
+	}
+
+	tests := []struct {
+		name  string
+		file  string
+		pages []int
+		want  []int
+	}{
+		{"no forms", input, nil, nil},
+		{"watermark", stamped, nil, []int{1, 2, 3}},
+		{"selected pages", stamped, []int{2}, []int{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnsearchedPages(tt.file, tt.pages, "")
+			if err != nil {
+				t.Fatalf("UnsearchedPages() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("UnsearchedPages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
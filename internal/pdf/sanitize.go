@@ -0,0 +1,557 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// activeActions are the action types removed by Sanitize besides JavaScript:
+// they launch programs, open or submit to other files, or change form data.
+var activeActions = map[string]bool{
+	"Launch":     true,
+	"SubmitForm": true,
+	"ImportData": true,
+	"ResetForm":  true,
+	"GoToR":      true,
+	"GoToE":      true,
+	"Rendition":  true,
+	"Movie":      true,
+	"Sound":      true,
+}
+
+// SanitizeOptions controls what Sanitize removes in addition to the defaults.
+type SanitizeOptions struct {
+	Annotations bool // remove all annotations, including links and form fields
+}
+
+// SanitizeReport counts what Sanitize removed.
+type SanitizeReport struct {
+	JavaScript    int  `json:"javascript"`     // document scripts and JavaScript actions
+	Actions       int  `json:"actions"`        // launch, form and other active actions
+	OpenAction    bool `json:"open_action"`    // document open action
+	EmbeddedFiles int  `json:"embedded_files"` // attachments and associated files
+	Metadata      int  `json:"metadata"`       // XMP metadata streams
+	InfoEntries   int  `json:"info_entries"`   // document information entries
+	PrivateData   int  `json:"private_data"`   // application private data (PieceInfo)
+	HiddenLayers  int  `json:"hidden_layers"`  // optional content groups hidden by default
+	HiddenContent int  `json:"hidden_content"` // content, XObjects and annotations on hidden layers
+	Annotations   int  `json:"annotations"`
+	UnusedObjects int  `json:"unused_objects"`
+}
+
+// Sanitize writes a copy of input without hidden data and active content:
+// JavaScript, active actions, the open action, embedded files, XMP and
+// document information metadata, private application data and content on
+// hidden layers. Objects no longer referenced are dropped from the output.
+//
+// pdfcpu always writes a document information dictionary, so the output keeps
+// one holding only the producer and the modification dates.
+func Sanitize(input, output string, opts SanitizeOptions, password string) (*SanitizeReport, error) {
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SanitizeReport{}
+	root, err := ctx.Catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	hidden := hiddenLayers(ctx, root)
+	if err := removeAnnotations(ctx, opts, hidden, report); err != nil {
+		return nil, err
+	}
+	if err := removeHiddenLayers(ctx, root, hidden, report); err != nil {
+		return nil, err
+	}
+	removeDocumentEntries(ctx, root, report)
+	removeInfo(ctx, report)
+
+	for _, entry := range ctx.Table {
+		if entry == nil || entry.Free || entry.Object == nil {
+			continue
+		}
+		visitDicts(entry.Object, func(d types.Dict) {
+			sanitizeDict(ctx, d, report)
+		})
+	}
+
+	report.UnusedObjects = countUnusedObjects(ctx)
+
+	if err := api.WriteContextFile(ctx, output); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// removeDocumentEntries removes scripts, attachments, the open action and
+// the XFA form from the document catalog.
+func removeDocumentEntries(ctx *model.Context, root types.Dict, report *SanitizeReport) {
+	if _, found := root.Find("OpenAction"); found {
+		root.Delete("OpenAction")
+		report.OpenAction = true
+	}
+
+	if names, err := ctx.DereferenceDict(root["Names"]); err == nil && names != nil {
+		if o, found := names.Find("JavaScript"); found {
+			report.JavaScript += countNameTree(ctx, o)
+			names.Delete("JavaScript")
+		}
+		if o, found := names.Find("EmbeddedFiles"); found {
+			report.EmbeddedFiles += countNameTree(ctx, o)
+			names.Delete("EmbeddedFiles")
+		}
+		if len(names) == 0 {
+			root.Delete("Names")
+		}
+	}
+	// pdfcpu writes name trees from its cache, not from the catalog.
+	delete(ctx.Names, "JavaScript")
+	delete(ctx.Names, "EmbeddedFiles")
+	if _, found := root.Find("Collection"); found {
+		root.Delete("Collection")
+	}
+
+	if form, err := ctx.DereferenceDict(root["AcroForm"]); err == nil && form != nil {
+		if _, found := form.Find("XFA"); found {
+			form.Delete("XFA")
+			report.JavaScript++
+		}
+	}
+}
+
+// removeInfo drops the document information dictionary.
+func removeInfo(ctx *model.Context, report *SanitizeReport) {
+	if ctx.Info == nil {
+		return
+	}
+	if d, err := ctx.DereferenceDict(*ctx.Info); err == nil {
+		report.InfoEntries = len(d)
+	}
+	ctx.Info = nil
+}
+
+// sanitizeDict removes active content, metadata and private data from a single dictionary.
+func sanitizeDict(ctx *model.Context, d types.Dict, report *SanitizeReport) {
+	if aa, found := d.Find("AA"); found {
+		if actions, err := ctx.DereferenceDict(aa); err == nil {
+			for _, a := range actions {
+				countAction(ctx, a, report)
+			}
+		}
+		d.Delete("AA")
+	}
+
+	for _, key := range []string{"A", "Next"} {
+		a, found := d.Find(key)
+		if !found || !isRemovedAction(ctx, a) {
+			continue
+		}
+		countAction(ctx, a, report)
+		d.Delete(key)
+	}
+
+	if _, found := d.Find("Metadata"); found {
+		d.Delete("Metadata")
+		report.Metadata++
+	}
+	if _, found := d.Find("PieceInfo"); found {
+		d.Delete("PieceInfo")
+		report.PrivateData++
+	}
+	if af, found := d.Find("AF"); found {
+		if arr, err := ctx.DereferenceArray(af); err == nil {
+			report.EmbeddedFiles += len(arr)
+		}
+		d.Delete("AF")
+	}
+}
+
+// actionType returns the /S entry of an action dictionary, or "" if o is not an action.
+func actionType(ctx *model.Context, o types.Object) string {
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return ""
+	}
+	if s := d.NameEntry("S"); s != nil {
+		return *s
+	}
+	return ""
+}
+
+// isRemovedAction reports whether o is a JavaScript or active action.
+// A /Next entry may also hold an array of actions.
+func isRemovedAction(ctx *model.Context, o types.Object) bool {
+	if arr, err := ctx.DereferenceArray(o); err == nil && arr != nil {
+		for _, a := range arr {
+			if isRemovedAction(ctx, a) {
+				return true
+			}
+		}
+		return false
+	}
+	s := actionType(ctx, o)
+	return s == "JavaScript" || activeActions[s]
+}
+
+// countAction adds an action, or an array of actions, to the report.
+func countAction(ctx *model.Context, o types.Object, report *SanitizeReport) {
+	if arr, err := ctx.DereferenceArray(o); err == nil && arr != nil {
+		for _, a := range arr {
+			countAction(ctx, a, report)
+		}
+		return
+	}
+	switch s := actionType(ctx, o); {
+	case s == "JavaScript":
+		report.JavaScript++
+	case s != "":
+		report.Actions++
+	}
+}
+
+// countNameTree returns the number of entries in a name tree.
+func countNameTree(ctx *model.Context, o types.Object) int {
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return 0
+	}
+	n := 0
+	if names, err := ctx.DereferenceArray(d["Names"]); err == nil {
+		n += len(names) / 2
+	}
+	if kids, err := ctx.DereferenceArray(d["Kids"]); err == nil {
+		for _, kid := range kids {
+			n += countNameTree(ctx, kid)
+		}
+	}
+	return n
+}
+
+// removeAnnotations removes file attachment annotations, annotations on hidden
+// layers and, with opts.Annotations, every annotation and the interactive form.
+func removeAnnotations(ctx *model.Context, opts SanitizeOptions, hidden layerSet, report *SanitizeReport) error {
+	for page := 1; page <= ctx.PageCount; page++ {
+		d, _, _, err := ctx.PageDict(page, false)
+		if err != nil {
+			return fmt.Errorf("failed to read page %d: %w", page, err)
+		}
+		annots, err := ctx.DereferenceArray(d["Annots"])
+		if err != nil || annots == nil {
+			continue
+		}
+
+		var kept types.Array
+		for _, a := range annots {
+			annot, err := ctx.DereferenceDict(a)
+			if err != nil || annot == nil {
+				continue
+			}
+			switch {
+			case opts.Annotations:
+				report.Annotations++
+			case subtypeOf(annot) == "FileAttachment":
+				report.EmbeddedFiles++
+			case hidden.contains(ctx, annot["OC"]):
+				report.HiddenContent++
+			default:
+				kept = append(kept, a)
+			}
+		}
+		if len(kept) == 0 {
+			d.Delete("Annots")
+		} else {
+			d.Update("Annots", kept)
+		}
+	}
+
+	if opts.Annotations {
+		if root, err := ctx.Catalog(); err == nil {
+			root.Delete("AcroForm")
+		}
+	}
+	return nil
+}
+
+// subtypeOf returns the /Subtype of a dictionary, or "".
+func subtypeOf(d types.Dict) string {
+	if s := d.Subtype(); s != nil {
+		return *s
+	}
+	return ""
+}
+
+// layerSet is a set of optional content groups, by object number.
+type layerSet map[int]bool
+
+// hiddenLayers returns the optional content groups that are off in the default configuration.
+func hiddenLayers(ctx *model.Context, root types.Dict) layerSet {
+	hidden := layerSet{}
+	oc, err := ctx.DereferenceDict(root["OCProperties"])
+	if err != nil || oc == nil {
+		return hidden
+	}
+	config, err := ctx.DereferenceDict(oc["D"])
+	if err != nil || config == nil {
+		return hidden
+	}
+
+	refs := func(key string) []int {
+		arr, err := ctx.DereferenceArray(config[key])
+		if err != nil {
+			return nil
+		}
+		var nums []int
+		for _, o := range arr {
+			if ir, ok := o.(types.IndirectRef); ok {
+				nums = append(nums, ir.ObjectNumber.Value())
+			}
+		}
+		return nums
+	}
+
+	if base := config.NameEntry("BaseState"); base != nil && *base == "OFF" {
+		on := map[int]bool{}
+		for _, n := range refs("ON") {
+			on[n] = true
+		}
+		if all, err := ctx.DereferenceArray(oc["OCGs"]); err == nil {
+			for _, o := range all {
+				if ir, ok := o.(types.IndirectRef); ok && !on[ir.ObjectNumber.Value()] {
+					hidden[ir.ObjectNumber.Value()] = true
+				}
+			}
+		}
+	}
+	for _, n := range refs("OFF") {
+		hidden[n] = true
+	}
+	return hidden
+}
+
+// contains reports whether the optional content group or membership
+// dictionary o is hidden.
+func (h layerSet) contains(ctx *model.Context, o types.Object) bool {
+	if len(h) == 0 || o == nil {
+		return false
+	}
+	if ir, ok := o.(types.IndirectRef); ok && h[ir.ObjectNumber.Value()] {
+		return true
+	}
+
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return false
+	}
+	if t := d.Type(); t == nil || *t != "OCMD" {
+		return false
+	}
+
+	// A membership dictionary is visible depending on its groups and policy.
+	var groups []types.Object
+	if arr, err := ctx.DereferenceArray(d["OCGs"]); err == nil && arr != nil {
+		groups = arr
+	} else if g, found := d.Find("OCGs"); found {
+		groups = []types.Object{g}
+	}
+	if len(groups) == 0 {
+		return false
+	}
+	off := 0
+	for _, g := range groups {
+		if h.contains(ctx, g) {
+			off++
+		}
+	}
+
+	policy := "AnyOn"
+	if p := d.NameEntry("P"); p != nil {
+		policy = *p
+	}
+	switch policy {
+	case "AllOn":
+		return off > 0
+	case "AnyOff":
+		return off == 0
+	case "AllOff":
+		return off < len(groups)
+	default:
+		return off == len(groups)
+	}
+}
+
+// removeHiddenLayers removes page content and XObject uses that belong to
+// optional content groups hidden by default, then drops the layer
+// definitions so the remaining content is always visible.
+func removeHiddenLayers(ctx *model.Context, root types.Dict, hidden layerSet, report *SanitizeReport) error {
+	if _, found := root.Find("OCProperties"); !found {
+		return nil
+	}
+	report.HiddenLayers = len(hidden)
+
+	if len(hidden) > 0 {
+		for page := 1; page <= ctx.PageCount; page++ {
+			d, _, _, err := ctx.PageDict(page, true)
+			if err != nil {
+				return fmt.Errorf("failed to read page %d: %w", page, err)
+			}
+			data, err := ctx.PageContent(d, page)
+			if err == model.ErrNoContent {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read content of page %d: %w", page, err)
+			}
+
+			content, removed, err := stripHiddenContent(ctx, d, data, hidden)
+			if err != nil {
+				return fmt.Errorf("failed to parse content of page %d: %w", page, err)
+			}
+			if removed == 0 {
+				continue
+			}
+			report.HiddenContent += removed
+			if err := setPageContent(ctx, d, content); err != nil {
+				return fmt.Errorf("failed to update page %d: %w", page, err)
+			}
+		}
+	}
+
+	// Content of hidden layers is gone, so what remains is shown anyway; this
+	// also keeps viewers from offering to toggle the now empty layers.
+	root.Delete("OCProperties")
+	return nil
+}
+
+// stripHiddenContent removes marked content sections and XObject draws that
+// belong to hidden layers from a page content stream.
+func stripHiddenContent(ctx *model.Context, page types.Dict, data []byte, hidden layerSet) ([]byte, int, error) {
+	ops, err := parseContentOps(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var properties, xobjects types.Dict
+	if res, err := ctx.DereferenceDict(page["Resources"]); err == nil && res != nil {
+		properties, _ = ctx.DereferenceDict(res["Properties"])
+		xobjects, _ = ctx.DereferenceDict(res["XObject"])
+	}
+
+	var out bytes.Buffer
+	last, removed := 0, 0
+	for i := 0; i < len(ops); i++ {
+		op := ops[i]
+		switch {
+		case op.name == "BDC" && len(op.operands) == 2 && op.operands[0].text == "OC" && op.operands[1].kind == tokName:
+			if !hidden.contains(ctx, properties[op.operands[1].text]) {
+				continue
+			}
+			end := matchingEMC(ops, i)
+			out.Write(data[last:op.start])
+			last = ops[end].end
+			i = end
+			removed++
+		case op.name == "Do" && len(op.operands) == 1 && op.operands[0].kind == tokName:
+			xobj, _, err := ctx.DereferenceStreamDict(xobjects[op.operands[0].text])
+			if err != nil || xobj == nil || !hidden.contains(ctx, xobj.Dict["OC"]) {
+				continue
+			}
+			out.Write(data[last:op.start])
+			last = op.end
+			removed++
+		}
+	}
+	out.Write(data[last:])
+	return out.Bytes(), removed, nil
+}
+
+// matchingEMC returns the index of the EMC operator that closes the marked
+// content section opened at ops[start], or the last operator if it is unclosed.
+func matchingEMC(ops []contentOp, start int) int {
+	depth := 0
+	for i := start; i < len(ops); i++ {
+		switch ops[i].name {
+		case "BMC", "BDC":
+			depth++
+		case "EMC":
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(ops) - 1
+}
+
+// visitDicts calls fn for every dictionary in o, including nested direct
+// dictionaries, without following indirect references.
+func visitDicts(o types.Object, fn func(types.Dict)) {
+	switch v := o.(type) {
+	case types.Dict:
+		fn(v)
+		for _, child := range v {
+			visitDicts(child, fn)
+		}
+	case types.StreamDict:
+		visitDicts(v.Dict, fn)
+	case types.Array:
+		for _, child := range v {
+			visitDicts(child, fn)
+		}
+	}
+}
+
+// countUnusedObjects returns the number of objects that can no longer be
+// reached from the document catalog, info dictionary or encryption
+// dictionary and are therefore left out when writing.
+func countUnusedObjects(ctx *model.Context) int {
+	reached := map[int]bool{}
+	var walk func(o types.Object)
+	walk = func(o types.Object) {
+		switch v := o.(type) {
+		case types.IndirectRef:
+			n := v.ObjectNumber.Value()
+			if reached[n] {
+				return
+			}
+			reached[n] = true
+			if entry, ok := ctx.FindTableEntryLight(n); ok && entry.Object != nil {
+				walk(entry.Object)
+			}
+		case types.Dict:
+			for _, child := range v {
+				walk(child)
+			}
+		case types.StreamDict:
+			walk(v.Dict)
+		case types.Array:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	for _, ir := range []*types.IndirectRef{ctx.Root, ctx.Info, ctx.Encrypt} {
+		if ir != nil {
+			walk(*ir)
+		}
+	}
+
+	unused := 0
+	for n, entry := range ctx.Table {
+		if n == 0 || entry == nil || entry.Free || entry.Object == nil || reached[n] {
+			continue
+		}
+		switch entry.Object.(type) {
+		case types.ObjectStreamDict, types.XRefStreamDict:
+			// Containers are rebuilt by the writer.
+			continue
+		}
+		unused++
+	}
+	return unused
+}
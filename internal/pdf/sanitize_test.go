@@ -0,0 +1,192 @@
+package pdf
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// buildPDF assembles a PDF from object bodies numbered from 1; the first object
+// is the catalog and infoObj, if not zero, the document information dictionary.
+func buildPDF(infoObj int, objects ...string) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.7\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R", len(objects)+1)
+	if infoObj > 0 {
+		fmt.Fprintf(&b, " /Info %d 0 R", infoObj)
+	}
+	fmt.Fprintf(&b, " >>\nstartxref\n%d\n%%%%EOF\n", xref)
+	return b.Bytes()
+}
+
+// stream formats a stream object with the given extra dictionary entries.
+func stream(entries, data string) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", entries, len(data), data)
+}
+
+// activeContentPDF returns a one-page PDF with scripts, an attachment,
+// metadata, a hidden layer and active annotations.
+func activeContentPDF() []byte {
+	content := "/OC /L1 BDC BT /F1 12 Tf 72 700 Td (Visible) Tj ET EMC " +
+		"/OC /L2 BDC BT /F1 12 Tf 72 680 Td (Hidden) Tj ET EMC"
+	return buildPDF(6,
+		"<< /Type /Catalog /Pages 2 0 R /OpenAction 7 0 R"+
+			" /Names << /JavaScript << /Names [(init) 7 0 R] >> /EmbeddedFiles << /Names [(secret.txt) 8 0 R] >> >>"+
+			" /Metadata 10 0 R /OCProperties << /OCGs [11 0 R 12 0 R] /D << /OFF [12 0 R] >> >> >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Contents 4 0 R"+
+			" /Resources << /Font << /F1 5 0 R >> /Properties << /L1 11 0 R /L2 12 0 R >> >>"+
+			" /Annots [13 0 R 14 0 R] /AA << /O 7 0 R >> >>",
+		stream("", content),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		"<< /Title (Secret plans) /Author (Jane) >>",
+		"<< /Type /Action /S /JavaScript /JS (app.alert\\(1\\)) >>",
+		"<< /Type /Filespec /F (secret.txt) /UF (secret.txt) /EF << /F 9 0 R >> >>",
+		stream("/Type /EmbeddedFile", "top secret"),
+		stream("/Type /Metadata /Subtype /XML", "<x:xmpmeta xmlns:x=\"adobe:ns:meta/\"/>"),
+		"<< /Type /OCG /Name (Visible) >>",
+		"<< /Type /OCG /Name (Hidden) >>",
+		"<< /Type /Annot /Subtype /Link /Rect [72 600 144 620] /A << /S /URI /URI (https://example.com) >> >>",
+		"<< /Type /Annot /Subtype /Link /Rect [72 560 144 580] /A << /S /Launch /F (calc.exe) >> >>",
+	)
+}
+
+// dumpObjects returns every object of a PDF as text, with decoded stream content.
+func dumpObjects(t *testing.T, path string) string {
+	t.Helper()
+	ctx, err := readContext(path, "")
+	if err != nil {
+		t.Fatalf("readContext() error = %v", err)
+	}
+	var b strings.Builder
+	for _, entry := range ctx.Table {
+		if entry == nil || entry.Free || entry.Object == nil {
+			continue
+		}
+		b.WriteString(entry.Object.String())
+		if sd, ok := entry.Object.(types.StreamDict); ok && sd.Decode() == nil {
+			b.Write(sd.Content)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func TestSanitize(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	input := filepath.Join(tmpDir, "active.pdf")
+	if err := os.WriteFile(input, activeContentPDF(), 0o600); err != nil {
+		t.Fatalf("Failed to write input: %v", err)
+	}
+
+	output := filepath.Join(tmpDir, "clean.pdf")
+	report, err := Sanitize(input, output, SanitizeOptions{}, "")
+	if err != nil {
+		t.Fatalf("Sanitize() error = %v", err)
+	}
+
+	want := SanitizeReport{
+		JavaScript:    2, // name tree entry and page open action
+		Actions:       1,
+		OpenAction:    true,
+		EmbeddedFiles: 1,
+		Metadata:      1,
+		InfoEntries:   2,
+		HiddenLayers:  1,
+		HiddenContent: 1,
+	}
+	got := *report
+	got.UnusedObjects = 0
+	if got != want {
+		t.Errorf("Sanitize() report = %+v, want %+v", got, want)
+	}
+	if report.UnusedObjects < 5 {
+		t.Errorf("UnusedObjects = %d, want at least 5", report.UnusedObjects)
+	}
+
+	objects := dumpObjects(t, output)
+	for _, leaked := range []string{"JavaScript", "secret.txt", "top secret", "xmpmeta", "Secret plans", "calc.exe", "OCProperties"} {
+		if strings.Contains(objects, leaked) {
+			t.Errorf("output still contains %q", leaked)
+		}
+	}
+	if !strings.Contains(objects, "https://example.com") {
+		t.Error("URI link was removed")
+	}
+
+	text, err := ExtractText(context.Background(), output, nil, "")
+	if err != nil {
+		t.Fatalf("ExtractText() error = %v", err)
+	}
+	if !strings.Contains(text, "Visible") || strings.Contains(text, "Hidden") {
+		t.Errorf("page text = %q, want only the visible layer", text)
+	}
+}
+
+func TestSanitizeAnnotations(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	input := filepath.Join(tmpDir, "active.pdf")
+	if err := os.WriteFile(input, activeContentPDF(), 0o600); err != nil {
+		t.Fatalf("Failed to write input: %v", err)
+	}
+
+	output := filepath.Join(tmpDir, "clean.pdf")
+	report, err := Sanitize(input, output, SanitizeOptions{Annotations: true}, "")
+	if err != nil {
+		t.Fatalf("Sanitize() error = %v", err)
+	}
+	if report.Annotations != 2 {
+		t.Errorf("Annotations = %d, want 2", report.Annotations)
+	}
+
+	if strings.Contains(dumpObjects(t, output), "example.com") {
+		t.Error("link annotation was not removed")
+	}
+}
+
+func TestSanitizeCleanFile(t *testing.T) {
+	input := samplePDF()
+	if _, err := os.Stat(input); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	report, err := Sanitize(input, filepath.Join(tmpDir, "clean.pdf"), SanitizeOptions{}, "")
+	if err != nil {
+		t.Fatalf("Sanitize() error = %v", err)
+	}
+	if report.JavaScript != 0 || report.EmbeddedFiles != 0 || report.HiddenContent != 0 {
+		t.Errorf("Sanitize() found active content in a plain file: %+v", report)
+	}
+}
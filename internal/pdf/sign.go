@@ -0,0 +1,519 @@
+package pdf
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hhrutter/pkcs7"
+	"github.com/lgbarn/pdf-cli/internal/fileio"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// Signature formats created by Sign.
+const (
+	SignFormatPAdES = "pades" // PAdES baseline B-B, SubFilter ETSI.CAdES.detached
+	SignFormatCMS   = "cms"   // CMS, SubFilter adbe.pkcs7.detached
+)
+
+// oidSigningCertificateV2 identifies the ESS signing-certificate-v2 attribute (RFC 5035).
+var oidSigningCertificateV2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 47}
+
+// essCertIDv2 identifies a certificate by its SHA-256 hash, the default
+// hash algorithm, which DER leaves out.
+type essCertIDv2 struct {
+	CertHash []byte
+}
+
+type signingCertificateV2 struct {
+	Certs []essCertIDv2
+}
+
+// Signer is a private key with its certificate and issuer certificates.
+type Signer struct {
+	Certificate *x509.Certificate
+	Chain       []*x509.Certificate // issuers of Certificate, nearest first
+	key         crypto.Signer
+}
+
+// SignOptions controls how Sign signs a PDF.
+type SignOptions struct {
+	Format   string // SignFormatPAdES (default) or SignFormatCMS
+	Field    string // signature field name (default: Signature1, Signature2, ...)
+	Name     string // signer name (default: common name of the certificate)
+	Reason   string
+	Location string
+	Contact  string
+	Visible  bool // show the signature on Page within Rect
+	Page     int  // page of the signature widget (default: 1)
+	Rect     Rect
+	Time     time.Time // signing time (default: now)
+}
+
+// LoadSigner loads a private key and its certificate chain. certPath is
+// either a PKCS#12 file (.p12 or .pfx) protected by password, or a PEM file
+// with the signer certificate followed by its issuers. For PEM, the
+// unencrypted private key is read from keyPath, or from certPath if keyPath
+// is empty.
+func LoadSigner(certPath, keyPath, password string) (*Signer, error) {
+	data, err := os.ReadFile(filepath.Clean(certPath)) // #nosec G304 -- path is cleaned
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate: %w", err)
+	}
+
+	if IsPKCS12File(certPath) {
+		key, cert, chain, err := pkcs12.DecodeChain(data, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", filepath.Base(certPath), err)
+		}
+		return newSigner(key, cert, chain)
+	}
+
+	var certs []*x509.Certificate
+	var key crypto.PrivateKey
+	keyData := data
+	if keyPath != "" {
+		if keyData, err = os.ReadFile(filepath.Clean(keyPath)); err != nil { // #nosec G304 -- path is cleaned
+			return nil, fmt.Errorf("failed to read private key: %w", err)
+		}
+	}
+	for rest := data; ; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse certificate: %w", err)
+			}
+			certs = append(certs, cert)
+		}
+	}
+	for rest := keyData; key == nil; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		if key, err = parsePrivateKey(block); err != nil {
+			return nil, err
+		}
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate found in %s", filepath.Base(certPath))
+	}
+	if key == nil {
+		return nil, fmt.Errorf("no private key found (use --key or a PKCS#12 file)")
+	}
+	return newSigner(key, certs[0], certs[1:])
+}
+
+// IsPKCS12File reports whether path names a PKCS#12 file (.p12 or .pfx).
+func IsPKCS12File(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".p12" || ext == ".pfx"
+}
+
+// parsePrivateKey parses a PEM private key block, or returns nil for other blocks.
+func parsePrivateKey(block *pem.Block) (crypto.PrivateKey, error) {
+	if !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+		return nil, nil
+	}
+	if block.Type == "ENCRYPTED PRIVATE KEY" || block.Headers["Proc-Type"] != "" {
+		return nil, fmt.Errorf("encrypted PEM private keys are not supported; use a PKCS#12 file instead")
+	}
+	var key crypto.PrivateKey
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	return key, nil
+}
+
+// newSigner checks that key belongs to cert and orders the issuer chain.
+func newSigner(key crypto.PrivateKey, cert *x509.Certificate, certs []*x509.Certificate) (*Signer, error) {
+	switch key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey:
+	case ed25519.PrivateKey:
+		return nil, fmt.Errorf("ed25519 keys are not supported for PDF signatures")
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	signer := key.(crypto.Signer)
+	pub, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pub.Equal(cert.PublicKey) {
+		return nil, fmt.Errorf("private key does not match the certificate of %s", cert.Subject.CommonName)
+	}
+
+	// The signature names the issuer of the signer certificate, which must be
+	// the first certificate of the chain, so follow the issuers from cert.
+	s := &Signer{Certificate: cert, key: signer}
+	for cur := cert; !bytes.Equal(cur.RawIssuer, cur.RawSubject); {
+		var issuer *x509.Certificate
+		for _, c := range certs {
+			if bytes.Equal(c.RawSubject, cur.RawIssuer) && cur.CheckSignatureFrom(c) == nil {
+				issuer = c
+				break
+			}
+		}
+		if issuer == nil || len(s.Chain) == len(certs) {
+			break
+		}
+		s.Chain = append(s.Chain, issuer)
+		cur = issuer
+	}
+	return s, nil
+}
+
+// Sign adds a digital signature to a PDF. The signature is written as an
+// incremental update, so existing signatures stay valid. Encrypted PDFs
+// cannot be signed.
+func Sign(input, output string, signer *Signer, opts SignOptions) error {
+	data, err := os.ReadFile(filepath.Clean(input)) // #nosec G304 -- path is cleaned
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	ctx, err := api.ReadAndValidate(bytes.NewReader(data), NewConfig(""))
+	if err != nil {
+		return err
+	}
+	if ctx.Encrypt != nil {
+		return fmt.Errorf("signing encrypted PDFs is not supported; decrypt the file first")
+	}
+
+	opts, err = signDefaults(ctx, signer, opts)
+	if err != nil {
+		return err
+	}
+	subFilter := "ETSI.CAdES.detached"
+	if opts.Format == SignFormatCMS {
+		subFilter = "adbe.pkcs7.detached"
+	}
+
+	// Room for the signature value: the certificates plus the signed
+	// attributes and the signature itself.
+	size := 4096
+	for _, c := range append([]*x509.Certificate{signer.Certificate}, signer.Chain...) {
+		size += len(c.Raw)
+	}
+	sigDict := types.Dict{
+		"Type":      types.Name("Sig"),
+		"Filter":    types.Name("Adobe.PPKLite"),
+		"SubFilter": types.Name(subFilter),
+		"ByteRange": types.NewIntegerArray(0, 0, 0, 0),
+		"Contents":  types.HexLiteral(strings.Repeat("0", 2*size)),
+		"M":         types.StringLiteral(types.DateString(opts.Time)),
+	}
+	for key, value := range map[string]string{"Name": opts.Name, "Reason": opts.Reason, "Location": opts.Location, "ContactInfo": opts.Contact} {
+		if value == "" {
+			continue
+		}
+		if sigDict[key], err = textString(value); err != nil {
+			return err
+		}
+	}
+
+	ctx.Write.Increment = true
+	ctx.WriteXRefStream = usesXRefStream(ctx, data)
+	if err := addSignatureField(ctx, sigDict, opts); err != nil {
+		return err
+	}
+
+	// The update starts on a new line after the original file.
+	var buf bytes.Buffer
+	buf.Write(data)
+	if !bytes.HasSuffix(data, []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	ctx.Write.Offset = int64(buf.Len())
+	if err := api.WriteIncrement(ctx, &buf); err != nil {
+		return fmt.Errorf("failed to write signature: %w", err)
+	}
+	signed := buf.Bytes()
+
+	// The signature covers the whole file except the Contents value.
+	start := ctx.Write.OffsetSigContents
+	end := start + int64(2*size+2)
+	byteRange := fmt.Sprintf("[0 %d %d %d]", start, end, int64(len(signed))-end)
+	copy(signed[ctx.Write.OffsetSigByteRange:], fmt.Sprintf("%-36s", byteRange))
+
+	digest := sha256.New()
+	digest.Write(signed[:start])
+	digest.Write(signed[end:])
+	cms, err := signDigest(signer, digest.Sum(nil), opts)
+	if err != nil {
+		return err
+	}
+	if len(cms) > size {
+		return fmt.Errorf("signature of %d bytes exceeds the reserved %d bytes", len(cms), size)
+	}
+	hex.Encode(signed[start+1:], cms)
+
+	return fileio.AtomicWrite(output, signed)
+}
+
+// xrefStreamHeader matches the start of a cross-reference stream object.
+var xrefStreamHeader = regexp.MustCompile(`^\s*\d+\s+\d+\s+obj`)
+
+// usesXRefStream reports whether the last cross-reference section of data is
+// a stream, so that an update continues in the same form. pdfcpu's own flag
+// is also set when it had to repair a file with a cross-reference table.
+func usesXRefStream(ctx *model.Context, data []byte) bool {
+	prev := ctx.Write.OffsetPrevXRef
+	if prev == nil || *prev < 0 || *prev >= int64(len(data)) {
+		return false
+	}
+	return xrefStreamHeader.Match(data[*prev:min(*prev+64, int64(len(data)))])
+}
+
+// signDefaults validates opts against the document and fills in defaults.
+func signDefaults(ctx *model.Context, signer *Signer, opts SignOptions) (SignOptions, error) {
+	switch opts.Format {
+	case "":
+		opts.Format = SignFormatPAdES
+	case SignFormatPAdES, SignFormatCMS:
+	default:
+		return opts, fmt.Errorf("unknown signature format %q (use %s or %s)", opts.Format, SignFormatPAdES, SignFormatCMS)
+	}
+	if opts.Page == 0 {
+		opts.Page = 1
+	}
+	if opts.Page < 1 || opts.Page > ctx.PageCount {
+		return opts, fmt.Errorf("page %d out of range (document has %d pages)", opts.Page, ctx.PageCount)
+	}
+	if opts.Visible && (opts.Rect.Width() <= 0 || opts.Rect.Height() <= 0) {
+		return opts, fmt.Errorf("a visible signature needs a rectangle")
+	}
+	if opts.Name == "" {
+		opts.Name = signer.Certificate.Subject.CommonName
+	}
+	if opts.Time.IsZero() {
+		opts.Time = time.Now()
+	}
+	opts.Time = opts.Time.UTC().Truncate(time.Second)
+
+	names, err := fieldNames(ctx)
+	if err != nil {
+		return opts, err
+	}
+	if opts.Field == "" {
+		for i := 1; opts.Field == "" || names[opts.Field]; i++ {
+			opts.Field = fmt.Sprintf("Signature%d", i)
+		}
+	} else if names[opts.Field] {
+		return opts, fmt.Errorf("form field %q already exists", opts.Field)
+	}
+	return opts, nil
+}
+
+// fieldNames returns the names of the top-level form fields.
+func fieldNames(ctx *model.Context) (map[string]bool, error) {
+	names := map[string]bool{}
+	root, err := ctx.Catalog()
+	if err != nil {
+		return nil, err
+	}
+	form, err := ctx.DereferenceDict(root["AcroForm"])
+	if err != nil || form == nil {
+		return names, err
+	}
+	fields, err := ctx.DereferenceArray(form["Fields"])
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range fields {
+		field, err := ctx.DereferenceDict(o)
+		if err != nil || field == nil {
+			continue
+		}
+		if t := field.StringEntry("T"); t != nil {
+			names[*t] = true
+		}
+	}
+	return names, nil
+}
+
+// addSignatureField adds the signature dictionary and a signature field
+// with its widget to ctx and marks every new or changed object for the
+// incremental update.
+func addSignatureField(ctx *model.Context, sigDict types.Dict, opts SignOptions) error {
+	sigRef, err := ctx.IndRefForNewObject(sigDict)
+	if err != nil {
+		return err
+	}
+	page, pageRef, _, err := ctx.PageDict(opts.Page, false)
+	if err != nil {
+		return err
+	}
+
+	field := types.Dict{
+		"Type":    types.Name("Annot"),
+		"Subtype": types.Name("Widget"),
+		"FT":      types.Name("Sig"),
+		"V":       *sigRef,
+		"F":       types.Integer(132), // print, locked
+		"P":       *pageRef,
+		"Rect":    types.NewNumberArray(0, 0, 0, 0),
+	}
+	if field["T"], err = textString(opts.Field); err != nil {
+		return err
+	}
+	if opts.Visible {
+		field["Rect"] = types.NewNumberArray(opts.Rect.LLX, opts.Rect.LLY, opts.Rect.URX, opts.Rect.URY)
+		ap, err := signatureAppearance(ctx, opts)
+		if err != nil {
+			return err
+		}
+		field["AP"] = types.Dict{"N": *ap}
+		ctx.Write.IncrementWithObjNr(ap.ObjectNumber.Value())
+	}
+	fieldRef, err := ctx.IndRefForNewObject(field)
+	if err != nil {
+		return err
+	}
+	ctx.Write.IncrementWithObjNr(sigRef.ObjectNumber.Value())
+	ctx.Write.IncrementWithObjNr(fieldRef.ObjectNumber.Value())
+
+	annots, err := ctx.DereferenceArray(page["Annots"])
+	if err != nil {
+		return err
+	}
+	page["Annots"] = append(annots, *fieldRef)
+	ctx.Write.IncrementWithObjNr(pageRef.ObjectNumber.Value())
+
+	root, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+	form, err := ctx.DereferenceDict(root["AcroForm"])
+	if err != nil {
+		return err
+	}
+	if ref, ok := root["AcroForm"].(types.IndirectRef); ok && form != nil {
+		ctx.Write.IncrementWithObjNr(ref.ObjectNumber.Value())
+	} else {
+		if form == nil {
+			form = types.Dict{}
+			root["AcroForm"] = form
+		}
+		ctx.Write.IncrementWithObjNr(ctx.Root.ObjectNumber.Value())
+	}
+	fields, err := ctx.DereferenceArray(form["Fields"])
+	if err != nil {
+		return err
+	}
+	form["Fields"] = append(fields, *fieldRef)
+	form["SigFlags"] = types.Integer(3) // signatures exist, append only
+	return nil
+}
+
+// signatureAppearance creates the appearance stream of a visible signature:
+// a frame with the signer, signing time, reason and location.
+func signatureAppearance(ctx *model.Context, opts SignOptions) (*types.IndirectRef, error) {
+	w, h := opts.Rect.Width(), opts.Rect.Height()
+	lines := []string{"Digitally signed by " + opts.Name, "Date: " + opts.Time.Format("2006-01-02 15:04:05 MST")}
+	if opts.Reason != "" {
+		lines = append(lines, "Reason: "+opts.Reason)
+	}
+	if opts.Location != "" {
+		lines = append(lines, "Location: "+opts.Location)
+	}
+
+	// Helvetica averages about half the font size per character.
+	longest := 0
+	for _, l := range lines {
+		longest = max(longest, len(l))
+	}
+	fontSize := math.Min(10, (h-4)/(1.2*float64(len(lines))))
+	fontSize = math.Min(fontSize, (w-4)/(0.5*float64(longest)))
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "q 0.5 G 0.5 w 0.25 0.25 %.2f %.2f re S Q\n", w-0.5, h-0.5)
+	fmt.Fprintf(&b, "BT /Helv %.2f Tf %.2f TL 2 %.2f Td\n", fontSize, 1.2*fontSize, h-2-fontSize)
+	for _, l := range lines {
+		fmt.Fprintf(&b, "%s '\n", encodeHexString(latin1(l)))
+	}
+	b.WriteString("ET\n")
+
+	sd, err := ctx.NewStreamDictForBuf(b.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	sd.Insert("Type", types.Name("XObject"))
+	sd.Insert("Subtype", types.Name("Form"))
+	sd.Insert("BBox", types.NewNumberArray(0, 0, w, h))
+	sd.Insert("Resources", types.Dict{"Font": types.Dict{"Helv": types.Dict{
+		"Type":     types.Name("Font"),
+		"Subtype":  types.Name("Type1"),
+		"BaseFont": types.Name("Helvetica"),
+		"Encoding": types.Name("WinAnsiEncoding"),
+	}}})
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+	return ctx.IndRefForNewObject(*sd)
+}
+
+// latin1 encodes s for a standard font, replacing characters outside Latin-1.
+func latin1(s string) []byte {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r > 0xFF {
+			r = '?'
+		}
+		b = append(b, byte(r))
+	}
+	return b
+}
+
+// signDigest creates the detached CMS signature of a document digest.
+func signDigest(signer *Signer, digest []byte, opts SignOptions) ([]byte, error) {
+	var attrs []pkcs7.Attribute
+	if opts.Format == SignFormatPAdES {
+		// PAdES binds the signer certificate and takes the time from /M.
+		certHash := sha256.Sum256(signer.Certificate.Raw)
+		attrs = append(attrs, pkcs7.Attribute{
+			Type:  oidSigningCertificateV2,
+			Value: signingCertificateV2{Certs: []essCertIDv2{{CertHash: certHash[:]}}},
+		})
+	} else {
+		attrs = append(attrs, pkcs7.Attribute{Type: pkcs7.OIDAttributeSigningTime, Value: opts.Time})
+	}
+
+	sd, err := pkcs7.NewSignedData()
+	if err != nil {
+		return nil, err
+	}
+	err = sd.AddSignerChain(signer.Certificate, signer.key, digest, pkcs7.OIDDigestAlgorithmSHA256,
+		signer.Chain, pkcs7.SignerInfoConfig{ExtraSignedAttributes: attrs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+	sd.Detach()
+	return sd.Finish()
+}
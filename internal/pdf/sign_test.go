@@ -0,0 +1,239 @@
+package pdf
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// testPKI is a CA and a signer certificate issued by it.
+type testPKI struct {
+	ca      *x509.Certificate
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	trusted *x509.CertPool
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Sign Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _ := x509.ParseCertificate(caDER)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "Jane Signer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+
+	trusted := x509.NewCertPool()
+	trusted.AddCert(ca)
+	return &testPKI{ca: ca, cert: cert, key: key, trusted: trusted}
+}
+
+// writePEM writes the signer certificate, the CA certificate and the key to a PEM file.
+func (p *testPKI) writePEM(t *testing.T) string {
+	t.Helper()
+	keyDER, err := x509.MarshalPKCS8PrivateKey(p.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data []byte
+	data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: p.cert.Raw})...)
+	data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: p.ca.Raw})...)
+	data = append(data, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})...)
+	path := filepath.Join(t.TempDir(), "signer.pem")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// writeP12 writes the key and certificates to a PKCS#12 file protected by password.
+func (p *testPKI) writeP12(t *testing.T, password string) string {
+	t.Helper()
+	data, err := pkcs12.Modern.Encode(p.key, p.cert, []*x509.Certificate{p.ca}, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "signer.p12")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadSigner(t *testing.T) {
+	pki := newTestPKI(t)
+
+	s, err := LoadSigner(pki.writeP12(t, "secret"), "", "secret")
+	if err != nil {
+		t.Fatalf("LoadSigner(p12) error = %v", err)
+	}
+	if !s.Certificate.Equal(pki.cert) || len(s.Chain) != 1 || !s.Chain[0].Equal(pki.ca) {
+		t.Errorf("LoadSigner(p12) = %v, chain %d", s.Certificate.Subject, len(s.Chain))
+	}
+	if _, err := LoadSigner(pki.writeP12(t, "secret"), "", "wrong"); err == nil {
+		t.Error("LoadSigner() with a wrong password should fail")
+	}
+
+	s, err = LoadSigner(pki.writePEM(t), "", "")
+	if err != nil {
+		t.Fatalf("LoadSigner(pem) error = %v", err)
+	}
+	if !s.Certificate.Equal(pki.cert) || len(s.Chain) != 1 {
+		t.Errorf("LoadSigner(pem) = %v, chain %d", s.Certificate.Subject, len(s.Chain))
+	}
+
+	// A key that does not belong to the certificate.
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+	keyPath := filepath.Join(t.TempDir(), "other.key")
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(other)})
+	if err := os.WriteFile(keyPath, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSigner(pki.writePEM(t), keyPath, ""); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("LoadSigner() with another key error = %v", err)
+	}
+}
+
+func TestSign(t *testing.T) {
+	pki := newTestPKI(t)
+	signer, err := LoadSigner(pki.writeP12(t, "secret"), "", "secret")
+	if err != nil {
+		t.Fatalf("LoadSigner() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		opts      SignOptions
+		subFilter string
+	}{
+		{"pades", samplePDF(), SignOptions{Reason: "Approved"}, "ETSI.CAdES.detached"},
+		{"cms", samplePDF(), SignOptions{Format: SignFormatCMS, Field: "Approval"}, "adbe.pkcs7.detached"},
+		{"visible", samplePDF(), SignOptions{Visible: true, Page: 2, Rect: Rect{LLX: 36, LLY: 36, URX: 236, URY: 96}, Location: "Zürich"}, "ETSI.CAdES.detached"},
+		{"xref stream with form", filepath.Join(testdataDir(), "form.pdf"), SignOptions{}, "ETSI.CAdES.detached"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "signed.pdf")
+			if err := Sign(tt.input, output, signer, tt.opts); err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+
+			s := verifySigned(t, output, pki.trusted)
+			if s.Status != SignatureValid || s.ModifiedAfterSigning {
+				t.Errorf("status = %q (%s, %v), modified = %v", s.Status, s.Reason, s.Problems, s.ModifiedAfterSigning)
+			}
+			if s.SubFilter != tt.subFilter || s.Signer != "CN=Jane Signer" {
+				t.Errorf("signature = %+v", s)
+			}
+			wantField := tt.opts.Field
+			if wantField == "" {
+				wantField = "Signature1"
+			}
+			if s.Field != wantField {
+				t.Errorf("field = %q, want %q", s.Field, wantField)
+			}
+
+			// The original document is kept unchanged in front of the signature.
+			original, _ := os.ReadFile(tt.input)
+			signed, _ := os.ReadFile(output)
+			if !strings.HasPrefix(string(signed), string(original)) {
+				t.Error("signing should append an incremental update")
+			}
+		})
+	}
+}
+
+func TestSignTwice(t *testing.T) {
+	pki := newTestPKI(t)
+	signer, err := LoadSigner(pki.writePEM(t), "", "")
+	if err != nil {
+		t.Fatalf("LoadSigner() error = %v", err)
+	}
+	dir := t.TempDir()
+	first, second := filepath.Join(dir, "first.pdf"), filepath.Join(dir, "second.pdf")
+	if err := Sign(samplePDF(), first, signer, SignOptions{}); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if err := Sign(first, second, signer, SignOptions{Format: SignFormatCMS}); err != nil {
+		t.Fatalf("Sign() of a signed PDF error = %v", err)
+	}
+	if err := Sign(first, second, signer, SignOptions{Field: "Signature1"}); err == nil {
+		t.Error("Sign() with an existing field name should fail")
+	}
+
+	sigs, err := VerifySignatures(second, pki.trusted, "")
+	if err != nil {
+		t.Fatalf("VerifySignatures() error = %v", err)
+	}
+	if len(sigs) != 2 {
+		t.Fatalf("got %d signatures, want 2", len(sigs))
+	}
+	for _, s := range sigs {
+		if s.Status != SignatureValid {
+			t.Errorf("%s: status = %q (%s)", s.Field, s.Status, s.Reason)
+		}
+	}
+	if sigs[0].Field != "Signature1" || !sigs[0].ModifiedAfterSigning || sigs[1].Field != "Signature2" {
+		t.Errorf("signatures = %+v", sigs)
+	}
+}
+
+func TestSignErrors(t *testing.T) {
+	pki := newTestPKI(t)
+	signer, err := LoadSigner(pki.writePEM(t), "", "")
+	if err != nil {
+		t.Fatalf("LoadSigner() error = %v", err)
+	}
+	output := filepath.Join(t.TempDir(), "out.pdf")
+	for name, opts := range map[string]SignOptions{
+		"format":  {Format: "xades"},
+		"page":    {Page: 99},
+		"no rect": {Visible: true},
+	} {
+		if err := Sign(samplePDF(), output, signer, opts); err == nil {
+			t.Errorf("%s: Sign() should fail", name)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package pdf
+
+import (
+	"cmp"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hhrutter/pkcs7"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Signature statuses reported by VerifySignatures.
+const (
+	SignatureValid    = "valid"    // intact and signed by a trusted certificate
+	SignatureInvalid  = "invalid"  // signed content changed or signature forged
+	SignatureUnknown  = "unknown"  // e.g. certificate not in the trust store
+	SignatureUnsigned = "unsigned" // empty signature field
+)
+
+// Signature describes a signature field and the result of verifying it.
+type Signature struct {
+	Field                string     `json:"field"`
+	Type                 string     `json:"type"` // form, page, usage rights or timestamp
+	Page                 int        `json:"page,omitempty"`
+	Signer               string     `json:"signer,omitempty"` // subject of the signer certificate
+	Issuer               string     `json:"issuer,omitempty"`
+	SigningTime          *time.Time `json:"signingTime,omitempty"`
+	SubFilter            string     `json:"subFilter,omitempty"`
+	ByteRange            []int64    `json:"byteRange,omitempty"`
+	ModifiedAfterSigning bool       `json:"modifiedAfterSigning"`
+	Status               string     `json:"status"`
+	Reason               string     `json:"reason,omitempty"`
+	Problems             []string   `json:"problems,omitempty"`
+}
+
+// reasonRevisionsAdded is the reason of a valid signature whose signed
+// revision is intact but that is followed by later revisions.
+const reasonRevisionsAdded = "signed revision has not been modified, but later revisions were added"
+
+// trustStoreExtensions lists the certificate files loaded from a trust store.
+var trustStoreExtensions = []string{".pem", ".crt", ".cer", ".p7c"}
+
+// verifyMu serializes verifications, which share pdfcpu's global certificate pool.
+var verifyMu sync.Mutex
+
+// LoadTrustStore returns a pool of the certificates in dir (.pem, .crt, .cer
+// and .p7c files). If dir is empty, the certificates installed on the system
+// are used.
+func LoadTrustStore(dir string) (*x509.CertPool, error) {
+	if dir == "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			return x509.NewCertPool(), nil
+		}
+		return pool, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trust store: %w", err)
+	}
+	pool := x509.NewCertPool()
+	count := 0
+	for _, e := range entries {
+		ext := strings.ToLower(filepath.Ext(e.Name()))
+		if e.IsDir() || !slices.Contains(trustStoreExtensions, ext) {
+			continue
+		}
+		certs, err := pdfcpu.LoadCertificatesFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load certificate %s: %w", e.Name(), err)
+		}
+		for _, cert := range certs {
+			pool.AddCert(cert)
+			count++
+		}
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("no certificates found in trust store %s", dir)
+	}
+	return pool, nil
+}
+
+// VerifySignatures lists the signature fields of a PDF and verifies their
+// signatures against the certificates in trusted. Verification is offline:
+// certificate revocation is not checked.
+func VerifySignatures(input string, trusted *x509.CertPool, password string) ([]Signature, error) {
+	f, err := os.Open(filepath.Clean(input)) // #nosec G304 -- path is cleaned
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	conf := NewConfig(password)
+	conf.Cmd = model.VALIDATESIGNATURES
+	conf.Offline = true
+	ctx, err := api.ReadAndValidate(f, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	verifyMu.Lock()
+	defer verifyMu.Unlock()
+	saved := model.UserCertPool
+	model.UserCertPool = trusted
+	defer func() { model.UserCertPool = saved }()
+
+	results, err := pdfcpu.ValidateSignatures(f, ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	sigs := make([]Signature, 0, len(results))
+	for _, r := range results {
+		sigs = append(sigs, signatureInfo(ctx, r, trusted, info.Size()))
+	}
+	// pdfcpu returns the results in map order; list them in document order.
+	slices.SortStableFunc(sigs, compareSignatures)
+	return sigs, nil
+}
+
+// compareSignatures orders signatures by the offset of their signed content,
+// and those without a byte range after them by field name.
+func compareSignatures(a, b Signature) int {
+	switch {
+	case len(a.ByteRange) >= 2 && len(b.ByteRange) >= 2:
+		if c := cmp.Compare(a.ByteRange[1], b.ByteRange[1]); c != 0 {
+			return c
+		}
+	case len(a.ByteRange) >= 2:
+		return -1
+	case len(b.ByteRange) >= 2:
+		return 1
+	}
+	return strings.Compare(a.Field, b.Field)
+}
+
+// signatureInfo describes the validation result r of a document of the given size.
+func signatureInfo(ctx *model.Context, r *model.SignatureValidationResult, trusted *x509.CertPool, size int64) Signature {
+	s := Signature{
+		Field:     r.Details.FieldName,
+		Type:      signatureType(r.Signature.Type),
+		Page:      r.Signature.PageNr,
+		SubFilter: r.Details.SubFilter,
+	}
+	if !r.Signed {
+		s.Status = SignatureUnsigned
+		return s
+	}
+
+	switch r.Status {
+	case model.SignatureStatusValid:
+		s.Status = SignatureValid
+	case model.SignatureStatusInvalid:
+		s.Status = SignatureInvalid
+	default:
+		s.Status = SignatureUnknown
+	}
+	if !r.Details.SigningTime.IsZero() {
+		t := r.Details.SigningTime
+		s.SigningTime = &t
+	}
+
+	problems := r.Problems
+	for _, signer := range r.Details.Signers {
+		problems = append(problems, signer.Problems...)
+	}
+	for _, p := range problems {
+		// Revocation cannot be checked offline; the commands document this.
+		if p = strings.TrimSpace(p); p != "" && !strings.Contains(p, "offline") {
+			s.Problems = append(s.Problems, p)
+		}
+	}
+	reason := r.Reason
+
+	sigDict := signatureDict(ctx, r)
+	if sigDict == nil {
+		s.Reason = reason.String()
+		return s
+	}
+	for _, o := range sigDict.ArrayEntry("ByteRange") {
+		if n, ok := o.(types.Integer); ok {
+			s.ByteRange = append(s.ByteRange, int64(n))
+		}
+	}
+	// Content changed within the byte ranges makes the signature invalid;
+	// content added after them comes from later revisions of the document.
+	s.ModifiedAfterSigning = r.DocModified == model.True
+	if n := len(s.ByteRange); n >= 2 && s.ByteRange[n-2]+s.ByteRange[n-1] < size {
+		s.ModifiedAfterSigning = true
+	}
+	if p7, cert := signerCertificate(sigDict); cert != nil {
+		s.Signer = cert.Subject.String()
+		s.Issuer = cert.Issuer.String()
+		// Offline, pdfcpu reports every certificate as untrusted because
+		// its revocation status is unknown, so the chain is checked here.
+		if r.Status == model.SignatureStatusUnknown && reason == model.SignatureReasonCertNotTrusted &&
+			trustedChain(p7, cert, trusted, r.Details.SigningTime) {
+			s.Status = SignatureValid
+			reason = model.SignatureReasonDocNotModified
+		}
+	}
+
+	if reason != model.SignatureReasonUnknown {
+		s.Reason = reason.String()
+	}
+	if reason == model.SignatureReasonDocNotModified && s.ModifiedAfterSigning {
+		s.Reason = reasonRevisionsAdded
+	}
+	if reason == model.SignatureReasonInternal && len(s.Problems) > 0 {
+		s.Reason = s.Problems[0]
+	}
+	return s
+}
+
+// signatureType names a pdfcpu signature type.
+func signatureType(t int) string {
+	switch t {
+	case model.SigTypeForm:
+		return "form"
+	case model.SigTypePage:
+		return "page"
+	case model.SigTypeUR:
+		return "usage rights"
+	default:
+		return "timestamp"
+	}
+}
+
+// signatureDict returns the signature dictionary of a validation result.
+func signatureDict(ctx *model.Context, r *model.SignatureValidationResult) types.Dict {
+	if r.Signature.Type == model.SigTypeUR {
+		return ctx.URSignature
+	}
+	field, err := ctx.DereferenceDict(*types.NewIndirectRef(r.Signature.ObjNr, 0))
+	if err != nil || field == nil {
+		return nil
+	}
+	d, err := ctx.DereferenceDict(field["V"])
+	if err != nil {
+		return nil
+	}
+	return d
+}
+
+// signerCertificate returns a PKCS#7 signature and the certificate of its
+// first signer, or nil for other kinds of signatures.
+func signerCertificate(sigDict types.Dict) (*pkcs7.PKCS7, *x509.Certificate) {
+	hl := sigDict.HexLiteralEntry("Contents")
+	if hl == nil {
+		return nil, nil
+	}
+	data, err := hl.Bytes()
+	if err != nil {
+		return nil, nil
+	}
+	p7, err := pkcs7.Parse(data)
+	if err != nil || len(p7.Signers) == 0 {
+		return nil, nil
+	}
+	return p7, pkcs7.GetCertFromCertsByIssuerAndSerial(p7.Certificates, p7.Signers[0].IssuerAndSerialNumber)
+}
+
+// trustedChain reports whether cert chains up to a certificate in trusted at
+// the signing time, using the certificates embedded in the signature.
+func trustedChain(p7 *pkcs7.PKCS7, cert *x509.Certificate, trusted *x509.CertPool, signingTime time.Time) bool {
+	if signingTime.IsZero() {
+		signingTime = time.Now()
+	}
+	_, err := pkcs7.VerifyCertChain(cert, p7.Certificates, trusted, signingTime)
+	return err == nil
+}
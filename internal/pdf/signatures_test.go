@@ -0,0 +1,154 @@
+package pdf
+
+import (
+	"bytes"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// signedPDF returns the path to a copy of sample.pdf signed by "Test Signer",
+// whose certificate was issued by the CA in testdata/truststore.
+func signedPDF() string {
+	return filepath.Join(testdataDir(), "signed.pdf")
+}
+
+// trustStoreDir returns the path to a trust store with the test CA.
+func trustStoreDir() string {
+	return filepath.Join(testdataDir(), "truststore")
+}
+
+func verifySigned(t *testing.T, path string, trusted *x509.CertPool) Signature {
+	t.Helper()
+	sigs, err := VerifySignatures(path, trusted, "")
+	if err != nil {
+		t.Fatalf("VerifySignatures() error = %v", err)
+	}
+	if len(sigs) != 1 {
+		t.Fatalf("got %d signatures, want 1: %+v", len(sigs), sigs)
+	}
+	return sigs[0]
+}
+
+func TestLoadTrustStore(t *testing.T) {
+	if _, err := LoadTrustStore(trustStoreDir()); err != nil {
+		t.Fatalf("LoadTrustStore() error = %v", err)
+	}
+	if _, err := LoadTrustStore(t.TempDir()); err == nil {
+		t.Error("LoadTrustStore() of an empty directory should fail")
+	}
+	if _, err := LoadTrustStore(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("LoadTrustStore() of a missing directory should fail")
+	}
+}
+
+func TestVerifySignatures(t *testing.T) {
+	trusted, err := LoadTrustStore(trustStoreDir())
+	if err != nil {
+		t.Fatalf("LoadTrustStore() error = %v", err)
+	}
+
+	s := verifySigned(t, signedPDF(), trusted)
+	if s.Status != SignatureValid {
+		t.Errorf("status = %q (%s, %v), want valid", s.Status, s.Reason, s.Problems)
+	}
+	if s.Field != "Signature1" || s.Type != "form" || s.SubFilter != "adbe.pkcs7.detached" {
+		t.Errorf("signature = %+v", s)
+	}
+	if s.Signer != "CN=Test Signer,O=pdf-cli" || s.Issuer != "CN=pdf-cli Test CA,O=pdf-cli" {
+		t.Errorf("signer = %q, issuer = %q", s.Signer, s.Issuer)
+	}
+	if s.SigningTime == nil || !s.SigningTime.Equal(time.Date(2026, 3, 2, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("signing time = %v", s.SigningTime)
+	}
+	info, _ := os.Stat(signedPDF())
+	if len(s.ByteRange) != 4 || s.ByteRange[0] != 0 || s.ByteRange[2]+s.ByteRange[3] != info.Size() {
+		t.Errorf("byte range = %v, file size %d", s.ByteRange, info.Size())
+	}
+	if s.ModifiedAfterSigning {
+		t.Error("signed.pdf should not be modified after signing")
+	}
+}
+
+func TestVerifySignaturesUpdatedAfterSigning(t *testing.T) {
+	trusted, err := LoadTrustStore(trustStoreDir())
+	if err != nil {
+		t.Fatalf("LoadTrustStore() error = %v", err)
+	}
+
+	// signed_updated.pdf adds a revision with a new title after signing.
+	s := verifySigned(t, filepath.Join(testdataDir(), "signed_updated.pdf"), trusted)
+	if s.Status != SignatureValid {
+		t.Errorf("status = %q (%s), want valid", s.Status, s.Reason)
+	}
+	if !s.ModifiedAfterSigning {
+		t.Error("ModifiedAfterSigning should be set for a later revision")
+	}
+	if s.Reason != reasonRevisionsAdded {
+		t.Errorf("reason = %q, want %q", s.Reason, reasonRevisionsAdded)
+	}
+}
+
+func TestCompareSignatures(t *testing.T) {
+	sigs := []Signature{
+		{Field: "Unsigned2"},
+		{Field: "Signature2", ByteRange: []int64{0, 9000, 12000, 100}},
+		{Field: "Unsigned1"},
+		{Field: "Signature1", ByteRange: []int64{0, 400, 3400, 100}},
+	}
+	slices.SortStableFunc(sigs, compareSignatures)
+	var fields []string
+	for _, s := range sigs {
+		fields = append(fields, s.Field)
+	}
+	if want := []string{"Signature1", "Signature2", "Unsigned1", "Unsigned2"}; !slices.Equal(fields, want) {
+		t.Errorf("order = %v, want %v", fields, want)
+	}
+}
+
+func TestVerifySignaturesTampered(t *testing.T) {
+	trusted, err := LoadTrustStore(trustStoreDir())
+	if err != nil {
+		t.Fatalf("LoadTrustStore() error = %v", err)
+	}
+	data, err := os.ReadFile(signedPDF())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := filepath.Join(t.TempDir(), "tampered.pdf")
+	data = bytes.Replace(data, []byte("(Page 2)"), []byte("(Page 9)"), 1)
+	if err := os.WriteFile(tampered, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s := verifySigned(t, tampered, trusted)
+	if s.Status != SignatureInvalid {
+		t.Errorf("status = %q, want invalid", s.Status)
+	}
+	if !s.ModifiedAfterSigning {
+		t.Error("ModifiedAfterSigning should be set for changed content")
+	}
+}
+
+func TestVerifySignaturesUntrusted(t *testing.T) {
+	s := verifySigned(t, signedPDF(), x509.NewCertPool())
+	if s.Status != SignatureUnknown {
+		t.Errorf("status = %q, want unknown", s.Status)
+	}
+	if s.Reason == "" {
+		t.Error("an untrusted signature should have a reason")
+	}
+}
+
+func TestVerifySignaturesUnsigned(t *testing.T) {
+	sigs, err := VerifySignatures(samplePDF(), x509.NewCertPool(), "")
+	if err != nil {
+		t.Fatalf("VerifySignatures() error = %v", err)
+	}
+	if len(sigs) != 0 {
+		t.Errorf("got %d signatures, want 0", len(sigs))
+	}
+}
@@ -0,0 +1,144 @@
+package pdf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+// TextStamp is a line of text stamped at a fixed position, as used for headers,
+// footers and Bates numbers.
+type TextStamp struct {
+	Text    string
+	Options WatermarkOptions
+}
+
+// BatesOptions controls the format and placement of Bates numbers.
+type BatesOptions struct {
+	Prefix string           // text before the number, e.g. "ACME"
+	Suffix string           // text after the number
+	Digits int              // minimum number of digits, padded with zeros
+	Style  WatermarkOptions // font, color and position of the number
+}
+
+// DefaultStampOptions returns options for small, upright black text placed at
+// position with margin points of space to the page edges.
+func DefaultStampOptions(position string, margin float64) WatermarkOptions {
+	opts := WatermarkOptions{
+		FontName: "Helvetica",
+		FontSize: 10,
+		Color:    "0 0 0",
+		Opacity:  1,
+		Position: position,
+	}
+	opts.OffsetX, opts.OffsetY = StampOffset(position, margin)
+	return opts
+}
+
+// StampOffset returns the offset that moves a stamp at position margin points
+// away from the page edges it is anchored to.
+func StampOffset(position string, margin float64) (dx, dy float64) {
+	position = strings.ToLower(position)
+	switch {
+	case strings.HasSuffix(position, "left"):
+		dx = margin
+	case strings.HasSuffix(position, "right"):
+		dx = -margin
+	}
+	switch {
+	case strings.HasPrefix(position, "top"):
+		dy = -margin
+	case strings.HasPrefix(position, "bottom"):
+		dy = margin
+	}
+	return dx, dy
+}
+
+// FormatBatesNumber formats n as a Bates number, e.g. "ACME000042".
+func FormatBatesNumber(prefix, suffix string, n, digits int) string {
+	num := strconv.Itoa(n)
+	if len(num) < digits {
+		num = strings.Repeat("0", digits-len(num)) + num
+	}
+	return prefix + num + suffix
+}
+
+// AddTextStamps stamps text on the selected pages (nil for all pages) in a single pass.
+// Stamp text may contain the placeholders described in ExpandWatermarkText.
+func AddTextStamps(input, output string, stamps []TextStamp, pages []int, password string) error {
+	if len(stamps) == 0 {
+		return fmt.Errorf("no text to stamp")
+	}
+
+	_, err := stampPages(input, output, pages, password, func(page, total int) []TextStamp {
+		resolved := make([]TextStamp, len(stamps))
+		for i, s := range stamps {
+			resolved[i] = TextStamp{Text: expandPageText(s.Text, page, total), Options: s.Options}
+		}
+		return resolved
+	})
+	return err
+}
+
+// AddBatesNumbers stamps consecutive Bates numbers on every page, starting with first.
+// It returns the number of pages stamped, so the next file can continue at first+count.
+func AddBatesNumbers(input, output string, first int, opts BatesOptions, password string) (int, error) {
+	if first < 0 {
+		return 0, fmt.Errorf("invalid start number %d: must not be negative", first)
+	}
+
+	return stampPages(input, output, nil, password, func(page, _ int) []TextStamp {
+		text := FormatBatesNumber(opts.Prefix, opts.Suffix, first+page-1, opts.Digits)
+		return []TextStamp{{Text: text, Options: opts.Style}}
+	})
+}
+
+// stampPages adds the stamps returned by stampsFor to each selected page (nil for all pages)
+// and returns the number of pages stamped. Every page gets its own watermarks, so the text
+// can differ from page to page.
+func stampPages(input, output string, pages []int, password string, stampsFor func(page, total int) []TextStamp) (int, error) {
+	total, err := PageCount(input, password)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get page count: %w", err)
+	}
+	if pages == nil {
+		for p := 1; p <= total; p++ {
+			pages = append(pages, p)
+		}
+	}
+
+	now := time.Now()
+	m := make(map[int][]*model.Watermark, len(pages))
+	for _, page := range pages {
+		for _, s := range stampsFor(page, total) {
+			if strings.TrimSpace(s.Text) == "" {
+				continue
+			}
+			wm, err := ParseTextWatermark(ExpandWatermarkText(s.Text, input, now), s.Options)
+			if err != nil {
+				return 0, err
+			}
+			m[page] = append(m[page], wm)
+		}
+	}
+	if len(m) == 0 {
+		return 0, fmt.Errorf("no text to stamp")
+	}
+
+	if err := api.AddWatermarksSliceMapFile(input, output, m, NewConfig(password)); err != nil {
+		return 0, err
+	}
+	return len(m), nil
+}
+
+// expandPageText resolves the {page} and {pages} placeholders for one page.
+func expandPageText(text string, page, total int) string {
+	return strings.NewReplacer(
+		"{pages}", strconv.Itoa(total),
+		"{page}", strconv.Itoa(page),
+	).Replace(text)
+}
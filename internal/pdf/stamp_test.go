@@ -0,0 +1,113 @@
+package pdf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFormatBatesNumber(t *testing.T) {
+	tests := []struct {
+		prefix, suffix string
+		n, digits      int
+		want           string
+	}{
+		{"ACME", "", 42, 6, "ACME000042"},
+		{"", "", 1, 1, "1"},
+		{"DEF-", "-C", 1234567, 6, "DEF-1234567-C"},
+		{"", "", 0, 3, "000"},
+	}
+	for _, tt := range tests {
+		if got := FormatBatesNumber(tt.prefix, tt.suffix, tt.n, tt.digits); got != tt.want {
+			t.Errorf("FormatBatesNumber(%q, %q, %d, %d) = %q, want %q", tt.prefix, tt.suffix, tt.n, tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestStampOffset(t *testing.T) {
+	tests := []struct {
+		position string
+		dx, dy   float64
+	}{
+		{"top-left", 20, -20},
+		{"top", 0, -20},
+		{"top-right", -20, -20},
+		{"bottom-left", 20, 20},
+		{"bottom", 0, 20},
+		{"bottom-right", -20, 20},
+		{"center", 0, 0},
+	}
+	for _, tt := range tests {
+		dx, dy := StampOffset(tt.position, 20)
+		if dx != tt.dx || dy != tt.dy {
+			t.Errorf("StampOffset(%q) = (%g, %g), want (%g, %g)", tt.position, dx, dy, tt.dx, tt.dy)
+		}
+	}
+}
+
+func TestAddBatesNumbers(t *testing.T) {
+	input := samplePDF()
+	if _, err := os.Stat(input); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output := filepath.Join(tmpDir, "bates.pdf")
+	opts := BatesOptions{Prefix: "ACME", Digits: 6, Style: DefaultStampOptions("bottom-right", 20)}
+
+	n, err := AddBatesNumbers(input, output, 100, opts, "")
+	if err != nil {
+		t.Fatalf("AddBatesNumbers() error = %v", err)
+	}
+	if n != 3 {
+		t.Errorf("AddBatesNumbers() = %d pages, want 3", n)
+	}
+	if _, err := os.Stat(output); err != nil {
+		t.Errorf("output not created: %v", err)
+	}
+
+	if _, err := AddBatesNumbers(input, output, -1, opts, ""); err == nil {
+		t.Error("AddBatesNumbers() with negative start should fail")
+	}
+}
+
+func TestAddTextStamps(t *testing.T) {
+	input := samplePDF()
+	if _, err := os.Stat(input); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output := filepath.Join(tmpDir, "stamped.pdf")
+	stamps := []TextStamp{
+		{Text: "{filename}", Options: DefaultStampOptions("top-left", 20)},
+		{Text: "Page {page} of {pages}", Options: DefaultStampOptions("bottom", 20)},
+	}
+
+	if err = AddTextStamps(input, output, stamps, []int{1, 3}, ""); err != nil {
+		t.Fatalf("AddTextStamps() error = %v", err)
+	}
+	if _, err := os.Stat(output); err != nil {
+		t.Errorf("output not created: %v", err)
+	}
+
+	if err := AddTextStamps(input, output, nil, nil, ""); err == nil {
+		t.Error("AddTextStamps() without stamps should fail")
+	}
+}
+
+func TestExpandPageText(t *testing.T) {
+	if got := expandPageText("Page {page} of {pages}", 2, 7); got != "Page 2 of 7" {
+		t.Errorf("expandPageText() = %q", got)
+	}
+}
@@ -157,6 +157,90 @@ func ExtractPages(input, output string, pages []int, password string) error {
 	return api.CollectFile(input, output, pagesToStrings(pages), NewConfig(password))
 }
 
+// DeletePages removes specific pages from a PDF
+func DeletePages(input, output string, pages []int, password string) error {
+	return api.RemovePagesFile(input, output, pagesToStrings(pages), NewConfig(password))
+}
+
+// InsertBlankPages inserts one blank page before or after each of the given pages.
+// If size is empty, each blank page matches the size of the page it is inserted next to.
+func InsertBlankPages(input, output string, positions []int, before bool, size, password string) error {
+	var pageConf *pdfcpu.PageConfiguration
+	if size != "" {
+		dim, _, err := ParsePageDim(size)
+		if err != nil {
+			return err
+		}
+		pageConf = &pdfcpu.PageConfiguration{PageDim: dim, UserDim: true, InpUnit: types.POINTS}
+	}
+	return api.InsertPagesFile(input, output, pagesToStrings(positions), before, pageConf, NewConfig(password))
+}
+
+// InsertPagesFrom inserts pages of source before or after each of the given pages of input.
+// sourcePages selects and orders the inserted pages; nil inserts all pages of source.
+//
+// The two files are merged into a temporary file first, then the final page
+// sequence is collected from it, so the same source pages may be inserted at
+// several positions.
+func InsertPagesFrom(input, output, source string, sourcePages, positions []int, before bool, password string) error {
+	basePages, err := PageCount(input, password)
+	if err != nil {
+		return fmt.Errorf("failed to get page count: %w", err)
+	}
+	if sourcePages == nil {
+		n, err := PageCount(source, password)
+		if err != nil {
+			return fmt.Errorf("failed to get page count of %s: %w", source, err)
+		}
+		for p := 1; p <= n; p++ {
+			sourcePages = append(sourcePages, p)
+		}
+	}
+
+	tmpFile, err := os.CreateTemp("", "pdf-insert-*.pdf")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	_ = tmpFile.Close()
+	unregisterTmp := cleanup.Register(tmpPath)
+	defer unregisterTmp()
+	defer os.Remove(tmpPath)
+
+	if err := api.MergeCreateFile([]string{input, source}, tmpPath, false, NewConfig(password)); err != nil {
+		return fmt.Errorf("failed to combine %s and %s: %w", input, source, err)
+	}
+
+	seq := insertSequence(basePages, positions, before, sourcePages)
+	return api.CollectFile(tmpPath, output, pagesToStrings(seq), NewConfig(password))
+}
+
+// insertSequence returns the page order of a merged document in which the
+// source pages (numbered from 1, following basePages base pages) are placed
+// before or after each position of the base document.
+func insertSequence(basePages int, positions []int, before bool, sourcePages []int) []int {
+	at := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		at[p] = true
+	}
+
+	seq := make([]int, 0, basePages+len(positions)*len(sourcePages))
+	for p := 1; p <= basePages; p++ {
+		if !before {
+			seq = append(seq, p)
+		}
+		if at[p] {
+			for _, sp := range sourcePages {
+				seq = append(seq, basePages+sp)
+			}
+		}
+		if before {
+			seq = append(seq, p)
+		}
+	}
+	return seq
+}
+
 // Rotate rotates pages in a PDF
 func Rotate(input, output string, angle int, pages []int, password string) error {
 	return api.RotateFile(input, output, angle, pagesToStrings(pages), NewConfig(password))
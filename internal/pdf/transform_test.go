@@ -493,3 +493,95 @@ func TestSplitWithProgressNonExistent(t *testing.T) {
 		t.Error("SplitWithProgress() expected error for non-existent file")
 	}
 }
+
+func TestInsertSequence(t *testing.T) {
+	tests := []struct {
+		name        string
+		positions   []int
+		before      bool
+		sourcePages []int
+		want        []int
+	}{
+		{"before first", []int{1}, true, []int{1}, []int{4, 1, 2, 3}},
+		{"after last", []int{3}, false, []int{1, 2}, []int{1, 2, 3, 4, 5}},
+		{"after multiple", []int{1, 3}, false, []int{2}, []int{1, 5, 2, 3, 5}},
+		{"duplicate position", []int{2, 2}, true, []int{1}, []int{1, 4, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := insertSequence(3, tt.positions, tt.before, tt.sourcePages)
+			if len(got) != len(tt.want) {
+				t.Fatalf("insertSequence() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("insertSequence() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestInsertBlankPages(t *testing.T) {
+	pdf := samplePDF()
+	if _, err := os.Stat(pdf); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output := filepath.Join(tmpDir, "blank.pdf")
+	if err := InsertBlankPages(pdf, output, []int{1, 3}, false, "A4", ""); err != nil {
+		t.Fatalf("InsertBlankPages() error = %v", err)
+	}
+
+	sizes, err := GetPageSizes(output, "")
+	if err != nil {
+		t.Fatalf("GetPageSizes() error = %v", err)
+	}
+	if len(sizes) != 2 || sizes[1].Name != "A4" {
+		t.Fatalf("GetPageSizes() = %+v, want Letter and A4 pages", sizes)
+	}
+	if got := sizes[1].Pages; len(got) != 2 || got[0] != 2 || got[1] != 5 {
+		t.Errorf("blank pages at %v, want [2 5]", got)
+	}
+}
+
+func TestInsertPagesFrom(t *testing.T) {
+	pdf := samplePDF()
+	if _, err := os.Stat(pdf); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output := filepath.Join(tmpDir, "inserted.pdf")
+	if err := InsertPagesFrom(pdf, output, pdf, []int{1, 2}, []int{2}, true, ""); err != nil {
+		t.Fatalf("InsertPagesFrom() error = %v", err)
+	}
+
+	count, err := PageCount(output, "")
+	if err != nil {
+		t.Fatalf("PageCount() error = %v", err)
+	}
+	if count != 5 {
+		t.Errorf("PageCount() = %d, want 5", count)
+	}
+
+	output = filepath.Join(tmpDir, "all.pdf")
+	if err := InsertPagesFrom(pdf, output, pdf, nil, []int{3}, false, ""); err != nil {
+		t.Fatalf("InsertPagesFrom() with all pages error = %v", err)
+	}
+	if count, _ := PageCount(output, ""); count != 6 {
+		t.Errorf("PageCount() = %d, want 6", count)
+	}
+}
@@ -1,27 +1,298 @@
 package pdf
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
+// watermarkMarker starts the marked content that pdfcpu wraps around every
+// watermark and stamp it adds to a page.
+const watermarkMarker = "/Artifact <</Subtype /Watermark /Type /Pagination >>BDC"
+
+// watermarkPositions maps the position names accepted on the command line to pdfcpu anchors.
+var watermarkPositions = map[string]string{
+	"center":        "c",
+	"top-left":      "tl",
+	"top":           "tc",
+	"top-center":    "tc",
+	"top-right":     "tr",
+	"left":          "l",
+	"right":         "r",
+	"bottom-left":   "bl",
+	"bottom":        "bc",
+	"bottom-center": "bc",
+	"bottom-right":  "br",
+}
+
+// WatermarkOptions controls the appearance and placement of a watermark.
+type WatermarkOptions struct {
+	FontName   string  // text only; empty uses Helvetica
+	FontSize   int     // text only; 0 scales the text relative to the page
+	Color      string  // text only; name (red), #RRGGBB or "r g b"; empty uses gray
+	Opacity    float64 // 0.0 (invisible) to 1.0 (opaque)
+	Rotation   float64 // degrees counter-clockwise, ignored when Diagonal is set
+	Diagonal   bool    // align with the page diagonal
+	Scale      float64 // size relative to the page (0 < s <= 1); 0 uses the default
+	Position   string  // center, top-left, top, top-right, left, right, bottom-left, bottom, bottom-right
+	OffsetX    float64 // horizontal offset from Position in points
+	OffsetY    float64 // vertical offset from Position in points
+	Background bool    // place behind page content instead of stamping on top
+}
+
+// DefaultTextWatermarkOptions returns the options used for text watermarks
+// when no styling is given: large gray text at 45 degrees across the page.
+func DefaultTextWatermarkOptions() WatermarkOptions {
+	return WatermarkOptions{
+		Color:    "0.5 0.5 0.5",
+		Opacity:  0.3,
+		Rotation: 45,
+		Scale:    1.0,
+		Position: "center",
+	}
+}
+
+// DefaultImageWatermarkOptions returns the options used for image watermarks
+// when no styling is given: a half-page image along the page diagonal.
+func DefaultImageWatermarkOptions() WatermarkOptions {
+	return WatermarkOptions{
+		Opacity:  0.3,
+		Diagonal: true,
+		Scale:    0.5,
+		Position: "center",
+	}
+}
+
 // AddWatermark adds a text watermark to a PDF
 func AddWatermark(input, output, text string, pages []int, password string) error {
-	wm, err := pdfcpu.ParseTextWatermarkDetails(text, "scale:1.0, rotation:45, opacity:0.3, color:0.5 0.5 0.5", true, types.POINTS)
+	return AddWatermarkWithOptions(input, output, text, DefaultTextWatermarkOptions(), pages, password)
+}
+
+// AddWatermarkWithOptions adds a styled text watermark to a PDF.
+// The text may contain the placeholders described in ExpandWatermarkText.
+func AddWatermarkWithOptions(input, output, text string, opts WatermarkOptions, pages []int, password string) error {
+	wm, err := ParseTextWatermark(ExpandWatermarkText(text, input, time.Now()), opts)
 	if err != nil {
-		return fmt.Errorf("failed to parse watermark: %w", err)
+		return err
 	}
 	return api.AddWatermarksFile(input, output, pagesToStrings(pages), wm, NewConfig(password))
 }
 
 // AddImageWatermark adds an image watermark to a PDF
 func AddImageWatermark(input, output, imagePath string, pages []int, password string) error {
-	wm, err := pdfcpu.ParseImageWatermarkDetails(imagePath, "scale:0.5, opacity:0.3", true, types.POINTS)
+	return AddImageWatermarkWithOptions(input, output, imagePath, DefaultImageWatermarkOptions(), pages, password)
+}
+
+// AddImageWatermarkWithOptions adds a styled image watermark to a PDF.
+func AddImageWatermarkWithOptions(input, output, imagePath string, opts WatermarkOptions, pages []int, password string) error {
+	wm, err := pdfcpu.ParseImageWatermarkDetails(imagePath, watermarkDescription(opts, false), !opts.Background, types.POINTS)
 	if err != nil {
 		return fmt.Errorf("failed to parse image watermark: %w", err)
 	}
 	return api.AddWatermarksFile(input, output, pagesToStrings(pages), wm, NewConfig(password))
 }
+
+// PageWatermarks reports the watermarks and stamps found on a page.
+type PageWatermarks struct {
+	Page  int
+	Count int
+}
+
+// ListWatermarks returns the pages that carry watermarks or stamps added by
+// pdf-cli or pdfcpu, in page order. Pages without watermarks are omitted.
+func ListWatermarks(path, password string) ([]PageWatermarks, error) {
+	ctx, err := readContext(path, password)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []PageWatermarks
+	for page := 1; page <= ctx.PageCount; page++ {
+		d, _, _, err := ctx.PageDict(page, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read page %d: %w", page, err)
+		}
+		content, err := ctx.PageContent(d, page)
+		if err != nil {
+			if errors.Is(err, model.ErrNoContent) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read content of page %d: %w", page, err)
+		}
+		if n := bytes.Count(content, []byte(watermarkMarker)); n > 0 {
+			result = append(result, PageWatermarks{Page: page, Count: n})
+		}
+	}
+	return result, nil
+}
+
+// RemoveWatermarks removes all watermarks and stamps added by pdf-cli or pdfcpu
+// from the selected pages (nil for all pages).
+func RemoveWatermarks(input, output string, pages []int, password string) error {
+	return api.RemoveWatermarksFile(input, output, pagesToStrings(pages), NewConfig(password))
+}
+
+// ParseTextWatermark builds a pdfcpu text watermark from already expanded text.
+// It can be used to validate options before processing any files.
+func ParseTextWatermark(text string, opts WatermarkOptions) (*model.Watermark, error) {
+	wm, err := pdfcpu.ParseTextWatermarkDetails(text, watermarkDescription(opts, true), !opts.Background, types.POINTS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse watermark: %w", err)
+	}
+	return wm, nil
+}
+
+// ValidateWatermarkOptions checks that the options describe a valid watermark.
+func ValidateWatermarkOptions(opts WatermarkOptions, isText bool) error {
+	if opts.Opacity < 0 || opts.Opacity > 1 {
+		return fmt.Errorf("invalid opacity %g: must be between 0 and 1", opts.Opacity)
+	}
+	if opts.Rotation < -180 || opts.Rotation > 180 {
+		return fmt.Errorf("invalid rotation %g: must be between -180 and 180 degrees", opts.Rotation)
+	}
+	if opts.Scale < 0 || opts.Scale > 1 {
+		return fmt.Errorf("invalid scale %g: must be greater than 0 and at most 1", opts.Scale)
+	}
+	if opts.FontSize < 0 {
+		return fmt.Errorf("invalid font size %d: must be positive", opts.FontSize)
+	}
+	if _, ok := watermarkPositions[strings.ToLower(opts.Position)]; !ok && opts.Position != "" {
+		return fmt.Errorf("invalid position %q: use center, top-left, top, top-right, left, right, bottom-left, bottom or bottom-right", opts.Position)
+	}
+	if isText {
+		_, err := ParseTextWatermark("x", opts)
+		return err
+	}
+	return nil
+}
+
+// ExpandWatermarkText resolves watermark text placeholders:
+//
+//	{page}     current page number
+//	{pages}    total number of pages
+//	{date}     current date (YYYY-MM-DD)
+//	{filename} base name of the input file
+//
+// Page placeholders are resolved by pdfcpu while stamping each page,
+// the others are resolved here. Unknown placeholders are kept as is.
+// A literal '%' directly followed by p, P, t or v is still read by pdfcpu
+// as one of its own placeholders.
+func ExpandWatermarkText(text, inputFile string, now time.Time) string {
+	values := map[string]string{
+		"{date}":     now.Format("2006-01-02"),
+		"{filename}": filepath.Base(inputFile),
+	}
+
+	var b, literal strings.Builder
+	afterPage := false
+	flush := func(beforePlaceholder bool) {
+		s := literal.String()
+		literal.Reset()
+		// pdfcpu reads the digits after %p as a page offset; a lone '%'
+		// ends the number and is dropped.
+		if afterPage && s != "" && s[0] >= '0' && s[0] <= '9' {
+			b.WriteByte('%')
+		}
+		if s != "" {
+			b.WriteString(escapePercent(s, beforePlaceholder))
+			afterPage = false
+		}
+	}
+	for text != "" {
+		switch {
+		case strings.HasPrefix(text, "{pages}"):
+			flush(true)
+			b.WriteString("%P")
+			afterPage = false
+			text = text[len("{pages}"):]
+		case strings.HasPrefix(text, "{page}"):
+			flush(true)
+			b.WriteString("%p")
+			afterPage = true
+			text = text[len("{page}"):]
+		default:
+			name := text[:1]
+			if end := strings.IndexByte(text, '}'); text[0] == '{' && end > 0 {
+				if _, ok := values[text[:end+1]]; ok {
+					name = text[:end+1]
+				}
+			}
+			if v, ok := values[name]; ok {
+				literal.WriteString(v)
+			} else {
+				literal.WriteString(name)
+			}
+			text = text[len(name):]
+		}
+	}
+	flush(false)
+	return b.String()
+}
+
+// escapePercent escapes the '%' characters of literal watermark text for
+// pdfcpu, which drops one '%' from every run of them. A run directly before
+// a page placeholder already gets the placeholder's own '%'.
+func escapePercent(s string, beforePlaceholder bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		b.WriteByte(s[i])
+		if s[i] == '%' && (i+1 == len(s) || s[i+1] != '%') && (i+1 < len(s) || !beforePlaceholder) {
+			b.WriteByte('%')
+		}
+	}
+	return b.String()
+}
+
+// watermarkDescription builds a pdfcpu watermark description string from options.
+func watermarkDescription(opts WatermarkOptions, isText bool) string {
+	var parts []string
+	add := func(key, value string) {
+		parts = append(parts, key+":"+value)
+	}
+
+	if isText {
+		if opts.FontName != "" {
+			add("fontname", opts.FontName)
+		}
+		if opts.Color != "" {
+			add("color", opts.Color)
+		}
+	}
+
+	switch {
+	case isText && opts.FontSize > 0:
+		add("points", strconv.Itoa(opts.FontSize))
+		add("scalefactor", "1 abs")
+	case opts.Scale > 0:
+		add("scalefactor", formatFloat(opts.Scale))
+	}
+
+	if opts.Diagonal {
+		add("diagonal", "1")
+	} else {
+		add("rotation", formatFloat(opts.Rotation))
+	}
+	add("opacity", formatFloat(opts.Opacity))
+
+	if anchor, ok := watermarkPositions[strings.ToLower(opts.Position)]; ok {
+		add("position", anchor)
+	}
+	if opts.OffsetX != 0 || opts.OffsetY != 0 {
+		add("offset", formatFloat(opts.OffsetX)+" "+formatFloat(opts.OffsetY))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatFloat formats a number for a pdfcpu description without trailing zeros.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,214 @@
+package pdf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/format"
+)
+
+func TestExpandWatermarkText(t *testing.T) {
+	now := time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"CONFIDENTIAL", "CONFIDENTIAL"},
+		{"Page {page} of {pages}", "Page %p of %P"},
+		{"{filename} - {date}", "report.pdf - 2026-03-09"},
+		{"50% off", "50%% off"},
+		{"100%%", "100%%%"},
+		{"{unknown}", "{unknown}"},
+		{"Page {page}1", "Page %p%1"},
+		{"{page}{date}", "%p%2026-03-09"},
+		{"{pages}1", "%P1"},
+		{"{page}% done", "%p%% done"},
+		{"%{page}", "%%p"},
+	}
+
+	for _, tt := range tests {
+		if got := ExpandWatermarkText(tt.text, "/tmp/docs/report.pdf", now); got != tt.want {
+			t.Errorf("ExpandWatermarkText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+
+	if got, want := ExpandWatermarkText("{filename}", "/tmp/docs/100%.pdf", now), "100%%.pdf"; got != want {
+		t.Errorf("ExpandWatermarkText with %% in the file name = %q, want %q", got, want)
+	}
+}
+
+func TestExpandWatermarkTextRendering(t *testing.T) {
+	now := time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		text, file string
+		want       string
+	}{
+		{"Page {page}1 of {pages}", "a.pdf", "Page 31 of 10"},
+		{"{page}{date}", "a.pdf", "32026-03-09"},
+		{"{filename}: 50%% off", "50% off.pdf", "50% off.pdf: 50%% off"},
+		{"%{page}", "a.pdf", "%3"},
+	}
+
+	for _, tt := range tests {
+		text := ExpandWatermarkText(tt.text, tt.file, now)
+		if got, _ := format.Text(text, "", 3, 10); got != tt.want {
+			t.Errorf("ExpandWatermarkText(%q) renders as %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestWatermarkDescription(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   WatermarkOptions
+		isText bool
+		want   string
+	}{
+		{
+			name:   "text defaults",
+			opts:   DefaultTextWatermarkOptions(),
+			isText: true,
+			want:   "color:0.5 0.5 0.5, scalefactor:1, rotation:45, opacity:0.3, position:c",
+		},
+		{
+			name:   "image defaults",
+			opts:   DefaultImageWatermarkOptions(),
+			isText: false,
+			want:   "scalefactor:0.5, diagonal:1, opacity:0.3, position:c",
+		},
+		{
+			name: "styled stamp",
+			opts: WatermarkOptions{
+				FontName: "Courier", FontSize: 10, Color: "red", Opacity: 1,
+				Position: "bottom-right", OffsetX: -20, OffsetY: 15,
+			},
+			isText: true,
+			want:   "fontname:Courier, color:red, points:10, scalefactor:1 abs, rotation:0, opacity:1, position:br, offset:-20 15",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := watermarkDescription(tt.opts, tt.isText); got != tt.want {
+				t.Errorf("watermarkDescription() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWatermarkOptions(t *testing.T) {
+	valid := DefaultTextWatermarkOptions()
+	if err := ValidateWatermarkOptions(valid, true); err != nil {
+		t.Errorf("ValidateWatermarkOptions(defaults) error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*WatermarkOptions)
+	}{
+		{"opacity", func(o *WatermarkOptions) { o.Opacity = 1.5 }},
+		{"rotation", func(o *WatermarkOptions) { o.Rotation = 270 }},
+		{"scale", func(o *WatermarkOptions) { o.Scale = 2 }},
+		{"font size", func(o *WatermarkOptions) { o.FontSize = -1 }},
+		{"position", func(o *WatermarkOptions) { o.Position = "middle" }},
+		{"font", func(o *WatermarkOptions) { o.FontName = "NoSuchFont" }},
+		{"color", func(o *WatermarkOptions) { o.Color = "purple-ish" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultTextWatermarkOptions()
+			tt.modify(&opts)
+			if err := ValidateWatermarkOptions(opts, true); err == nil {
+				t.Errorf("ValidateWatermarkOptions() expected error for invalid %s", tt.name)
+			}
+		})
+	}
+}
+
+func TestAddWatermarkWithOptions(t *testing.T) {
+	pdf := samplePDF()
+	if _, err := os.Stat(pdf); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	opts := DefaultTextWatermarkOptions()
+	opts.FontSize = 10
+	opts.Rotation = 0
+	opts.Position = "bottom"
+	opts.OffsetY = 20
+	opts.Background = true
+
+	output := filepath.Join(tmpDir, "stamped.pdf")
+	if err := AddWatermarkWithOptions(pdf, output, "CONFIDENTIAL - Page {page} of {pages}", opts, nil, ""); err != nil {
+		t.Fatalf("AddWatermarkWithOptions() error = %v", err)
+	}
+
+	ok, err := api.HasWatermarksFile(output, NewConfig(""))
+	if err != nil {
+		t.Fatalf("HasWatermarksFile() error = %v", err)
+	}
+	if !ok {
+		t.Error("AddWatermarkWithOptions() output has no watermark")
+	}
+}
+
+func TestListAndRemoveWatermarks(t *testing.T) {
+	input := samplePDF()
+	if _, err := os.Stat(input); os.IsNotExist(err) {
+		t.Skip("sample.pdf not found in testdata")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	found, err := ListWatermarks(input, "")
+	if err != nil {
+		t.Fatalf("ListWatermarks() error = %v", err)
+	}
+	if len(found) != 0 {
+		t.Errorf("ListWatermarks() on clean file = %v, want none", found)
+	}
+
+	marked := filepath.Join(tmpDir, "marked.pdf")
+	if err = AddWatermark(input, marked, "DRAFT", []int{1, 3}, ""); err != nil {
+		t.Fatalf("AddWatermark() error = %v", err)
+	}
+
+	found, err = ListWatermarks(marked, "")
+	if err != nil {
+		t.Fatalf("ListWatermarks() error = %v", err)
+	}
+	want := []PageWatermarks{{Page: 1, Count: 1}, {Page: 3, Count: 1}}
+	if len(found) != len(want) || found[0] != want[0] || found[1] != want[1] {
+		t.Errorf("ListWatermarks() = %v, want %v", found, want)
+	}
+
+	clean := filepath.Join(tmpDir, "clean.pdf")
+	if err = RemoveWatermarks(marked, clean, nil, ""); err != nil {
+		t.Fatalf("RemoveWatermarks() error = %v", err)
+	}
+	found, err = ListWatermarks(clean, "")
+	if err != nil {
+		t.Fatalf("ListWatermarks() error = %v", err)
+	}
+	if len(found) != 0 {
+		t.Errorf("ListWatermarks() after removal = %v, want none", found)
+	}
+
+	if err = RemoveWatermarks(input, filepath.Join(tmpDir, "none.pdf"), nil, ""); err == nil {
+		t.Error("RemoveWatermarks() on a file without watermarks should fail")
+	}
+}
@@ -0,0 +1,169 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// xmpName matches custom keys that can be used as XML element names.
+var xmpName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// pdfaIdentification matches the PDF/A part and conformance properties, which
+// are carried over when the XMP metadata is rewritten.
+var pdfaIdentification = regexp.MustCompile(`pdfaid:(part|conformance)(?:="([^"]*)"|>([^<]*)<)`)
+
+// GetXMP returns the XMP metadata packet of a PDF, or "" if it has none.
+func GetXMP(input, password string) (string, error) {
+	ctx, err := readContext(input, password)
+	if err != nil {
+		return "", err
+	}
+	data, err := catalogXMP(ctx)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// catalogXMP returns the decoded XMP metadata stream of the document catalog.
+func catalogXMP(ctx *model.Context) ([]byte, error) {
+	root, err := ctx.Catalog()
+	if err != nil {
+		return nil, err
+	}
+	o, found := root.Find("Metadata")
+	if !found {
+		return nil, nil
+	}
+	sd, _, err := ctx.DereferenceStreamDict(o)
+	if err != nil || sd == nil {
+		return nil, err
+	}
+	if err := sd.Decode(); err != nil {
+		return nil, err
+	}
+	return sd.Content, nil
+}
+
+// setXMP replaces the XMP metadata of the document with a packet describing info.
+func setXMP(ctx *model.Context, info types.Dict) error {
+	old, err := catalogXMP(ctx)
+	if err != nil {
+		return err
+	}
+
+	sd, err := ctx.NewStreamDictForBuf(buildXMP(ctx, info, old))
+	if err != nil {
+		return err
+	}
+	// Metadata stays uncompressed so that tools can find it without a PDF parser.
+	sd.FilterPipeline = nil
+	sd.Delete("Filter")
+	sd.InsertName("Type", "Metadata")
+	sd.InsertName("Subtype", "XML")
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	ir, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		return err
+	}
+	root, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+	root.Update("Metadata", *ir)
+	return nil
+}
+
+// buildXMP returns an XMP packet with the Dublin Core, PDF and XMP basic
+// properties for info. PDF/A identification found in old is kept.
+func buildXMP(ctx *model.Context, info types.Dict, old []byte) []byte {
+	text := func(key string) string {
+		if o, ok := info[key]; ok {
+			return infoText(ctx, o)
+		}
+		return ""
+	}
+	date := func(key string) string {
+		if t, ok := types.DateTime(text(key), true); ok {
+			return t.Format(time.RFC3339)
+		}
+		return ""
+	}
+
+	var b bytes.Buffer
+	b.WriteString("<?xpacket begin=\"\xef\xbb\xbf\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n")
+	b.WriteString("<x:xmpmeta xmlns:x=\"adobe:ns:meta/\">\n")
+	b.WriteString(" <rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\">\n")
+	b.WriteString("  <rdf:Description rdf:about=\"\"\n")
+	b.WriteString("    xmlns:dc=\"http://purl.org/dc/elements/1.1/\"\n")
+	b.WriteString("    xmlns:pdf=\"http://ns.adobe.com/pdf/1.3/\"\n")
+	b.WriteString("    xmlns:xmp=\"http://ns.adobe.com/xap/1.0/\"\n")
+	b.WriteString("    xmlns:pdfx=\"http://ns.adobe.com/pdfx/1.3/\"\n")
+	b.WriteString("    xmlns:pdfaid=\"http://www.aiim.org/pdfa/ns/id/\">\n")
+
+	element := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "   <%s>%s</%s>\n", name, escapeXML(value), name)
+		}
+	}
+	alt := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "   <%s><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></%s>\n", name, escapeXML(value), name)
+		}
+	}
+
+	element("dc:format", "application/pdf")
+	alt("dc:title", text("Title"))
+	if author := text("Author"); author != "" {
+		fmt.Fprintf(&b, "   <dc:creator><rdf:Seq><rdf:li>%s</rdf:li></rdf:Seq></dc:creator>\n", escapeXML(author))
+	}
+	alt("dc:description", text("Subject"))
+	element("pdf:Keywords", text("Keywords"))
+	element("pdf:Producer", text("Producer"))
+	element("xmp:CreatorTool", text("Creator"))
+	element("xmp:CreateDate", date("CreationDate"))
+	element("xmp:ModifyDate", date("ModDate"))
+	element("xmp:MetadataDate", time.Now().Format(time.RFC3339))
+
+	var custom []string
+	for key := range info {
+		if !isStandardInfoKey(key) && xmpName.MatchString(key) {
+			custom = append(custom, key)
+		}
+	}
+	sort.Strings(custom)
+	for _, key := range custom {
+		element("pdfx:"+key, text(key))
+	}
+
+	for _, m := range pdfaIdentification.FindAllSubmatch(old, -1) {
+		value := string(m[2])
+		if value == "" {
+			value = string(m[3])
+		}
+		element("pdfaid:"+string(m[1]), value)
+	}
+
+	b.WriteString("  </rdf:Description>\n")
+	b.WriteString(" </rdf:RDF>\n")
+	b.WriteString("</x:xmpmeta>\n")
+	b.WriteString("<?xpacket end=\"w\"?>")
+	return b.Bytes()
+}
+
+// escapeXML escapes s for use as XML character data.
+func escapeXML(s string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,114 @@
+package pdferrors
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FileResult is the outcome of a batch operation on one file. Err is nil
+// when the file was processed; Skipped files were not processed because an
+// earlier file failed with --fail-fast. Warnings describe problems that did
+// not stop the file from being processed.
+type FileResult struct {
+	Input      string
+	Output     string
+	Err        error
+	Skipped    bool
+	Warnings   []string
+	Duration   time.Duration
+	SizeBefore int64
+	SizeAfter  int64
+}
+
+// Status returns StatusOK, StatusFailed or StatusSkipped.
+func (r FileResult) Status() string {
+	switch {
+	case r.Err != nil:
+		return StatusFailed
+	case r.Skipped:
+		return StatusSkipped
+	default:
+		return StatusOK
+	}
+}
+
+// Summary counts the results of a batch.
+type Summary struct {
+	Total      int   `json:"total"`
+	Succeeded  int   `json:"succeeded"`
+	Failed     int   `json:"failed"`
+	Skipped    int   `json:"skipped"`
+	DurationMS int64 `json:"duration_ms"`
+	SizeBefore int64 `json:"size_before"`
+	SizeAfter  int64 `json:"size_after"`
+}
+
+// Summarize counts results of a batch that took elapsed.
+func Summarize(results []FileResult, elapsed time.Duration) Summary {
+	s := Summary{Total: len(results), DurationMS: elapsed.Milliseconds()}
+	for _, r := range results {
+		switch r.Status() {
+		case StatusOK:
+			s.Succeeded++
+			s.SizeBefore += r.SizeBefore
+			s.SizeAfter += r.SizeAfter
+		case StatusFailed:
+			s.Failed++
+		default:
+			s.Skipped++
+		}
+	}
+	return s
+}
+
+func (s Summary) String() string {
+	msg := fmt.Sprintf("%d files: %d succeeded, %d failed", s.Total, s.Succeeded, s.Failed)
+	if s.Skipped > 0 {
+		msg += fmt.Sprintf(", %d skipped", s.Skipped)
+	}
+	return msg + fmt.Sprintf(" (%s)", time.Duration(s.DurationMS)*time.Millisecond)
+}
+
+// BatchError reports a batch operation of which at least one file failed.
+// Results holds every file of the batch in input order.
+type BatchError struct {
+	Results []FileResult
+	Elapsed time.Duration
+}
+
+func (e *BatchError) Error() string {
+	var msgs []string
+	for _, r := range e.Results {
+		if r.Err != nil {
+			msgs = append(msgs, fmt.Sprintf("%s: %v", r.Input, r.Err))
+		}
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (e *BatchError) Unwrap() []error {
+	var errs []error
+	for _, r := range e.Results {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs
+}
+
+// Summary counts the results of the batch.
+func (e *BatchError) Summary() Summary {
+	return Summarize(e.Results, e.Elapsed)
+}
+
+// NewBatchError returns the results of a batch as a BatchError, or nil if
+// no file failed.
+func NewBatchError(results []FileResult, elapsed time.Duration) error {
+	for _, r := range results {
+		if r.Err != nil {
+			return &BatchError{Results: results, Elapsed: elapsed}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package pdferrors
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBatchError(t *testing.T) {
+	if err := NewBatchError([]FileResult{{Input: "a.pdf"}}, 0); err != nil {
+		t.Errorf("NewBatchError() without errors = %v, want nil", err)
+	}
+	err := NewBatchError([]FileResult{
+		{Input: "a.pdf", Err: ErrCorruptPDF},
+		{Input: "b.pdf"},
+		{Input: "c.pdf", Err: errors.New("boom")},
+	}, time.Second)
+	if want := "a.pdf: PDF file is corrupted\nc.pdf: boom"; err.Error() != want {
+		t.Errorf("BatchError.Error() = %q, want %q", err.Error(), want)
+	}
+	if !errors.Is(err, ErrCorruptPDF) {
+		t.Error("BatchError should unwrap to its errors")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	results := []FileResult{
+		{Input: "a.pdf", SizeBefore: 100, SizeAfter: 60},
+		{Input: "b.pdf", SizeBefore: 50, Err: errors.New("boom")},
+		{Input: "c.pdf", Skipped: true},
+	}
+	want := Summary{Total: 3, Succeeded: 1, Failed: 1, Skipped: 1, DurationMS: 1500, SizeBefore: 100, SizeAfter: 60}
+	got := Summarize(results, 1500*time.Millisecond)
+	if got != want {
+		t.Errorf("Summarize() = %+v, want %+v", got, want)
+	}
+	if s := got.String(); s != "3 files: 1 succeeded, 1 failed, 1 skipped (1.5s)" {
+		t.Errorf("Summary.String() = %q", s)
+	}
+
+	statuses := []string{StatusOK, StatusFailed, StatusSkipped}
+	for i, r := range results {
+		if r.Status() != statuses[i] {
+			t.Errorf("Status() of %s = %s, want %s", r.Input, r.Status(), statuses[i])
+		}
+	}
+}
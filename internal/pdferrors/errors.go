@@ -3,7 +3,11 @@ package pdferrors
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 )
 
 // PDFError represents a user-friendly error for PDF operations
@@ -12,6 +16,7 @@ type PDFError struct {
 	File      string
 	Cause     error
 	Hint      string
+	Code      int // process exit code, see ExitCode
 }
 
 func (e *PDFError) Error() string {
@@ -38,6 +43,7 @@ func NewPDFError(operation, file string, cause error) *PDFError {
 		Operation: operation,
 		File:      file,
 		Cause:     cause,
+		Code:      exitCodeOf(cause),
 	}
 }
 
@@ -56,32 +62,121 @@ var (
 	ErrWrongPassword    = errors.New("incorrect password")
 	ErrCorruptPDF       = errors.New("PDF file is corrupted")
 	ErrOutputExists     = errors.New("output file already exists")
+	ErrNoWatermarks     = errors.New("no watermarks found")
+
+	ErrOwnerPasswordRequired = errors.New("owner password required")
+)
+
+// Hints for the password errors
+const (
+	hintPasswordRequired      = "Use --password-file or PDF_CLI_PASSWORD to provide the document password"
+	hintWrongPassword         = "Check the password given with --password-file or PDF_CLI_PASSWORD"
+	hintOwnerPasswordRequired = "This operation is restricted by the document permissions; provide the owner password"
+	hintDecryptFirst          = "Decrypt the file first with 'pdf decrypt'"
 )
 
+// ownerPasswordMessages are the pdfcpu errors for operations that need the
+// owner password. pdfcpu does not export them as sentinel errors.
+var ownerPasswordMessages = []string{
+	"pdfcpu: please provide the owner password with --opw",
+	"pdfcpu: please provide owner password and optional user password",
+	"pdfcpu: operation restricted via pdfcpu's permission bits setting",
+}
+
+// passwordCause returns the password error err stands for, or nil.
+func passwordCause(err error) error {
+	switch {
+	case errors.Is(err, ErrPasswordRequired):
+		return ErrPasswordRequired
+	case errors.Is(err, ErrWrongPassword), errors.Is(err, pdfcpu.ErrWrongPassword):
+		return ErrWrongPassword
+	case errors.Is(err, ErrOwnerPasswordRequired):
+		return ErrOwnerPasswordRequired
+	}
+	for _, msg := range ownerPasswordMessages {
+		if err.Error() == msg {
+			return ErrOwnerPasswordRequired
+		}
+	}
+	return nil
+}
+
+// passwordHint returns the hint for a password error cause.
+func passwordHint(cause error) string {
+	switch cause {
+	case ErrPasswordRequired:
+		return hintPasswordRequired
+	case ErrWrongPassword:
+		return hintWrongPassword
+	default:
+		return hintOwnerPasswordRequired
+	}
+}
+
+// corruptPDFErrors are the pdfcpu errors for files that cannot be parsed.
+var corruptPDFErrors = []error{
+	pdfcpu.ErrCorruptHeader,
+	pdfcpu.ErrMissingXRefSection,
+	pdfcpu.ErrReferenceDoesNotExist,
+	model.ErrCorruptObjectOffset,
+}
+
+// isCorruptPDF checks if err is a pdfcpu error for an unreadable file.
+func isCorruptPDF(err error) bool {
+	for _, corrupt := range corruptPDFErrors {
+		if errors.Is(err, corrupt) {
+			return true
+		}
+	}
+	return false
+}
+
 // WrapError wraps an error with additional context
 func WrapError(operation string, file string, err error) error {
 	if err == nil {
 		return nil
 	}
+	pdfErr := classifyError(operation, file, err)
+	pdfErr.Code = exitCodeOf(pdfErr.Cause)
+	return pdfErr
+}
 
+// classifyError maps err to a PDFError with a known cause where possible.
+func classifyError(operation string, file string, err error) *PDFError {
 	// Check for known error patterns and provide user-friendly messages
 	errStr := err.Error()
 
 	switch {
-	case strings.Contains(errStr, "no such file"):
+	case errors.Is(err, fs.ErrNotExist) || strings.Contains(errStr, "no such file"):
 		return &PDFError{
 			Operation: operation,
 			File:      file,
 			Cause:     ErrFileNotFound,
 		}
-	case strings.Contains(errStr, "encrypted") || strings.Contains(errStr, "password"):
+	case passwordCause(err) != nil:
+		cause := passwordCause(err)
+		return &PDFError{
+			Operation: operation,
+			File:      file,
+			Cause:     cause,
+			Hint:      passwordHint(cause),
+		}
+	case strings.HasSuffix(errStr, "is encrypted"):
+		// The operation does not accept encrypted files at all
 		return &PDFError{
 			Operation: operation,
 			File:      file,
 			Cause:     ErrPasswordRequired,
-			Hint:      "Use --password to provide the document password",
+			Hint:      hintDecryptFirst,
+		}
+	case strings.Contains(errStr, "no watermarks found"):
+		return &PDFError{
+			Operation: operation,
+			File:      file,
+			Cause:     ErrNoWatermarks,
+			Hint:      "Use 'pdf watermark --list' to see which pages have watermarks",
 		}
-	case strings.Contains(errStr, "invalid PDF") || strings.Contains(errStr, "malformed"):
+	case isCorruptPDF(err) || strings.Contains(errStr, "invalid PDF") || strings.Contains(errStr, "malformed"):
 		return &PDFError{
 			Operation: operation,
 			File:      file,
@@ -96,6 +191,28 @@ func WrapError(operation string, file string, err error) error {
 	}
 }
 
+// WithoutPassword refines an error of an operation that ran without a
+// password: pdfcpu reports a missing password as a wrong one, so an
+// incorrect password becomes a required password. Every PDFError in the
+// error tree is refined, such as those of each file of a BatchError.
+func WithoutPassword(err error) error {
+	switch e := err.(type) {
+	case *PDFError:
+		if errors.Is(e.Cause, ErrWrongPassword) {
+			e.Cause = ErrPasswordRequired
+			e.Hint = hintPasswordRequired
+			e.Code = ExitPasswordRequired
+		}
+	case interface{ Unwrap() []error }:
+		for _, wrapped := range e.Unwrap() {
+			WithoutPassword(wrapped)
+		}
+	case interface{ Unwrap() error }:
+		WithoutPassword(e.Unwrap())
+	}
+	return err
+}
+
 // FormatError formats an error for display to the user
 func FormatError(err error) string {
 	if err == nil {
@@ -127,3 +244,21 @@ func IsPasswordRequired(err error) bool {
 	}
 	return false
 }
+
+// IsWrongPassword checks if an error indicates an incorrect password
+func IsWrongPassword(err error) bool {
+	var pdfErr *PDFError
+	if errors.As(err, &pdfErr) {
+		return errors.Is(pdfErr.Cause, ErrWrongPassword)
+	}
+	return false
+}
+
+// IsOwnerPasswordRequired checks if an error indicates the operation needs the owner password
+func IsOwnerPasswordRequired(err error) bool {
+	var pdfErr *PDFError
+	if errors.As(err, &pdfErr) {
+		return errors.Is(pdfErr.Cause, ErrOwnerPasswordRequired)
+	}
+	return false
+}
@@ -2,8 +2,12 @@ package pdferrors
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 )
 
 func TestPDFError_Error(t *testing.T) {
@@ -100,6 +104,27 @@ func TestWrapError(t *testing.T) {
 			err:       errors.New("document is encrypted"),
 			wantCause: ErrPasswordRequired,
 		},
+		{
+			name:      "wrong password error",
+			operation: "reading",
+			file:      "secure.pdf",
+			err:       fmt.Errorf("read: %w", pdfcpu.ErrWrongPassword),
+			wantCause: ErrWrongPassword,
+		},
+		{
+			name:      "owner password error",
+			operation: "changing permissions",
+			file:      "secure.pdf",
+			err:       errors.New("pdfcpu: please provide the owner password with --opw"),
+			wantCause: ErrOwnerPasswordRequired,
+		},
+		{
+			name:      "not encrypted error",
+			operation: "decrypting",
+			file:      "plain.pdf",
+			err:       errors.New("pdfcpu: this file is not encrypted"),
+			wantCause: nil,
+		},
 		{
 			name:      "invalid PDF error",
 			operation: "reading",
@@ -136,6 +161,9 @@ func TestWrapError(t *testing.T) {
 			if tt.wantCause != nil && !errors.Is(pdfErr.Cause, tt.wantCause) {
 				t.Errorf("WrapError() cause = %v, want %v", pdfErr.Cause, tt.wantCause)
 			}
+			if tt.wantCause == nil && pdfErr.Cause != tt.err {
+				t.Errorf("WrapError() cause = %v, want the original error", pdfErr.Cause)
+			}
 		})
 	}
 }
@@ -243,6 +271,55 @@ func TestIsPasswordRequired(t *testing.T) {
 	}
 }
 
+func TestPasswordErrorHints(t *testing.T) {
+	hints := map[string]bool{}
+	for _, err := range []error{
+		pdfcpu.ErrWrongPassword,
+		errors.New("pdfcpu: operation restricted via pdfcpu's permission bits setting"),
+		errors.New("pdfcpu: this file is encrypted"),
+		WithoutPassword(WrapError("reading", "secure.pdf", pdfcpu.ErrWrongPassword)),
+	} {
+		var pdfErr *PDFError
+		if !errors.As(WrapError("reading", "secure.pdf", err), &pdfErr) || pdfErr.Hint == "" {
+			t.Fatalf("WrapError(%v) has no hint", err)
+		}
+		hints[pdfErr.Hint] = true
+	}
+	if len(hints) != 4 {
+		t.Errorf("password errors should have distinct hints, got %v", hints)
+	}
+}
+
+func TestWithoutPassword(t *testing.T) {
+	wrong := fmt.Errorf("secure.pdf: %w", WrapError("reading", "secure.pdf", pdfcpu.ErrWrongPassword))
+	err := WithoutPassword(wrong)
+	if !IsPasswordRequired(err) {
+		t.Errorf("WithoutPassword() = %v, want password required", err)
+	}
+	if !strings.HasPrefix(err.Error(), "secure.pdf: ") {
+		t.Errorf("WithoutPassword() should keep the context, got %v", err)
+	}
+
+	other := WrapError("reading", "corrupt.pdf", errors.New("invalid PDF"))
+	if got := WithoutPassword(other); got != other || IsPasswordRequired(got) {
+		t.Errorf("WithoutPassword() changed an unrelated error: %v", got)
+	}
+	batch := WithoutPassword(NewBatchError([]FileResult{
+		{Input: "a.pdf", Err: WrapError("reading", "a.pdf", pdfcpu.ErrWrongPassword)},
+		{Input: "b.pdf", Err: WrapError("reading", "b.pdf", pdfcpu.ErrWrongPassword)},
+		{Input: "c.pdf", Err: other},
+	}, time.Second))
+	var batchErr *BatchError
+	if !errors.As(batch, &batchErr) {
+		t.Fatalf("WithoutPassword() = %v, want a BatchError", batch)
+	}
+	for i, want := range []int{ExitPasswordRequired, ExitPasswordRequired, ExitInvalidPDF} {
+		if got := ExitCode(batchErr.Results[i].Err); got != want {
+			t.Errorf("%s: exit code = %d, want %d", batchErr.Results[i].Input, got, want)
+		}
+	}
+}
+
 func TestPDFError_Unwrap(t *testing.T) {
 	cause := errors.New("original error")
 	err := &PDFError{
@@ -0,0 +1,67 @@
+package pdferrors
+
+import (
+	"errors"
+	"io/fs"
+)
+
+// Process exit codes. They are documented in the "Exit Codes" section of
+// the README; scripts rely on them, so existing values must not change.
+const (
+	ExitOK                    = 0
+	ExitError                 = 1 // any error without a more specific code
+	ExitFileNotFound          = 2
+	ExitInvalidPDF            = 3 // not a PDF or a corrupted PDF
+	ExitPasswordRequired      = 4
+	ExitWrongPassword         = 5
+	ExitOwnerPasswordRequired = 6
+	ExitOutputExists          = 7
+	ExitInvalidPages          = 8
+	ExitPartialFailure        = 9 // some files of a batch failed
+)
+
+// exitCodes maps the sentinel errors to their exit codes.
+var exitCodes = []struct {
+	err  error
+	code int
+}{
+	{ErrFileNotFound, ExitFileNotFound},
+	{fs.ErrNotExist, ExitFileNotFound},
+	{ErrNotPDF, ExitInvalidPDF},
+	{ErrCorruptPDF, ExitInvalidPDF},
+	{ErrPasswordRequired, ExitPasswordRequired},
+	{ErrWrongPassword, ExitWrongPassword},
+	{ErrOwnerPasswordRequired, ExitOwnerPasswordRequired},
+	{ErrOutputExists, ExitOutputExists},
+	{ErrInvalidPages, ExitInvalidPages},
+}
+
+// exitCodeOf returns the exit code of the first sentinel error in err's
+// chain, or ExitError.
+func exitCodeOf(err error) int {
+	for _, c := range exitCodes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	return ExitError
+}
+
+// ExitCode returns the process exit code for err: ExitOK for nil,
+// ExitPartialFailure when some files of a batch succeeded and others
+// failed, the code of the first PDFError or sentinel error otherwise and
+// ExitError as fallback.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var batchErr *BatchError
+	if errors.As(err, &batchErr) && batchErr.Summary().Succeeded > 0 {
+		return ExitPartialFailure
+	}
+	var pdfErr *PDFError
+	if errors.As(err, &pdfErr) && pdfErr.Code != 0 {
+		return pdfErr.Code
+	}
+	return exitCodeOf(err)
+}
@@ -0,0 +1,55 @@
+package pdferrors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestExitCode(t *testing.T) {
+	notFound := WrapError("reading", "a.pdf", ErrFileNotFound)
+	corrupt := WrapError("reading", "b.pdf", fmt.Errorf("read: %w", pdfcpu.ErrCorruptHeader))
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"file not found", fmt.Errorf("%w: a.pdf", ErrFileNotFound), ExitFileNotFound},
+		{"missing file", WrapError("reading", "a.pdf", os.ErrNotExist), ExitFileNotFound},
+		{"not a PDF", fmt.Errorf("%w: a.txt", ErrNotPDF), ExitInvalidPDF},
+		{"corrupt PDF", corrupt, ExitInvalidPDF},
+		{"password required", &PDFError{Cause: ErrPasswordRequired}, ExitPasswordRequired},
+		{"wrong password", WrapError("reading", "a.pdf", pdfcpu.ErrWrongPassword), ExitWrongPassword},
+		{"owner password", &PDFError{Cause: ErrOwnerPasswordRequired}, ExitOwnerPasswordRequired},
+		{"output exists", fmt.Errorf("%w: out.pdf", ErrOutputExists), ExitOutputExists},
+		{"invalid pages", fmt.Errorf("%w: page 9", ErrInvalidPages), ExitInvalidPages},
+		{"explicit code", &PDFError{Cause: errors.New("boom"), Code: ExitOutputExists}, ExitOutputExists},
+		{"partial batch", NewBatchError([]FileResult{{Input: "a.pdf", Err: notFound}, {Input: "c.pdf"}}, 0), ExitPartialFailure},
+		{"failed batch", NewBatchError([]FileResult{{Input: "b.pdf", Err: corrupt}, {Input: "a.pdf", Err: notFound}}, 0), ExitInvalidPDF},
+		{"fail-fast batch", NewBatchError([]FileResult{{Input: "b.pdf", Err: corrupt}, {Input: "a.pdf", Skipped: true}}, 0), ExitInvalidPDF},
+		{"other", errors.New("boom"), ExitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapErrorCode(t *testing.T) {
+	var pdfErr *PDFError
+	if !errors.As(WrapError("reading", "a.pdf", pdfcpu.ErrWrongPassword), &pdfErr) || pdfErr.Code != ExitWrongPassword {
+		t.Errorf("WrapError() code = %d, want %d", pdfErr.Code, ExitWrongPassword)
+	}
+	if code := NewPDFError("writing", "a.pdf", ErrOutputExists).Code; code != ExitOutputExists {
+		t.Errorf("NewPDFError() code = %d, want %d", code, ExitOutputExists)
+	}
+}
@@ -0,0 +1,111 @@
+package pdferrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Result statuses of the files of a batch
+const (
+	StatusOK      = "ok"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+)
+
+// ErrorInfo is the machine-readable form of an error.
+type ErrorInfo struct {
+	Operation string `json:"operation,omitempty"`
+	File      string `json:"file,omitempty"`
+	Cause     string `json:"cause"`
+	Hint      string `json:"hint,omitempty"`
+	Code      int    `json:"code"`
+}
+
+// ResultInfo is the machine-readable outcome of one file of a batch.
+type ResultInfo struct {
+	Input      string     `json:"input"`
+	Output     string     `json:"output,omitempty"`
+	Status     string     `json:"status"`
+	Error      *ErrorInfo `json:"error,omitempty"`
+	Warnings   []string   `json:"warnings,omitempty"`
+	DurationMS int64      `json:"duration_ms"`
+	SizeBefore int64      `json:"size_before,omitempty"`
+	SizeAfter  int64      `json:"size_after,omitempty"`
+}
+
+// Envelope is the JSON document written for a failed command and for the
+// results of a batch. Error is only set on failure, Results and Summary
+// only for batch operations.
+type Envelope struct {
+	Error   *ErrorInfo   `json:"error,omitempty"`
+	Results []ResultInfo `json:"results,omitempty"`
+	Summary *Summary     `json:"summary,omitempty"`
+}
+
+// NewErrorInfo describes err, taking operation, file and hint from the
+// PDFError it wraps.
+func NewErrorInfo(err error) ErrorInfo {
+	info := ErrorInfo{Cause: err.Error(), Code: ExitCode(err)}
+	var pdfErr *PDFError
+	if errors.As(err, &pdfErr) {
+		info.Operation = pdfErr.Operation
+		info.File = pdfErr.File
+		info.Cause = pdfErr.Cause.Error()
+		info.Hint = pdfErr.Hint
+	}
+	return info
+}
+
+// NewEnvelope returns the JSON envelope for err. For a BatchError the
+// envelope summarizes the batch and lists the result of each file.
+func NewEnvelope(err error) Envelope {
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		info := NewErrorInfo(err)
+		return Envelope{Error: &info}
+	}
+
+	env := NewBatchEnvelope(batchErr.Results, batchErr.Elapsed)
+	env.Error = &ErrorInfo{
+		Cause: fmt.Sprintf("%d of %d files failed", env.Summary.Failed, env.Summary.Total),
+		Code:  ExitCode(err),
+	}
+	return env
+}
+
+// NewBatchEnvelope returns the JSON envelope listing the results of a
+// batch that took elapsed.
+func NewBatchEnvelope(results []FileResult, elapsed time.Duration) Envelope {
+	summary := Summarize(results, elapsed)
+	env := Envelope{Results: make([]ResultInfo, 0, len(results)), Summary: &summary}
+	for _, r := range results {
+		result := ResultInfo{
+			Input:      r.Input,
+			Output:     r.Output,
+			Status:     r.Status(),
+			Warnings:   r.Warnings,
+			DurationMS: r.Duration.Milliseconds(),
+			SizeBefore: r.SizeBefore,
+			SizeAfter:  r.SizeAfter,
+		}
+		if r.Err != nil {
+			info := NewErrorInfo(r.Err)
+			result.Error = &info
+		}
+		env.Results = append(env.Results, result)
+	}
+	return env
+}
+
+// JSON returns the envelope on a single line.
+func (e Envelope) JSON() []byte {
+	data, _ := json.Marshal(e) // only strings and ints, cannot fail
+	return data
+}
+
+// EnvelopeJSON returns the JSON envelope of err on a single line.
+func EnvelopeJSON(err error) []byte {
+	return NewEnvelope(err).JSON()
+}
@@ -0,0 +1,78 @@
+package pdferrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestNewEnvelope(t *testing.T) {
+	err := fmt.Errorf("secure.pdf: %w", WrapError("reading", "secure.pdf", pdfcpu.ErrWrongPassword))
+	want := ErrorInfo{
+		Operation: "reading",
+		File:      "secure.pdf",
+		Cause:     "incorrect password",
+		Hint:      hintWrongPassword,
+		Code:      ExitWrongPassword,
+	}
+	env := NewEnvelope(err)
+	if env.Error == nil || *env.Error != want || env.Results != nil || env.Summary != nil {
+		t.Errorf("NewEnvelope() = %+v, want %+v", env, want)
+	}
+
+	env = NewEnvelope(errors.New("boom"))
+	if want := (ErrorInfo{Cause: "boom", Code: ExitError}); *env.Error != want {
+		t.Errorf("NewEnvelope() = %+v, want %+v", env.Error, want)
+	}
+}
+
+func TestNewEnvelopeBatch(t *testing.T) {
+	err := NewBatchError([]FileResult{
+		{Input: "a.pdf", Output: "a_out.pdf", SizeBefore: 10, SizeAfter: 5, Duration: 2 * time.Millisecond},
+		{Input: "b.pdf", Output: "b_out.pdf", Err: WrapError("compressing", "b.pdf", ErrCorruptPDF)},
+	}, time.Second)
+	env := NewEnvelope(err)
+	if env.Error.Cause != "1 of 2 files failed" || env.Error.Code != ExitPartialFailure {
+		t.Errorf("NewEnvelope() error = %+v", env.Error)
+	}
+	if env.Summary == nil || env.Summary.Succeeded != 1 || env.Summary.Failed != 1 {
+		t.Errorf("NewEnvelope() summary = %+v", env.Summary)
+	}
+	if len(env.Results) != 2 {
+		t.Fatalf("NewEnvelope() results = %+v, want 2", env.Results)
+	}
+	want := ResultInfo{Input: "a.pdf", Output: "a_out.pdf", Status: StatusOK, DurationMS: 2, SizeBefore: 10, SizeAfter: 5}
+	if r := env.Results[0]; !reflect.DeepEqual(r, want) {
+		t.Errorf("result[0] = %+v, want %+v", r, want)
+	}
+	if r := env.Results[1]; r.Input != "b.pdf" || r.Status != StatusFailed || r.Error == nil || r.Error.Code != ExitInvalidPDF {
+		t.Errorf("result[1] = %+v", r)
+	}
+}
+
+func TestNewBatchEnvelope(t *testing.T) {
+	env := NewBatchEnvelope([]FileResult{{Input: "a.pdf", Warnings: []string{"page 2 not searched"}}, {Input: "b.pdf", Skipped: true}}, 0)
+	if env.Error != nil || len(env.Results) != 2 || env.Results[1].Status != StatusSkipped || len(env.Results[0].Warnings) != 1 {
+		t.Errorf("NewBatchEnvelope() = %+v", env)
+	}
+}
+
+func TestEnvelopeJSON(t *testing.T) {
+	data := EnvelopeJSON(NewPDFError("reading", "a.pdf", ErrFileNotFound))
+	var got map[string]map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("EnvelopeJSON() is not JSON: %v\n%s", err, data)
+	}
+	e := got["error"]
+	if e["operation"] != "reading" || e["file"] != "a.pdf" || e["cause"] != "file not found" || e["code"] != float64(ExitFileNotFound) {
+		t.Errorf("EnvelopeJSON() = %s", data)
+	}
+	if _, ok := e["hint"]; ok {
+		t.Errorf("EnvelopeJSON() should omit an empty hint: %s", data)
+	}
+}